@@ -0,0 +1,42 @@
+package config
+
+import "fmt"
+
+// LatencyBudget is the maximum acceptable inference latency for one model
+// A zero budget means that input size is not gated
+type LatencyBudget struct {
+	SmallMs int64 `json:"small"`
+	LargeMs int64 `json:"large"`
+}
+
+// LoadLatencyBudgets reads per-model latency budgets from a JSON file keyed by model name, e.g.
+//
+//	{"gpt2": {"small": 200, "large": 500}}
+//
+// models are the names in the model matrix; a budget for any other name is an error.
+func (c *Config) LoadLatencyBudgets(path string, models []string) error {
+	budgets, err := loadPerModel[LatencyBudget](path, "latency budgets", models)
+	if err != nil {
+		return err
+	}
+	for name, budget := range budgets {
+		if budget.SmallMs < 0 || budget.LargeMs < 0 {
+			return fmt.Errorf("invalid latency budget for %s: budgets must not be negative", name)
+		}
+	}
+
+	c.LatencyBudgets = budgets
+	return nil
+}
+
+// LatencyBudgetFor returns the budget in ms for a model's small or large inference (0 if none)
+func (c *Config) LatencyBudgetFor(modelName string, large bool) int64 {
+	budget, ok := c.LatencyBudgets[modelName]
+	if !ok {
+		return 0
+	}
+	if large {
+		return budget.LargeMs
+	}
+	return budget.SmallMs
+}
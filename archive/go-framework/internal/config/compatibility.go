@@ -0,0 +1,161 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// compatibilityJSON is the Axon/Core compatibility matrix: a list of compatibilityRule.
+// Add a rule whenever an Axon release's output stops loading on some Core releases (or the reverse).
+//
+//go:embed compatibility.json
+var compatibilityJSON []byte
+
+// Levels of a compatibility rule
+// Both current rules only break some models, so they warn; fail is for a pair where nothing can pass.
+const (
+	compatWarn = "warn" // Some models won't work; the run is still worth doing
+	compatFail = "fail" // Nothing the converter produces will load; config.New refuses the pair
+)
+
+// compatibilityRule marks the Axon versions matching Axon as incompatible with the Core versions matching Core
+// Constraints are a version, optionally prefixed by =, <, <=, > or >= (e.g. ">=v3.1.9"); empty matches any.
+type compatibilityRule struct {
+	Axon   string `json:"axon"`
+	Core   string `json:"core"`
+	Level  string `json:"level"`
+	Reason string `json:"reason"`
+}
+
+// compatibilityRules parses the embedded matrix
+func compatibilityRules() ([]compatibilityRule, error) {
+	var rules []compatibilityRule
+	if err := json.Unmarshal(compatibilityJSON, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse compatibility matrix: %w", err)
+	}
+	for _, rule := range rules {
+		if rule.Level != compatWarn && rule.Level != compatFail {
+			return nil, fmt.Errorf("invalid compatibility rule level %q for Axon %q, Core %q", rule.Level, rule.Axon, rule.Core)
+		}
+	}
+	return rules, nil
+}
+
+// checkCompatibility warns about, or refuses, an Axon/Core pair the matrix lists
+// Rules at the fail level only warn when ignore (--ignore-compat) is set.
+func checkCompatibility(axonVersion, coreVersion string, ignore bool) error {
+	rules, err := compatibilityRules()
+	if err != nil {
+		return err
+	}
+	return applyCompatibility(rules, axonVersion, coreVersion, ignore)
+}
+
+// applyCompatibility is checkCompatibility against a given matrix
+func applyCompatibility(rules []compatibilityRule, axonVersion, coreVersion string, ignore bool) error {
+	for _, rule := range incompatibilities(rules, axonVersion, coreVersion) {
+		switch {
+		case rule.Level == compatWarn:
+			log.Printf("WARN: Axon %s and Core %s are partly incompatible: %s", axonVersion, coreVersion, rule.Reason)
+		case ignore:
+			log.Printf("WARN: Axon %s and Core %s are known to be incompatible: %s (continuing because --ignore-compat was passed)", axonVersion, coreVersion, rule.Reason)
+		default:
+			return fmt.Errorf("Axon %s and Core %s are known to be incompatible: %s (pass --ignore-compat to run anyway)", axonVersion, coreVersion, rule.Reason)
+		}
+	}
+	return nil
+}
+
+// incompatibilities returns the rules of the matrix an Axon/Core pair matches
+// Versions that aren't vMAJOR.MINOR.PATCH tags (e.g. "latest") match no constrained rule.
+func incompatibilities(rules []compatibilityRule, axonVersion, coreVersion string) []compatibilityRule {
+	var matched []compatibilityRule
+	for _, rule := range rules {
+		if versionMatches(rule.Axon, axonVersion) && versionMatches(rule.Core, coreVersion) {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
+
+// versionMatches reports whether version satisfies a constraint such as ">=v3.1.9"
+func versionMatches(constraint, version string) bool {
+	constraint = strings.TrimSpace(constraint)
+	if constraint == "" {
+		return true
+	}
+	op := ""
+	for _, candidate := range []string{"<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(constraint, candidate) {
+			op = candidate
+			break
+		}
+	}
+	cmp, ok := compareVersions(version, strings.TrimSpace(strings.TrimPrefix(constraint, op)))
+	if !ok {
+		return false
+	}
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "", "=":
+		return cmp == 0
+	}
+	return false
+}
+
+// compareVersions orders two release tags like v3.1.9 or v6.2.0-alpha, returning -1, 0 or 1
+// As in semver, a pre-release sorts before its release. ok is false if either isn't a tag.
+func compareVersions(a, b string) (int, bool) {
+	aParts, aPre, aOK := parseVersion(a)
+	bParts, bPre, bOK := parseVersion(b)
+	if !aOK || !bOK {
+		return 0, false
+	}
+	for i := range aParts {
+		if aParts[i] != bParts[i] {
+			if aParts[i] < bParts[i] {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	switch {
+	case aPre == bPre:
+		return 0, true
+	case aPre == "":
+		return 1, true
+	case bPre == "":
+		return -1, true
+	}
+	return strings.Compare(aPre, bPre), true
+}
+
+// parseVersion splits a tag like v6.2.0-alpha into its numbers and pre-release suffix
+// Missing minor and patch numbers count as 0.
+func parseVersion(version string) ([3]int, string, bool) {
+	var parts [3]int
+	core, pre, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(version), "v"), "-")
+	fields := strings.Split(core, ".")
+	if len(fields) > len(parts) {
+		return parts, "", false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, "", false
+		}
+		parts[i] = n
+	}
+	return parts, pre, true
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVersionMatches(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"", "latest", true},
+		{">=v3.1.6", "v3.1.6", true},
+		{">=v3.1.6", "3.1.9", true}, // Tags without the v
+		{">=v3.1.6", "v3.1.5", false},
+		{"<v3.2.10-alpha", "v3.2.9-alpha", true},
+		{"<v3.2.10-alpha", "v3.2.10-alpha", false},
+		{"<v3.2.10-alpha", "v3.2.10", false},     // A release sorts after its pre-release
+		{"<v3.2.10-alpha", "v3.2.2-alpha", true}, // Numbers compare numerically, not as text
+		{"<=v3.2.0", "v3.2", true},
+		{">v7.0.0", "v7.0.0", false},
+		{"v3.2.0-alpha", "v3.2.0-alpha", true},
+		{"=v3.2.0-alpha", "v3.2.0-beta", false},
+		{">=v3.1.6", "latest", false},
+		{">=v3.1.6", "v3.1.6.1", false},
+	}
+	for _, tt := range tests {
+		if got := versionMatches(tt.constraint, tt.version); got != tt.want {
+			t.Errorf("versionMatches(%q, %q) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestApplyCompatibilityLevels(t *testing.T) {
+	rules := []compatibilityRule{
+		{Axon: ">=v3.1.6", Core: "<v3.2.10-alpha", Level: compatWarn, Reason: "LLMs won't load"},
+		{Axon: ">=v4.0.0", Core: "<v7.0.0", Level: compatFail, Reason: "new model format"},
+	}
+	tests := []struct {
+		name    string
+		axon    string
+		core    string
+		ignore  bool
+		wantErr string // "" if the pair may run
+	}{
+		{"compatible", "v3.1.9", "v7.0.0", false, ""},
+		{"warn rule only warns", "v3.1.9", "v3.2.9-alpha", false, ""},
+		{"fail rule refuses", "v4.0.0", "v6.2.0-alpha", false, "new model format (pass --ignore-compat"},
+		{"fail rule ignored", "v4.0.0", "v6.2.0-alpha", true, ""},
+		{"untagged versions match no rule", "latest", "latest", false, ""},
+	}
+	for _, tt := range tests {
+		err := applyCompatibility(rules, tt.axon, tt.core, tt.ignore)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCompatibilityMatrixMatchesModelsYAML(t *testing.T) {
+	rules, err := compatibilityRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// config/models.yaml: GGUF LLMs need Core v3.2.10-alpha+ and Axon v3.1.6+
+	tests := []struct {
+		axon, core string
+		want       bool
+	}{
+		{"v3.1.9", "v3.2.9-alpha", true},
+		{"v3.1.9", "v3.2.10-alpha", false},
+		{"v3.1.9", "v7.0.0", false},
+	}
+	for _, tt := range tests {
+		matched := false
+		for _, rule := range incompatibilities(rules, tt.axon, tt.core) {
+			if strings.Contains(rule.Reason, "GGUF") {
+				matched = true
+			}
+		}
+		if matched != tt.want {
+			t.Errorf("GGUF rule matches Axon %s, Core %s = %v, want %v", tt.axon, tt.core, matched, tt.want)
+		}
+	}
+}
@@ -2,9 +2,20 @@ package config
 
 import (
 	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/criteria"
+	"github.com/mlOS-foundation/system-test/internal/release"
 )
 
 // Config holds all configuration for E2E tests
@@ -13,20 +24,172 @@ type Config struct {
 	CoreVersion   string
 	OutputDir     string
 	TestAllModels bool
-	MinimalTest   bool // Only test one small model (distilgpt2) for smoke testing
-	SkipInstall   bool
-	Verbose       bool
-	CorePort      int // HTTP port for MLOS Core (default: 18080, non-privileged)
+	MinimalTest   bool     // Only test one small model (distilgpt2) for smoke testing
+	Models        []string // Only test these models (name or full ID); empty means all
+	Categories    []string // Only test these categories (nlp, vision, multimodal); empty means all
+
+	// Categories installed and registered but never inferred, to validate the install/convert path on its own
+	InstallOnlyCategories []string
+
+	RequireCategories  []string // Fail the run if any of these categories has no tested model
+	MinSuccessRate     float64  // Fail the run below this inference success rate, in percent (default: 100)
+	MinPassingModels   int      // Fail the run with fewer models whose every inference test passed (0: no minimum)
+	SuccessCriteria    string   // Gate expression replacing MinSuccessRate/MinPassingModels (see SetSuccessCriteria)
+	SkipInstall        bool
+	Verbose            bool
+	Progress           bool     // Prefix each step with an overall [done/total] counter (interactive terminals only)
+	Force              bool     // Run even if CoreVersion is on the known-broken list
+	IgnoreCompat       bool     // Run even if the compatibility matrix says this Axon/Core pair can't work
+	CorePort           int      // HTTP port for MLOS Core (default: 18080, non-privileged)
+	Host               string   // Host Core is reached at (default: 127.0.0.1; may be ::1 or a hostname)
+	ExternalCoreURL    string   // Test an already-running Core at this URL instead of downloading/starting one
+	BasePath           string   // Prefix of every Core route, for a Core behind a path-rewriting proxy (see SetBasePath)
+	InsecureSkipVerify bool     // Accept self-signed TLS certificates from an https:// Core
+	StrictHealth       bool     // Require /health to return 200 with HealthBody instead of any HTTP response
+	HealthBody         string   // Body --strict-health expects (JSON matches by field; empty accepts any 200)
+	StrictVersions     bool     // Reinstall, then fail, if the installed Axon isn't AxonVersion instead of warning
+	CoreInstances      int      // Core instances to start; extras get free ports after CorePort (isolation tests when > 1)
+	AutoRestartCore    bool     // Restart Core (and re-register models) if it dies mid-suite
+	MaxCoreRestarts    int      // Upper bound on watchdog restarts per run (default: 3)
+	PipelineRetries    int      // Rerun the whole pipeline this many times if downloading releases or starting Core fails (see test.RunWithRetries)
+	Repeat             int      // Run the whole pipeline this many times and report per-model flakiness (<= 1: once)
+	OTLPEndpoint       string   // OTLP/HTTP traces URL pipeline spans are exported to (empty: no tracing; see SetOTLPEndpoint)
+	CoreDockerImage    string   // Image Core runs in when CORE_IN_DOCKER=true (empty: release.DefaultCoreDockerImage)
+	CoreBinary         string   // Locally built Core to run instead of downloading the release (see SetCoreBinary)
+	AxonCacheDir       string   // Axon cache root (default: $AXON_CACHE_DIR, else ~/.axon/cache)
+	ReleaseMirror      string   // Base URL or local directory mirroring github.com release downloads (empty: GitHub)
+	ModelFilenames     []string // Accepted ONNX filenames/patterns in preference order (nil: model.DefaultModelFilenames)
+	AxonErrorPrefixes  []string // Axon output lines starting with these fail an install/register (nil: model.DefaultAxonErrorPrefixes)
+	StrictAxonOutput   bool     // Fail on "error"/"failed" anywhere in Axon output instead of only prefixed lines
+	ConverterDigest    string   // Pin Axon's converter image to this sha256: digest (empty: the release's version tag)
+	BatchSize          int      // Examples per batched inference request (<= 1 disables the batch test)
+	BatchSweepSizes    []int    // Batch sizes for the throughput scaling sweep (nil disables; see DefaultBatchSweepSizes)
+
+	MaxParallelInference int // Inference tests in flight at once, across models and sizes (default: 1, serial)
+	RegisterConcurrency  int // Model registrations in flight at once (default: 1, serial; > 1 exercises Core's concurrent loading)
+
+	MinMemoryMB int // Available memory required to start (0: DefaultMinMemoryMB, or AllModelsMinMemoryMB with TestAllModels; < 0 disables)
+
+	MeasureColdStart   bool // Time the first post-registration inference separately from warm ones
+	MeasureModelMemory bool // Sample Core RSS after each registration and attribute the growth to that model
+	NormalizeTimings   bool // Benchmark the machine at startup and report inference timings relative to it
+	MonitorThermal     bool // Watch for thermal throttling while inference and load monitoring run
+	StreamInference    bool // Also request a streamed inference per model and time its tokens
+	TestUnregister     bool // Unregister every model at the end and check Core no longer lists it
+	TestReregister     bool // Register every registered model a second time and check Core treats it as a no-op
+
+	InjectFailures []string // Failure injection scenarios to run (nil: off; see SetInjectFailures)
+
+	FuzzIterations int   // Randomized valid inputs sent per model (0 disables fuzzing)
+	Seed           int64 // Seed for randomized inputs (0 picks one; the seed used is recorded)
+
+	// Determinism check: each model's small input sent twice and the outputs compared (--check-determinism)
+	CheckDeterminism     bool
+	DeterminismTolerance float64 // Relative difference allowed between output numbers (0: identical; see SetDeterminismTolerance)
+
+	// Inference request timeouts (0 for the large timeout means use InferenceTimeout)
+	InferenceTimeout      time.Duration // Small (and repeated) inference requests (default: 30s)
+	LargeInferenceTimeout time.Duration // Large and batched inference requests
+
+	InputKey string // JSON key for the token IDs in inference requests (default: input_ids)
+	APIStyle string // Inference API to exercise: APIStyleNative (default) or APIStyleOpenAI (see SetAPIStyle)
+
+	InferencePathTemplate string // Native inference route with a {model} placeholder (empty: model.DefaultInferencePathTemplate)
+
+	// Extra inference request headers, e.g. auth or routing (see AddInferenceHeader, LoadModelHeaders)
+	InferenceHeaders map[string]string            // Sent with every inference request
+	ModelHeaders     map[string]map[string]string // model_name -> headers, overriding InferenceHeaders
+
+	// Human-readable inference input (empty uses the built-in token IDs)
+	Prompt        string // Text tokenized into the small input (repeated for the large one)
+	TokenizerPath string // Tokenizer for Prompt (empty: tokenizer.json/vocab.txt/vocab.json next to each model)
+
+	// Request compression (Core must accept Content-Encoding: gzip)
+	GzipRequests       bool // Gzip inference request bodies of at least GzipThresholdBytes
+	GzipThresholdBytes int  // Smallest body worth compressing (default: 64 KiB)
+
+	VerboseHTTP bool // Print every inference request and response in full, credentials redacted (live only; see RecordPath to save them)
+
+	// Inference session capture (see model.Session)
+	RecordPath string // Write every inference request and response of the run to this file (empty: off)
+	ReplayPath string // Resend the requests of a recorded session and diff the responses (empty: off)
+
+	LatencyBudgets map[string]LatencyBudget // model_name -> latency gate (see LoadLatencyBudgets)
+	InferenceSizes map[string][]string      // model_name -> sizes to run (see LoadInferenceSizes; unlisted: both)
+
+	ModelExpectations map[string]ModelExpectation // model_name -> ONNX inputs/outputs/metadata to check after install (see LoadModelExpectations)
+
+	ExpectedStatuses map[string]int // model_name -> HTTP status its inference tests must get (see LoadExpectedStatuses; unlisted: 200)
+
+	// Core pprof capture under sustained load (a Core without /debug/pprof is skipped)
+	Profile        bool // Save Core's CPU and heap profiles next to the report (--profile)
+	ProfileSeconds int  // CPU profile length (default: 10)
+
+	// Rolling inference: each passing model's small input sent repeatedly, every latency kept (--record-timeseries)
+	RecordTimeseries     bool
+	TimeseriesIterations int // Requests per model (default: 50)
+
+	// Memory stability check (0 iterations disables it)
+	LeakCheckIterations int     // Inference rounds between the before/after RSS samples
+	LeakThresholdMB     float64 // RSS growth above this is flagged as a potential leak
+
+	// Report
+	OutputFormat       string // ReportFormatHTML (default), ReportFormatJSON or ReportFormatMarkdown (see SetOutputFormat)
+	OutputStdout       bool   // Write the report to stdout instead of ReportPath (see SetOutputStdout)
+	ReportTemplatePath string // Custom HTML template for the report (empty uses the embedded one)
+	ValidateMetrics    bool   // Check the written metrics.json against the embedded results schema
+	SQLitePath         string // Append the run's summary and per-model metrics to this SQLite database (empty: off)
+
+	// Disk cleanup once the report is written (see Runner.Cleanup)
+	Cleanup       bool // Remove the downloaded Core/ONNX Runtime artifacts from OutputDir
+	CleanupModels bool // Also purge the models from Axon's cache
+	CleanupTemp   bool // Remove the temporary files the run left behind (they are reported either way)
+
+	// Force-remove axon-converter containers found before and after the installs
+	// (otherwise they are only reported); other runs sharing the Docker daemon lose theirs too
+	CleanupContainers bool
+
+	// Harness build that produced the results (see NewHarnessBuild; main sets it from its ldflags)
+	Harness HarnessBuild
 
-	// Derived paths
-	TestDir     string
-	ReportPath  string
-	LogPath     string
-	MetricsPath string
+	// Where the report goes with --output-stdout (os.Stdout)
+	ReportStdout io.Writer
+
+	// Where the run's progress messages and the output of the commands it runs go
+	// (nil: os.Stdout; os.Stderr with --output-stdout, so stdout carries only the report)
+	Output io.Writer
+
+	// Derived paths; only the report is named per run (see ReportFileName)
+	TestDir      string
+	ReportPath   string
+	LogPath      string
+	MetricsPath  string
+	ManifestPath string
 }
 
+// Memory the run needs free before it starts: Core plus Axon's Docker converter (see MinMemoryRequiredMB)
+const (
+	DefaultMinMemoryMB   = 2048
+	AllModelsMinMemoryMB = 4096 // The extended models are larger to convert and load
+)
+
+// DefaultBatchSweepSizes is the batch-size set used by --batch-sweep when no sizes are given
+var DefaultBatchSweepSizes = []int{1, 2, 4, 8, 16}
+
 // New creates a new configuration
-func New(axonVersion, coreVersion, outputDir string, testAllModels, minimalTest, skipInstall, verbose bool) (*Config, error) {
+func New(axonVersion, coreVersion, outputDir string, testAllModels, minimalTest, skipInstall, verbose, force, ignoreCompat bool) (*Config, error) {
+	// Refuse to waste a run on a Core release already known to fail
+	if reason := knownBrokenReason(coreVersion); reason != "" {
+		if !force {
+			return nil, fmt.Errorf("Core %s is known to be broken: %s (pass --force to run anyway)", coreVersion, reason)
+		}
+		log.Printf("WARN: Core %s is known to be broken: %s (continuing because --force was passed)", coreVersion, reason)
+	}
+	// Likewise for a pair whose converter output this Core can't load (see compatibility.json)
+	if err := checkCompatibility(axonVersion, coreVersion, ignoreCompat); err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{
 		AxonVersion:   axonVersion,
 		CoreVersion:   coreVersion,
@@ -34,13 +197,33 @@ func New(axonVersion, coreVersion, outputDir string, testAllModels, minimalTest,
 		MinimalTest:   minimalTest,
 		SkipInstall:   skipInstall,
 		Verbose:       verbose,
-		CorePort:      18080, // Use non-privileged port to avoid sudo requirement
+		Force:         force,
+		IgnoreCompat:  ignoreCompat,
+		AxonCacheDir:  os.Getenv("AXON_CACHE_DIR"), // Empty keeps Axon's default
+		CorePort:      18080,                       // Use non-privileged port to avoid sudo requirement
+		Host:          "127.0.0.1",                 // Explicit IPv4 avoids IPv6 resolution issues in CI
+
+		MinSuccessRate: 100,
+
+		CoreInstances:   1,
+		MaxCoreRestarts: 3,
+		HealthBody:      release.DefaultHealthBody,
+
+		LeakThresholdMB:  50,
+		ProfileSeconds:   10,
+		InferenceTimeout: 30 * time.Second,
+		InputKey:         "input_ids", // Current Core API; older/newer builds may expect "inputs"
+
+		GzipThresholdBytes: 64 * 1024,
+
+		MaxParallelInference: 1,
+		RegisterConcurrency:  1,
+		TimeseriesIterations: 50,
 	}
 
-	// Set output directory
+	// Set output directory (callers with --output-name-template pass OutputDirName's result)
 	if outputDir == "" {
-		timestamp := time.Now().Unix()
-		outputDir = fmt.Sprintf("e2e-results-%d", timestamp)
+		outputDir = OutputDirName("", axonVersion, coreVersion)
 	}
 	cfg.OutputDir = outputDir
 
@@ -51,9 +234,239 @@ func New(axonVersion, coreVersion, outputDir string, testAllModels, minimalTest,
 
 	// Set derived paths
 	cfg.TestDir = outputDir
-	cfg.ReportPath = filepath.Join(outputDir, "release-validation-report.html")
+	cfg.ReportPath = filepath.Join(outputDir, ReportFileName(outputDir, axonVersion, coreVersion, reportExtensions[ReportFormatHTML]))
 	cfg.LogPath = filepath.Join(outputDir, "test.log")
 	cfg.MetricsPath = filepath.Join(outputDir, "metrics.json")
+	cfg.ManifestPath = filepath.Join(outputDir, "model-manifest.json")
 
 	return cfg, nil
 }
+
+// CoreURL returns the base URL of the Core HTTP API (IPv6 hosts are bracketed)
+func (c *Config) CoreURL() string {
+	if c.ExternalCoreURL != "" {
+		coreURL := strings.TrimRight(c.ExternalCoreURL, "/")
+		if !strings.Contains(coreURL, "://") {
+			coreURL = "http://" + coreURL // A bare host:port means plain HTTP
+		}
+		return coreURL + c.BasePath
+	}
+	return "http://" + net.JoinHostPort(c.Host, strconv.Itoa(c.CorePort)) + c.BasePath
+}
+
+// ValidateCoreURL checks that an external Core URL is a usable http:// or https:// URL
+func (c *Config) ValidateCoreURL() error {
+	if !c.UsesExternalCore() {
+		return nil
+	}
+	u, err := url.Parse(c.CoreURL())
+	if err != nil {
+		return fmt.Errorf("invalid Core URL %q: %w", c.ExternalCoreURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid Core URL %q: scheme must be http or https", c.ExternalCoreURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid Core URL %q: missing host", c.ExternalCoreURL)
+	}
+	return nil
+}
+
+// InstallOnly reports whether a category's models are installed but not inferred (--install-only-categories)
+func (c *Config) InstallOnly(category string) bool {
+	for _, installOnly := range c.InstallOnlyCategories {
+		if installOnly == category {
+			return true
+		}
+	}
+	return false
+}
+
+// URLForPort returns the base URL of a Core instance this run started on port
+func (c *Config) URLForPort(port int) string {
+	return "http://" + net.JoinHostPort(c.Host, strconv.Itoa(port)) + c.BasePath
+}
+
+// InferenceTimeoutFor returns the request timeout for small or large inference
+func (c *Config) InferenceTimeoutFor(large bool) time.Duration {
+	if large && c.LargeInferenceTimeout > 0 {
+		return c.LargeInferenceTimeout
+	}
+	return c.InferenceTimeout
+}
+
+// MinMemoryRequiredMB returns the available memory the run needs before it starts (0: no check)
+func (c *Config) MinMemoryRequiredMB() int {
+	switch {
+	case c.MinMemoryMB < 0:
+		return 0
+	case c.MinMemoryMB > 0:
+		return c.MinMemoryMB
+	case c.TestAllModels:
+		return AllModelsMinMemoryMB
+	default:
+		return DefaultMinMemoryMB
+	}
+}
+
+// UsesExternalCore reports whether the run targets a Core it did not start
+func (c *Config) UsesExternalCore() bool {
+	return c.ExternalCoreURL != ""
+}
+
+// SetCoreBinary runs the Core binary at path instead of the release for CoreVersion (--core-binary)
+// The release is then not downloaded; CoreVersion only labels the report.
+func (c *Config) SetCoreBinary(path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("invalid --core-binary %s: %w", path, err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return fmt.Errorf("invalid --core-binary: %w", err)
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("invalid --core-binary: %s is not an executable file", abs)
+	}
+	c.CoreBinary = abs
+	return nil
+}
+
+// converterDigestPattern matches an image digest; the algorithm prefix is required
+var converterDigestPattern = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// SetConverterDigest pins Axon's converter image to an immutable digest (--converter-digest)
+// Accepts "sha256:<hex>" with or without a leading "@" or "<repo>@".
+func (c *Config) SetConverterDigest(spec string) error {
+	digest := strings.ToLower(strings.TrimSpace(spec))
+	if i := strings.LastIndex(digest, "@"); i >= 0 {
+		digest = digest[i+1:]
+	}
+	if !converterDigestPattern.MatchString(digest) {
+		return fmt.Errorf("invalid converter digest %q (want sha256:<64 hex digits>)", spec)
+	}
+	c.ConverterDigest = digest
+	return nil
+}
+
+// Inference API styles (--api-style)
+const (
+	APIStyleNative = "native" // Core's own /models/{id}/inference route
+	APIStyleOpenAI = "openai" // Core's OpenAI-compatible /v1/completions and /v1/chat/completions routes
+)
+
+// SetAPIStyle selects which of Core's inference APIs the inference tests exercise (--api-style)
+func (c *Config) SetAPIStyle(style string) error {
+	switch style {
+	case APIStyleNative, APIStyleOpenAI:
+		c.APIStyle = style
+		return nil
+	}
+	return fmt.Errorf("invalid API style %q (use %q or %q)", style, APIStyleNative, APIStyleOpenAI)
+}
+
+// SetBasePath prefixes every Core route with path, e.g. "/mlos" for a Core behind an ingress (--base-path)
+// Health checks, registration and inference all go through CoreURL, which ends with it. Slashes are
+// normalized: "mlos/" and "/mlos" are the same prefix, and "/" is none.
+func (c *Config) SetBasePath(path string) error {
+	if strings.ContainsAny(path, "?#") || strings.Contains(path, "://") {
+		return fmt.Errorf("invalid --base-path %q: must be a URL path such as /mlos", path)
+	}
+	path = strings.Trim(path, "/")
+	if path != "" {
+		path = "/" + path
+	}
+	c.BasePath = path
+	return nil
+}
+
+// SetInferencePathTemplate sends native inference requests to another Core route (--inference-path-template)
+// template is a path with a {model} placeholder for the model ID, e.g. "/v1/models/{model}/predict".
+func (c *Config) SetInferencePathTemplate(template string) error {
+	if !strings.HasPrefix(template, "/") || !strings.Contains(template, "{model}") {
+		return fmt.Errorf("invalid --inference-path-template %q: must be a path starting with / and containing {model}", template)
+	}
+	c.InferencePathTemplate = template
+	return nil
+}
+
+// SetDeterminismTolerance sets how far apart output numbers may be for --check-determinism (--determinism-tolerance)
+// The tolerance is relative to the larger magnitude, e.g. 1e-6; 0 requires identical outputs.
+func (c *Config) SetDeterminismTolerance(tolerance float64) error {
+	if tolerance < 0 || tolerance >= 1 || math.IsNaN(tolerance) {
+		return fmt.Errorf("invalid --determinism-tolerance %g: must be at least 0 and below 1", tolerance)
+	}
+	c.DeterminismTolerance = tolerance
+	return nil
+}
+
+// SetOTLPEndpoint exports the pipeline's stage and inference spans to an OTLP/HTTP collector (--otlp-endpoint)
+// endpoint is the collector's base URL, e.g. http://localhost:4318; the standard /v1/traces path is
+// added when it has no path of its own.
+func (c *Config) SetOTLPEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid --otlp-endpoint %q: must be an http:// or https:// URL", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/v1/traces"
+	}
+	c.OTLPEndpoint = u.String()
+	return nil
+}
+
+// Report formats (--output-format)
+const (
+	ReportFormatHTML     = "html"
+	ReportFormatJSON     = "json"     // The results as written to metrics.json
+	ReportFormatMarkdown = "markdown" // Summary, inference results and failures as Markdown tables
+)
+
+// reportExtensions are the report file extensions of each format
+var reportExtensions = map[string]string{
+	ReportFormatHTML:     ".html",
+	ReportFormatJSON:     ".json",
+	ReportFormatMarkdown: ".md",
+}
+
+// SetOutputFormat selects the report format (--output-format) and renames the report to match
+func (c *Config) SetOutputFormat(format string) error {
+	ext, ok := reportExtensions[format]
+	if !ok {
+		return fmt.Errorf("invalid output format %q (use %q, %q or %q)", format, ReportFormatHTML, ReportFormatJSON, ReportFormatMarkdown)
+	}
+	if c.OutputStdout && format == ReportFormatHTML {
+		return fmt.Errorf("--output-stdout needs --output-format %s or %s", ReportFormatJSON, ReportFormatMarkdown)
+	}
+	c.OutputFormat = format
+	c.ReportPath = filepath.Join(c.OutputDir, ReportFileName(c.OutputDir, c.AxonVersion, c.CoreVersion, ext))
+	return nil
+}
+
+// SetOutputStdout writes the report to stdout instead of a file (--output-stdout)
+// HTML needs its JavaScript next to it, so the format defaults to JSON. stdout then carries
+// only the report: everything else the run prints, including the output of the commands
+// it runs, goes to Output, which this points at stderr. Call it before the run starts.
+func (c *Config) SetOutputStdout() error {
+	switch c.OutputFormat {
+	case "":
+		c.OutputFormat = ReportFormatJSON
+	case ReportFormatHTML:
+		return fmt.Errorf("--output-stdout needs --output-format %s or %s", ReportFormatJSON, ReportFormatMarkdown)
+	}
+	c.OutputStdout = true
+	c.ReportStdout = os.Stdout
+	c.Output = os.Stderr
+	return nil
+}
+
+// SetSuccessCriteria makes an expression over the run's metrics the pass/fail gate (--success-criteria)
+// It replaces --min-success-rate and --min-passing-models; test.CriteriaVariables lists the variables.
+// Only the syntax is checked here; unknown variables fail the gate when it is evaluated.
+func (c *Config) SetSuccessCriteria(expr string) error {
+	if _, err := criteria.Parse(expr); err != nil {
+		return fmt.Errorf("invalid success criteria %q: %w", expr, err)
+	}
+	c.SuccessCriteria = expr
+	return nil
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// EnvVar is an environment variable known to change how a run behaves
+type EnvVar struct {
+	Name   string
+	Effect string
+}
+
+// RecognizedEnvVars lists every environment variable that changes the run's behavior
+// It is the one place to add a variable so it shows up in results and the report.
+var RecognizedEnvVars = []EnvVar{
+	{"AXON_CACHE_DIR", "Axon model cache location"},
+	{"FORCE_CORE_PLATFORM", "Core/ONNX Runtime platform to download instead of the host's"},
+	{"CORE_IN_DOCKER", "Runs Core in a Linux Docker container"},
+	{"LD_LIBRARY_PATH", "Extra library search path for Core (Linux)"},
+	{"DYLD_LIBRARY_PATH", "Extra library search path for Core (macOS)"},
+	{"MLOS_CORE_ENDPOINT", "Core endpoint axon uses outside of register"},
+	{"GITHUB_TOKEN", "GitHub authentication for gh release downloads"},
+	{"GH_TOKEN", "GitHub authentication for gh release downloads"},
+	{"HF_TOKEN", "Hugging Face authentication for gated models"},
+	{"HUGGING_FACE_HUB_TOKEN", "Hugging Face authentication for gated models"},
+	{"HTTP_PROXY", "Proxy for HTTP downloads and requests"},
+	{"HTTPS_PROXY", "Proxy for HTTPS downloads and requests"},
+	{"NO_PROXY", "Hosts that bypass the proxy"},
+	{"ALL_PROXY", "Proxy for all curl downloads"},
+	{"http_proxy", "Proxy for HTTP downloads and requests"},
+	{"https_proxy", "Proxy for HTTPS downloads and requests"},
+	{"no_proxy", "Hosts that bypass the proxy"},
+	{"all_proxy", "Proxy for all curl downloads"},
+}
+
+// EnvironmentSummary returns the recognized environment variables that are set, with secrets redacted
+func EnvironmentSummary() map[string]string {
+	summary := make(map[string]string)
+	for _, v := range RecognizedEnvVars {
+		if value, ok := os.LookupEnv(v.Name); ok {
+			summary[v.Name] = release.RedactValue(v.Name, value)
+		}
+	}
+	return summary
+}
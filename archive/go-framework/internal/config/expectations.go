@@ -0,0 +1,31 @@
+package config
+
+import "fmt"
+
+// ModelExpectation is what an installed model's ONNX graph must declare (see LoadModelExpectations)
+// Empty fields are not checked.
+type ModelExpectation struct {
+	Inputs   []string          `json:"inputs"`   // Graph input names, in any order
+	Outputs  []string          `json:"outputs"`  // Graph output names, in any order
+	Metadata map[string]string `json:"metadata"` // metadata_props entries that must be present with these values
+}
+
+// LoadModelExpectations reads per-model metadata expectations from a JSON file keyed by model name, e.g.
+//
+//	{"bert": {"inputs": ["input_ids", "attention_mask", "token_type_ids"], "metadata": {"license": "apache-2.0"}}}
+//
+// Models not listed are not checked; names that are not in models, the model matrix, are an error.
+func (c *Config) LoadModelExpectations(path string, models []string) error {
+	expectations, err := loadPerModel[ModelExpectation](path, "model expectations", models)
+	if err != nil {
+		return err
+	}
+	for name, e := range expectations {
+		if len(e.Inputs) == 0 && len(e.Outputs) == 0 && len(e.Metadata) == 0 {
+			return fmt.Errorf("invalid model expectations for %s: set at least one of inputs, outputs or metadata", name)
+		}
+	}
+
+	c.ModelExpectations = expectations
+	return nil
+}
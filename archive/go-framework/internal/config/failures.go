@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Failure injection scenarios (--inject-failures)
+const (
+	FailureWrongPort        = "wrong-port"        // Send inference to a port nothing listens on
+	FailureMalformedPayload = "malformed-payload" // Send invalid request bodies and expect 4xx, not a crash
+	FailureKillCore         = "kill-core"         // Kill Core mid-inference, check the harness notices and cleans up
+)
+
+// FailureScenarios lists the injection scenarios in the order they run (kill-core last: it takes Core down)
+var FailureScenarios = []string{FailureWrongPort, FailureMalformedPayload, FailureKillCore}
+
+// SetInjectFailures enables failure injection from a comma-separated list of scenarios, or "all"
+// Failure injection deliberately breaks the run's Core, so it is only ever on when asked for.
+func (c *Config) SetInjectFailures(spec string) error {
+	var scenarios []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+			continue
+		case name == "all":
+			scenarios = append(scenarios, FailureScenarios...)
+		case isFailureScenario(name):
+			scenarios = append(scenarios, name)
+		default:
+			return fmt.Errorf("unknown failure scenario %q (want %s or all)", name, strings.Join(FailureScenarios, ", "))
+		}
+	}
+	if len(scenarios) == 0 {
+		return fmt.Errorf("no failure scenarios in %q", spec)
+	}
+
+	// Keep FailureScenarios order and drop duplicates
+	c.InjectFailures = nil
+	for _, name := range FailureScenarios {
+		for _, s := range scenarios {
+			if s == name {
+				c.InjectFailures = append(c.InjectFailures, name)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// InjectsFailure reports whether a failure injection scenario is enabled
+func (c *Config) InjectsFailure(scenario string) bool {
+	for _, s := range c.InjectFailures {
+		if s == scenario {
+			return true
+		}
+	}
+	return false
+}
+
+func isFailureScenario(name string) bool {
+	for _, s := range FailureScenarios {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
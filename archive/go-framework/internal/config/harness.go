@@ -0,0 +1,88 @@
+package config
+
+import (
+	"runtime/debug"
+	"strings"
+)
+
+// HarnessBuild identifies the build of this test harness, so results can be traced to the
+// harness revision that measured them
+type HarnessBuild struct {
+	Version string // Release version (empty for untagged builds)
+	Commit  string // Git commit the harness was built from ("-dirty" if it had local changes)
+	Date    string // Build or commit time
+}
+
+// NewHarnessBuild records the harness build from main's version/commit/date ldflags
+// Unset ldflags (empty or their "dev"/"none"/"unknown" placeholders) fall back to the VCS
+// information the Go toolchain embeds, so `go build` and `go run` binaries are still traceable.
+func NewHarnessBuild(version, commit, date string) HarnessBuild {
+	b := HarnessBuild{Version: ldflag(version), Commit: ldflag(commit), Date: ldflag(date)}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return b
+	}
+	if b.Version == "" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		b.Version = info.Main.Version
+	}
+	var revision, vcsTime string
+	modified := false
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.time":
+			vcsTime = s.Value
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	if b.Commit == "" && revision != "" {
+		b.Commit = revision
+		if modified {
+			b.Commit += "-dirty"
+		}
+	}
+	if b.Date == "" {
+		b.Date = vcsTime
+	}
+	return b
+}
+
+// String renders the build for logs, e.g. "v1.4.0 (3f2c1ab, 2024-05-01T10:00:00Z)"
+func (b HarnessBuild) String() string {
+	version := b.Version
+	if version == "" {
+		version = "dev"
+	}
+	var details []string
+	if b.Commit != "" {
+		details = append(details, shortCommit(b.Commit))
+	}
+	if b.Date != "" {
+		details = append(details, b.Date)
+	}
+	if len(details) == 0 {
+		return version
+	}
+	return version + " (" + strings.Join(details, ", ") + ")"
+}
+
+// ldflag treats the placeholder values of unset version ldflags as empty
+func ldflag(value string) string {
+	switch value {
+	case "dev", "none", "unknown":
+		return ""
+	}
+	return value
+}
+
+// shortCommit abbreviates a commit hash to 7 characters, keeping any "-dirty" suffix
+func shortCommit(commit string) string {
+	hash := strings.TrimSuffix(commit, "-dirty")
+	if len(hash) <= 7 {
+		return commit
+	}
+	return hash[:7] + commit[len(hash):]
+}
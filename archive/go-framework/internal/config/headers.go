@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// AddInferenceHeader adds a header sent with every inference request from a key=value
+// spec (--inference-header, repeatable). A later value for the same header replaces the earlier one.
+func (c *Config) AddInferenceHeader(spec string) error {
+	key, value, ok := strings.Cut(spec, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("invalid inference header %q (want key=value)", spec)
+	}
+	if err := validateHeaderName(key); err != nil {
+		return err
+	}
+	if c.InferenceHeaders == nil {
+		c.InferenceHeaders = make(map[string]string)
+	}
+	c.InferenceHeaders[http.CanonicalHeaderKey(key)] = value
+	return nil
+}
+
+// LoadModelHeaders reads per-model inference headers from a JSON file keyed by model name, e.g.
+//
+//	{"gpt2": {"X-Model-Version": "3"}}
+//
+// models are the names in the model matrix; headers for any other name are an error.
+func (c *Config) LoadModelHeaders(path string, models []string) error {
+	raw, err := loadPerModel[map[string]string](path, "model headers", models)
+	if err != nil {
+		return err
+	}
+	headers := make(map[string]map[string]string, len(raw))
+	for name, modelHeaders := range raw {
+		headers[name] = make(map[string]string, len(modelHeaders))
+		for key, value := range modelHeaders {
+			if err := validateHeaderName(key); err != nil {
+				return fmt.Errorf("invalid header for %s: %w", name, err)
+			}
+			headers[name][http.CanonicalHeaderKey(key)] = value
+		}
+	}
+
+	c.ModelHeaders = headers
+	return nil
+}
+
+// validateHeaderName rejects names that are not HTTP tokens or that the harness sets itself
+func validateHeaderName(key string) error {
+	if strings.IndexFunc(key, func(r rune) bool {
+		return r <= ' ' || r >= 0x7f || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r)
+	}) >= 0 {
+		return fmt.Errorf("invalid header name %q", key)
+	}
+	switch http.CanonicalHeaderKey(key) {
+	case "Content-Type", "Content-Encoding", "Content-Length":
+		return fmt.Errorf("header %s is set by the harness and cannot be overridden", http.CanonicalHeaderKey(key))
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package config
+
+import "sort"
+
+// knownBrokenCoreVersions lists Core releases with known E2E-breaking bugs.
+// Maps a version constraint, as in compatibility.json (e.g. "v2.3.0" or "<v3.2.0-alpha"), to the
+// reason, including the issue link when there is one. Tags match with or without the leading v.
+// config.New refuses these versions unless --force is passed.
+var knownBrokenCoreVersions = map[string]string{
+	// config/models.yaml: large payloads (the 224x224 images and long prompts of the large tests) need v3.2.0-alpha+
+	"<v3.2.0-alpha": "predates large inference payload support (up to 16MB); every large inference test fails",
+}
+
+// knownBrokenReason returns why coreVersion is on the known-broken list, or "" if it isn't
+// Constraints are tried in sorted order, so the reason is the same from run to run.
+func knownBrokenReason(coreVersion string) string {
+	constraints := make([]string, 0, len(knownBrokenCoreVersions))
+	for constraint := range knownBrokenCoreVersions {
+		constraints = append(constraints, constraint)
+	}
+	sort.Strings(constraints)
+	for _, constraint := range constraints {
+		if constraint == coreVersion || versionMatches(constraint, coreVersion) {
+			return knownBrokenCoreVersions[constraint]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,31 @@
+package config
+
+import "testing"
+
+func TestKnownBrokenReason(t *testing.T) {
+	tests := []struct {
+		version string
+		broken  bool
+	}{
+		{"v3.1.6-alpha", true},
+		{"3.1.6-alpha", true}, // Workflows pass tags without the v
+		{"v3.2.0-alpha", false},
+		{"v3.2.10-alpha", false},
+		{"7.0.0-beta", false},
+		{"latest", false},
+	}
+	for _, tt := range tests {
+		if reason := knownBrokenReason(tt.version); (reason != "") != tt.broken {
+			t.Errorf("knownBrokenReason(%q) = %q, want broken = %v", tt.version, reason, tt.broken)
+		}
+	}
+}
+
+func TestNewRefusesKnownBrokenCore(t *testing.T) {
+	if _, err := New("v3.1.9", "v3.1.6-alpha", t.TempDir(), false, false, false, false, false, false); err == nil {
+		t.Fatal("New accepted a known-broken Core without --force")
+	}
+	if _, err := New("v3.1.9", "v3.1.6-alpha", t.TempDir(), false, false, false, false, true, false); err != nil {
+		t.Fatalf("New with --force: %v", err)
+	}
+}
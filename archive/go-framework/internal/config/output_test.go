@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetOutputStdoutKeepsProcessStdout(t *testing.T) {
+	stdout := os.Stdout
+	c := &Config{}
+	if err := c.SetOutputStdout(); err != nil {
+		t.Fatal(err)
+	}
+	if os.Stdout != stdout {
+		os.Stdout = stdout
+		t.Fatal("SetOutputStdout replaced os.Stdout")
+	}
+	if c.ReportStdout != os.Stdout {
+		t.Errorf("ReportStdout = %v, want os.Stdout", c.ReportStdout)
+	}
+	if c.Output != os.Stderr {
+		t.Errorf("Output = %v, want os.Stderr", c.Output)
+	}
+	if c.OutputFormat != ReportFormatJSON {
+		t.Errorf("OutputFormat = %q, want %q", c.OutputFormat, ReportFormatJSON)
+	}
+}
+
+func TestReportFileNameReturnsOnStatErrors(t *testing.T) {
+	// A file where the directory should be: every candidate fails with ENOTDIR, not "not exist"
+	notDir := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(notDir, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan string, 1)
+	go func() { done <- ReportFileName(notDir, "v3.1.9", "v3.2.10-alpha", ".html") }()
+	select {
+	case name := <-done:
+		if !strings.HasPrefix(name, "release-validation-report-v3.2.10-alpha-") || !strings.HasSuffix(name, ".html") {
+			t.Errorf("ReportFileName = %q", name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ReportFileName did not return for an unreadable directory")
+	}
+}
+
+func TestReportFileNameSkipsExistingReports(t *testing.T) {
+	dir := t.TempDir()
+	first := ReportFileName(dir, "v3.1.9", "v3.2.10-alpha", ".md")
+	if err := os.WriteFile(filepath.Join(dir, first), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if second := ReportFileName(dir, "v3.1.9", "v3.2.10-alpha", ".md"); second == first {
+		t.Errorf("ReportFileName returned the existing %s again", first)
+	}
+}
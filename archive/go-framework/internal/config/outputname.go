@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultOutputNameTemplate is the output directory name used when no template is given
+const DefaultOutputNameTemplate = "e2e-results-{timestamp}"
+
+// OutputNamePlaceholders lists the placeholders accepted by --output-name-template
+var OutputNamePlaceholders = []string{"{axon_version}", "{core_version}", "{date}", "{time}", "{timestamp}", "{git_sha}"}
+
+var (
+	placeholderPattern = regexp.MustCompile(`\{[^{}]*\}`)
+	unsafeNameChars    = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// OutputDirName expands an output directory name template such as "e2e-{core_version}-{date}"
+// An empty or invalid template falls back to DefaultOutputNameTemplate (with a warning for invalid ones).
+func OutputDirName(template, axonVersion, coreVersion string) string {
+	now := time.Now()
+	if template != "" {
+		name, err := expandOutputName(template, axonVersion, coreVersion, now)
+		if err == nil {
+			return name
+		}
+		log.Printf("WARN: Ignoring --output-name-template %q: %v", template, err)
+	}
+	name, _ := expandOutputName(DefaultOutputNameTemplate, axonVersion, coreVersion, now)
+	return name
+}
+
+// reportNameTemplate names the report, so runs reusing an output directory keep their reports
+const reportNameTemplate = "release-validation-report-{core_version}-{date}-{time}"
+
+// ReportFileName returns a free report file name in dir for this run, e.g.
+// release-validation-report-v1.2.0-2025-11-30-214521.html (with -2, -3, ... if that exists)
+// ext is the format's extension, including the dot. A name that can't be checked (e.g. dir is
+// unreadable) is returned as is; writing the report then reports why.
+// Only the report is named per run: metrics.json and test.log keep fixed names because
+// make render, the scripts and CI artifact uploads read them by name, so a later run in the
+// same directory replaces them while the earlier reports, which embed their results, remain.
+func ReportFileName(dir, axonVersion, coreVersion, ext string) string {
+	base, err := expandOutputName(reportNameTemplate, axonVersion, coreVersion, time.Now())
+	if err != nil {
+		base = "release-validation-report"
+	}
+	name := base + ext
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			return name // Free, or not checkable
+		}
+		name = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+}
+
+// expandOutputName substitutes the placeholders of template
+// Values are reduced to filename-safe characters; {git_sha} fails if the working directory is not a git checkout.
+func expandOutputName(template, axonVersion, coreVersion string, now time.Time) (string, error) {
+	var expandErr error
+	name := placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		var value string
+		switch placeholder {
+		case "{axon_version}":
+			value = axonVersion
+		case "{core_version}":
+			value = coreVersion
+		case "{date}":
+			value = now.Format("2006-01-02")
+		case "{time}":
+			value = now.Format("150405")
+		case "{timestamp}":
+			value = strconv.FormatInt(now.Unix(), 10)
+		case "{git_sha}":
+			sha, err := gitShortSHA()
+			if err != nil && expandErr == nil {
+				expandErr = err
+			}
+			value = sha
+		default:
+			if expandErr == nil {
+				expandErr = fmt.Errorf("unknown placeholder %s (supported: %s)", placeholder, strings.Join(OutputNamePlaceholders, ", "))
+			}
+		}
+		return unsafeNameChars.ReplaceAllString(value, "-")
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	if strings.ContainsAny(name, "{}") {
+		return "", fmt.Errorf("unbalanced braces")
+	}
+	if strings.ContainsAny(name, `/\`) || name == "." || name == ".." || strings.TrimSpace(name) == "" {
+		return "", fmt.Errorf("%q is not a valid directory name", name)
+	}
+	return name, nil
+}
+
+// gitShortSHA returns the abbreviated commit of the working directory's git checkout
+func gitShortSHA() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("{git_sha}: not in a git checkout: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
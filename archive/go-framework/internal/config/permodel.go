@@ -0,0 +1,41 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// loadPerModel reads a JSON object keyed by model name, e.g. a latency budgets file
+// what names the file in errors ("latency budgets"). Every key must be one of models, the names
+// in the model matrix (see test.MatrixModelNames), so a typo fails the run instead of
+// configuring no model at all.
+func loadPerModel[T any](path, what string, models []string) (map[string]T, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", what, err)
+	}
+
+	values := make(map[string]T)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse %s %s: %w", what, path, err)
+	}
+
+	known := make(map[string]bool, len(models))
+	for _, name := range models {
+		known[name] = true
+	}
+	var unknown []string
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%s %s: unknown model %s (the matrix has %s)", what, path, strings.Join(unknown, ", "), strings.Join(models, ", "))
+	}
+	return values, nil
+}
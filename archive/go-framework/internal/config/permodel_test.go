@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPerModelFilesRejectUnknownModels(t *testing.T) {
+	models := []string{"gpt2", "bert", "roberta"}
+	tests := []struct {
+		name    string
+		content string
+		load    func(c *Config, path string) error
+		wantErr string // "" if the file loads
+	}{
+		{"budgets", `{"gpt2": {"small": 200}}`, func(c *Config, p string) error { return c.LoadLatencyBudgets(p, models) }, ""},
+		{"budgets typo", `{"gtp2": {"small": 200}}`, func(c *Config, p string) error { return c.LoadLatencyBudgets(p, models) }, "unknown model gtp2"},
+		{"headers typo", `{"bret": {"X-Model-Version": "3"}}`, func(c *Config, p string) error { return c.LoadModelHeaders(p, models) }, "unknown model bret"},
+		{"sizes typo", `{"robert": ["small"]}`, func(c *Config, p string) error { return c.LoadInferenceSizes(p, models) }, "unknown model robert"},
+		{"expectations typo", `{"BERT": {"inputs": ["input_ids"]}}`, func(c *Config, p string) error { return c.LoadModelExpectations(p, models) }, "unknown model BERT"},
+		{"statuses typos listed together", `{"gpt-2": 413, "bert": 413, "bart": 400}`, func(c *Config, p string) error { return c.LoadExpectedStatuses(p, models) }, "unknown model bart, gpt-2"},
+		{"statuses", `{"gpt2": 413}`, func(c *Config, p string) error { return c.LoadExpectedStatuses(p, models) }, ""},
+		{"invalid JSON", `{"gpt2": `, func(c *Config, p string) error { return c.LoadExpectedStatuses(p, models) }, "failed to parse expected statuses"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "models.json")
+		if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		err := tt.load(&Config{}, path)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import "fmt"
+
+// Inference test sizes a model can be limited to (see LoadInferenceSizes)
+const (
+	InferenceSizeSmall = "small"
+	InferenceSizeLarge = "large"
+)
+
+// LoadInferenceSizes reads which inference sizes each model runs from a JSON file keyed by model name, e.g.
+//
+//	{"t5": ["small"], "clip": ["large"]}
+//
+// Models not listed run both sizes; names that are not in models, the model matrix, are an error.
+func (c *Config) LoadInferenceSizes(path string, models []string) error {
+	sizes, err := loadPerModel[[]string](path, "inference sizes", models)
+	if err != nil {
+		return err
+	}
+	for name, list := range sizes {
+		if len(list) == 0 {
+			return fmt.Errorf("invalid inference sizes for %s: list at least one of %q, %q", name, InferenceSizeSmall, InferenceSizeLarge)
+		}
+		for _, size := range list {
+			if size != InferenceSizeSmall && size != InferenceSizeLarge {
+				return fmt.Errorf("invalid inference size %q for %s (use %q or %q)", size, name, InferenceSizeSmall, InferenceSizeLarge)
+			}
+		}
+	}
+
+	c.InferenceSizes = sizes
+	return nil
+}
+
+// RunsInferenceSize reports whether a model's small or large inference test should run
+func (c *Config) RunsInferenceSize(modelName string, large bool) bool {
+	sizes, ok := c.InferenceSizes[modelName]
+	if !ok {
+		return true
+	}
+	want := InferenceSizeSmall
+	if large {
+		want = InferenceSizeLarge
+	}
+	for _, size := range sizes {
+		if size == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// LoadExpectedStatuses reads the HTTP status each model's inference tests must get from a JSON file
+// keyed by model name, for negative tests of Core's input validation, e.g.
+//
+//	{"gpt2": 413}
+//
+// Models not listed must get 200; names that are not in models, the model matrix, are an error.
+func (c *Config) LoadExpectedStatuses(path string, models []string) error {
+	statuses, err := loadPerModel[int](path, "expected statuses", models)
+	if err != nil {
+		return err
+	}
+	for name, status := range statuses {
+		if http.StatusText(status) == "" {
+			return fmt.Errorf("invalid expected status %d for %s: not an HTTP status", status, name)
+		}
+	}
+
+	c.ExpectedStatuses = statuses
+	return nil
+}
+
+// ExpectedStatusFor returns the HTTP status a model's inference tests must get (200 unless configured)
+func (c *Config) ExpectedStatusFor(modelName string) int {
+	if status, ok := c.ExpectedStatuses[modelName]; ok {
+		return status
+	}
+	return http.StatusOK
+}
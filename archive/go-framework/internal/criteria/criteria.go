@@ -0,0 +1,369 @@
+// Package criteria evaluates boolean success-criteria expressions such as
+//
+//	category.nlp.all_passed && p95_ms < 300 && memory_mb < 2048
+//
+// against a set of named number and boolean variables. The language has no
+// function calls, assignments or loops, so evaluating user input is safe.
+//
+//	literals     12, 0.5, true, false
+//	variables    letters, digits, "_" and "." (e.g. model.gpt2.small_ms)
+//	arithmetic   + - * / and unary -
+//	comparison   < <= > >= == !=
+//	logic        && || ! (or AND, OR, NOT), parentheses for grouping
+package criteria
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression is a parsed criteria expression
+type Expression struct {
+	source string
+	root   node
+}
+
+// node is one operation of the parsed expression
+type node interface {
+	eval(vars map[string]interface{}) (interface{}, error)
+}
+
+// Parse checks an expression's syntax; variables are only resolved by Eval
+func Parse(source string) (*Expression, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.tokens[p.pos].text, p.tokens[p.pos].offset+1)
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// String returns the expression as written
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Eval evaluates the expression; vars values must be float64 or bool
+// The expression must produce a boolean. Unknown variables are an error, so a typo can't pass silently.
+func (e *Expression) Eval(vars map[string]interface{}) (bool, error) {
+	value, err := e.root.eval(vars)
+	if err != nil {
+		return false, err
+	}
+	result, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression evaluates to the number %g, not true or false", value)
+	}
+	return result, nil
+}
+
+// token is one lexical element of an expression
+type token struct {
+	kind   tokenKind
+	text   string
+	number float64
+	offset int
+}
+
+type tokenKind int
+
+const (
+	tokenNumber tokenKind = iota
+	tokenIdent
+	tokenOperator
+)
+
+// operators are checked longest first so "<=" isn't read as "<" "="
+var operators = []string{"&&", "||", "<=", ">=", "==", "!=", "<", ">", "!", "+", "-", "*", "/", "(", ")"}
+
+// keywords are word spellings of the logical operators
+var keywords = map[string]string{"and": "&&", "or": "||", "not": "!"}
+
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(source); {
+		c := rune(source[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || (c == '.' && i+1 < len(source) && unicode.IsDigit(rune(source[i+1]))):
+			start := i
+			for i < len(source) && (unicode.IsDigit(rune(source[i])) || source[i] == '.') {
+				i++
+			}
+			n, err := strconv.ParseFloat(source[start:i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", source[start:i], start+1)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: source[start:i], number: n, offset: start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(source) && (unicode.IsLetter(rune(source[i])) || unicode.IsDigit(rune(source[i])) || source[i] == '_' || source[i] == '.') {
+				i++
+			}
+			word := source[start:i]
+			if op, ok := keywords[strings.ToLower(word)]; ok {
+				tokens = append(tokens, token{kind: tokenOperator, text: op, offset: start})
+			} else {
+				tokens = append(tokens, token{kind: tokenIdent, text: word, offset: start})
+			}
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(source[i:], op) {
+					tokens = append(tokens, token{kind: tokenOperator, text: op, offset: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i+1)
+			}
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+	return tokens, nil
+}
+
+// parser is a recursive-descent parser; each level binds tighter than the one before
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// accept consumes the next token if it is one of ops
+func (p *parser) accept(ops ...string) (string, bool) {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if p.tokens[p.pos].text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseBinary(p.parseAnd, "||")
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseBinary(p.parseNot, "&&")
+}
+
+func (p *parser) parseNot() (node, error) {
+	if _, ok := p.accept("!"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if op, ok := p.accept("<", "<=", ">", ">=", "==", "!="); ok {
+		right, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		return binaryNode{op, left, right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseSum() (node, error) {
+	return p.parseBinary(p.parseProduct, "+", "-")
+}
+
+func (p *parser) parseProduct() (node, error) {
+	return p.parseBinary(p.parseUnary, "*", "/")
+}
+
+// parseBinary parses a left-associative chain of ops between operands parsed by next
+func (p *parser) parseBinary(next func() (node, error), ops ...string) (node, error) {
+	left, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(ops...)
+		if !ok {
+			return left, nil
+		}
+		right, err := next()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op, left, right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if _, ok := p.accept("-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return binaryNode{"-", literalNode{0.0}, operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("expression ends unexpectedly")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	switch t.kind {
+	case tokenNumber:
+		return literalNode{t.number}, nil
+	case tokenIdent:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return literalNode{true}, nil
+		case "false":
+			return literalNode{false}, nil
+		}
+		return variableNode{t.text}, nil
+	}
+	if t.text == "(" {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, fmt.Errorf("missing ) for ( at position %d", t.offset+1)
+		}
+		return inner, nil
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.offset+1)
+}
+
+type literalNode struct{ value interface{} }
+
+func (n literalNode) eval(map[string]interface{}) (interface{}, error) {
+	return n.value, nil
+}
+
+type variableNode struct{ name string }
+
+func (n variableNode) eval(vars map[string]interface{}) (interface{}, error) {
+	value, ok := vars[n.name]
+	if !ok {
+		return nil, fmt.Errorf("unknown variable %s", n.name)
+	}
+	return value, nil
+}
+
+type notNode struct{ operand node }
+
+func (n notNode) eval(vars map[string]interface{}) (interface{}, error) {
+	value, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return nil, fmt.Errorf("! needs true or false, got %v", value)
+	}
+	return !b, nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n binaryNode) eval(vars map[string]interface{}) (interface{}, error) {
+	left, err := n.left.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	// && and || short-circuit, so a guard can protect a variable that may be missing
+	if n.op == "&&" || n.op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s needs true or false, got %v", n.op, left)
+		}
+		if (n.op == "&&" && !l) || (n.op == "||" && l) {
+			return l, nil
+		}
+		right, err := n.right.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s needs true or false, got %v", n.op, right)
+		}
+		return r, nil
+	}
+
+	right, err := n.right.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "==" || n.op == "!=" {
+		if lb, ok := left.(bool); ok {
+			rb, ok := right.(bool)
+			if !ok {
+				return nil, fmt.Errorf("cannot compare true/false with %v", right)
+			}
+			return (lb == rb) == (n.op == "=="), nil
+		}
+	}
+
+	l, lok := left.(float64)
+	r, rok := right.(float64)
+	if !lok || !rok {
+		return nil, fmt.Errorf("%s needs numbers, got %v and %v", n.op, left, right)
+	}
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return l / r, nil
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	case ">=":
+		return l >= r, nil
+	case "==":
+		return l == r, nil
+	case "!=":
+		return l != r, nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", n.op)
+}
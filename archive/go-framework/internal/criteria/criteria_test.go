@@ -0,0 +1,165 @@
+package criteria
+
+import (
+	"strings"
+	"testing"
+)
+
+// vars is a typical variable set, as test.CriteriaVariables builds it
+var vars = map[string]interface{}{
+	"success_rate":                100.0,
+	"p95_ms":                      250.0,
+	"memory_mb":                   1500.0,
+	"category.nlp.all_passed":     true,
+	"category.vision.all_passed":  false,
+	"model.gpt2.small_ms":         42.0,
+	"model.gpt2.small_passed":     true,
+	"model.bert.large_ms":         0.0,
+	"model.bert.large_passed":     false,
+	"model.t5.small_response_len": 3.0,
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    bool
+		wantErr string // "" if the expression evaluates
+	}{
+		// Precedence and associativity
+		{"1 + 2 * 3 == 7", true, ""},
+		{"10 - 4 - 3 == 3", true, ""},
+		{"8 / 4 / 2 == 1", true, ""},
+		{"-2 * 3 == -6", true, ""},
+		{"- -2 == 2", true, ""},
+		{"true || false && false", true, ""},
+		{"!false && false", false, ""},
+		{"not p95_ms > 300", true, ""},
+		{"success_rate >= 100 && p95_ms < 300 || false", true, ""},
+
+		// Parentheses
+		{"(1 + 2) * 3 == 9", true, ""},
+		{"(true || false) && false", false, ""},
+		{"((p95_ms < 300))", true, ""},
+		{"!(category.nlp.all_passed && category.vision.all_passed)", true, ""},
+
+		// Variables and keywords
+		{"category.nlp.all_passed AND p95_ms < 300 AND memory_mb < 2048", true, ""},
+		{"model.gpt2.small_passed and not model.bert.large_passed", true, ""},
+		{"category.vision.all_passed Or True", true, ""},
+		{"model.gpt2.small_passed == true", true, ""},
+		{"model.bert.large_passed != false", false, ""},
+		{"model.t5.small_response_len > 0", true, ""},
+
+		// Unknown variables fail, unless a short-circuit skips them
+		{"p95 < 300", false, "unknown variable p95"},
+		{"category.nlp.all_passed && model.gtp2.small_ms < 100", false, "unknown variable model.gtp2.small_ms"},
+		{"category.vision.all_passed && missing", false, ""},
+		{"category.nlp.all_passed || missing", true, ""},
+
+		// Division by zero
+		{"1 / 0 > 0", false, "division by zero"},
+		{"p95_ms / model.bert.large_ms < 2", false, "division by zero"},
+		{"0 / 5 == 0", true, ""},
+
+		// Units are part of variable names; literals are plain numbers
+		{"memory_mb < 2 * 1024", true, ""},
+		{"p95_ms < 0.3 * 1000", true, ""},
+		{"p95_ms < 300ms", false, `unexpected "ms"`},
+		{"memory_mb < 2GB", false, `unexpected "GB"`},
+
+		// Type errors
+		{"p95_ms", false, "not true or false"},
+		{"!p95_ms", false, "! needs true or false"},
+		{"p95_ms && true", false, "&& needs true or false"},
+		{"true || 1", true, ""}, // Never evaluated
+		{"false || 1", false, "|| needs true or false"},
+		{"true + 1 > 0", false, "+ needs numbers"},
+		{"true == 1", false, "cannot compare"},
+		{"1 == true", false, "== needs numbers"},
+	}
+	for _, tt := range tests {
+		expr, err := Parse(tt.expr)
+		if err == nil {
+			var got bool
+			got, err = expr.Eval(vars)
+			if err == nil && got != tt.want {
+				t.Errorf("%s: got %v, want %v", tt.expr, got, tt.want)
+			}
+		}
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("%s: %v", tt.expr, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%s: error = %v, want %q", tt.expr, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseRejectsMalformedInput(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{"", "empty expression"},
+		{"   ", "empty expression"},
+		{"(", "ends unexpectedly"},
+		{")", `unexpected ")" at position 1`},
+		{"(p95_ms < 300", "missing ) for ( at position 1"},
+		{"p95_ms < 300)", `unexpected ")" at position 13`},
+		{"1 +", "ends unexpectedly"},
+		{"p95_ms <", "ends unexpectedly"},
+		{"&& true", `unexpected "&&"`},
+		{"p95_ms < < 300", `unexpected "<"`},
+		{"1 < 2 < 3", `unexpected "<" at position 7`}, // Comparisons don't chain
+		{"p95_ms 300", `unexpected "300"`},
+		{"1.2.3 > 0", `invalid number "1.2.3"`},
+		{"p95_ms = 300", `unexpected character '='`},
+		{"p95_ms < 300 & true", `unexpected character '&'`},
+		{"p95_ms < $limit", `unexpected character '$'`},
+		{"memory_mb < 2e3", `unexpected "e3"`},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Parse(%q) error = %v, want %q", tt.expr, err, tt.wantErr)
+		}
+	}
+}
+
+// TestParseNeverPanics feeds every prefix of valid expressions, which cuts them mid-token
+// and mid-group, plus deeply nested input; each must parse or fail with an error
+func TestParseNeverPanics(t *testing.T) {
+	sources := []string{
+		"!(category.nlp.all_passed && (p95_ms < 300 || memory_mb <= 2 * 1024)) != false",
+		"-(-1.5 + model.gpt2.small_ms) / 2 >= 0.25 OR NOT true",
+		strings.Repeat("(", 200) + "true" + strings.Repeat(")", 200),
+		strings.Repeat("!", 200) + "true",
+		strings.Repeat("-", 200) + "1 < 0",
+	}
+	for _, source := range sources {
+		for i := 0; i <= len(source); i++ {
+			prefix := source[:i]
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Fatalf("Parse(%.40q...) panicked: %v", prefix, r)
+					}
+				}()
+				if expr, err := Parse(prefix); err == nil {
+					_, _ = expr.Eval(vars)
+				}
+			}()
+		}
+	}
+}
+
+func TestExpressionString(t *testing.T) {
+	const source = "success_rate >= 100  AND p95_ms < 300"
+	expr, err := Parse(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expr.String() != source {
+		t.Errorf("String() = %q, want the expression as written", expr.String())
+	}
+}
@@ -0,0 +1,56 @@
+package hardware
+
+import "time"
+
+// benchmarkMatrixSize is the side of the square matrices the performance benchmark multiplies
+const benchmarkMatrixSize = 256
+
+// benchmarkRounds is how many timed multiplications MeasurePerformanceIndex takes the fastest of
+const benchmarkRounds = 5
+
+// ReferenceGFLOPS is the benchmark throughput that scores a performance index of 1.0
+// The value is arbitrary; it only has to stay fixed for indices from different runs to compare.
+const ReferenceGFLOPS = 2.0
+
+// MeasurePerformanceIndex times a fixed single-threaded matrix multiply and returns the machine's speed
+// relative to ReferenceGFLOPS (2.0 is twice as fast). The fastest of a few rounds is used, after a
+// warm-up, so a busy moment doesn't drag the index down. It takes well under a second on CI hardware.
+func MeasurePerformanceIndex() float64 {
+	n := benchmarkMatrixSize
+	a, b, c := make([]float64, n*n), make([]float64, n*n), make([]float64, n*n)
+	for i := range a {
+		a[i] = float64(i%7) + 0.5
+		b[i] = float64(i%5) - 1.5
+	}
+
+	multiplyMatrices(a, b, c, n)
+	best := time.Duration(0)
+	for round := 0; round < benchmarkRounds; round++ {
+		start := time.Now()
+		multiplyMatrices(a, b, c, n)
+		if elapsed := time.Since(start); best == 0 || elapsed < best {
+			best = elapsed
+		}
+	}
+
+	flops := 2 * float64(n) * float64(n) * float64(n)
+	return flops / best.Seconds() / 1e9 / ReferenceGFLOPS
+}
+
+// multiplyMatrices sets c to a × b for n×n row-major matrices
+// The i-k-j loop order keeps the inner loop sequential in memory, like any real kernel would.
+func multiplyMatrices(a, b, c []float64, n int) {
+	for i := range c {
+		c[i] = 0
+	}
+	for i := 0; i < n; i++ {
+		row := c[i*n : (i+1)*n]
+		for k := 0; k < n; k++ {
+			aik := a[i*n+k]
+			bk := b[k*n : (k+1)*n]
+			for j := range row {
+				row[j] += aik * bk[j]
+			}
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package hardware
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ThermalSample is one reading of the machine's thermal state
+// Fields the platform can't report are left at -1.
+type ThermalSample struct {
+	Time          time.Time
+	MaxTempC      float64 // Hottest thermal zone (Linux)
+	SpeedLimitPct int     // CPU speed limit, 100 = unthrottled (macOS)
+	ThrottleCount int64   // Kernel thermal throttle events so far, summed over CPUs (Linux, Intel)
+	CoolingActive int     // Processor cooling devices currently limiting the CPU (Linux)
+}
+
+// ThermalReport summarizes the samples taken by a ThermalMonitor
+type ThermalReport struct {
+	Throttled bool
+	Details   []string // Why the run counts as throttled, one line per finding
+	MaxTempC  float64  // -1 if unknown
+	Samples   int
+}
+
+// ThermalMonitor samples the thermal state in the background until stopped
+type ThermalMonitor struct {
+	stop    chan struct{}
+	done    chan struct{}
+	mu      sync.Mutex
+	samples []ThermalSample
+
+	stopOnce sync.Once
+	report   ThermalReport
+}
+
+// StartThermalMonitor starts sampling every interval
+func StartThermalMonitor(interval time.Duration) *ThermalMonitor {
+	m := &ThermalMonitor{stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if sample, err := SampleThermal(); err == nil {
+				m.mu.Lock()
+				m.samples = append(m.samples, sample)
+				m.mu.Unlock()
+			}
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return m
+}
+
+// Stop ends sampling and reports whether the machine throttled meanwhile
+// It is safe to call more than once; later calls return the same report.
+func (m *ThermalMonitor) Stop() ThermalReport {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+		<-m.done
+
+		// One last reading so a throttle at the very end isn't missed
+		if sample, err := SampleThermal(); err == nil {
+			m.samples = append(m.samples, sample)
+		}
+		m.report = summarizeThermal(m.samples)
+	})
+	return m.report
+}
+
+func summarizeThermal(samples []ThermalSample) ThermalReport {
+	report := ThermalReport{MaxTempC: -1, Samples: len(samples)}
+	if len(samples) == 0 {
+		return report
+	}
+
+	minSpeed := 100
+	var minSpeedAt time.Time
+	maxCooling := 0
+	for _, s := range samples {
+		if s.MaxTempC > report.MaxTempC {
+			report.MaxTempC = s.MaxTempC
+		}
+		if s.SpeedLimitPct > 0 && s.SpeedLimitPct < minSpeed {
+			minSpeed = s.SpeedLimitPct
+			minSpeedAt = s.Time
+		}
+		if s.CoolingActive > maxCooling {
+			maxCooling = s.CoolingActive
+		}
+	}
+
+	if minSpeed < 100 {
+		report.Details = append(report.Details, fmt.Sprintf("CPU speed limited to %d%% at %s", minSpeed, minSpeedAt.Format("15:04:05")))
+	}
+	first, last := samples[0].ThrottleCount, samples[len(samples)-1].ThrottleCount
+	if first >= 0 && last > first {
+		report.Details = append(report.Details, fmt.Sprintf("kernel recorded %d thermal throttle events", last-first))
+	}
+	if maxCooling > 0 {
+		report.Details = append(report.Details, fmt.Sprintf("%d processor cooling device(s) limited the CPU", maxCooling))
+	}
+	report.Throttled = len(report.Details) > 0
+	return report
+}
+
+// SampleThermal reads the current thermal state (macOS: pmset -g therm, Linux: sysfs)
+func SampleThermal() (ThermalSample, error) {
+	sample := ThermalSample{Time: time.Now(), MaxTempC: -1, SpeedLimitPct: -1, ThrottleCount: -1}
+	switch runtime.GOOS {
+	case "darwin":
+		output, err := exec.Command("pmset", "-g", "therm").Output()
+		if err != nil {
+			return sample, fmt.Errorf("pmset -g therm failed: %w", err)
+		}
+		// Throttled machines report e.g. "CPU_Speed_Limit = 70"; unthrottled ones omit it or say 100
+		sample.SpeedLimitPct = 100
+		if m := speedLimitPattern.FindSubmatch(output); m != nil {
+			if pct, err := strconv.Atoi(string(m[1])); err == nil {
+				sample.SpeedLimitPct = pct
+			}
+		}
+		return sample, nil
+	case "linux":
+		sample.MaxTempC = linuxMaxTempC()
+		sample.ThrottleCount = linuxThrottleCount()
+		sample.CoolingActive = linuxActiveCooling()
+		if sample.MaxTempC < 0 && sample.ThrottleCount < 0 {
+			return sample, fmt.Errorf("no thermal information in /sys")
+		}
+		return sample, nil
+	default:
+		return sample, fmt.Errorf("thermal monitoring not supported on %s", runtime.GOOS)
+	}
+}
+
+var speedLimitPattern = regexp.MustCompile(`CPU_Speed_Limit\s*=\s*(\d+)`)
+
+// linuxMaxTempC returns the hottest thermal zone in °C, or -1 if none can be read
+func linuxMaxTempC() float64 {
+	maxTemp := -1.0
+	paths, _ := filepath.Glob("/sys/class/thermal/thermal_zone*/temp")
+	for _, path := range paths {
+		if milli, ok := readSysInt(path); ok {
+			if temp := float64(milli) / 1000; temp > maxTemp {
+				maxTemp = temp
+			}
+		}
+	}
+	return maxTemp
+}
+
+// linuxThrottleCount sums the per-CPU thermal throttle counters, or -1 if there are none
+func linuxThrottleCount() int64 {
+	paths, _ := filepath.Glob("/sys/devices/system/cpu/cpu*/thermal_throttle/core_throttle_count")
+	if len(paths) == 0 {
+		return -1
+	}
+	var total int64
+	for _, path := range paths {
+		if count, ok := readSysInt(path); ok {
+			total += count
+		}
+	}
+	return total
+}
+
+// linuxActiveCooling counts processor cooling devices currently in a throttling state
+func linuxActiveCooling() int {
+	active := 0
+	dirs, _ := filepath.Glob("/sys/class/thermal/cooling_device*")
+	for _, dir := range dirs {
+		kind, err := os.ReadFile(filepath.Join(dir, "type"))
+		if err != nil || strings.TrimSpace(string(kind)) != "Processor" {
+			continue
+		}
+		if state, ok := readSysInt(filepath.Join(dir, "cur_state")); ok && state > 0 {
+			active++
+		}
+	}
+	return active
+}
+
+func readSysInt(path string) (int64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	value, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,47 @@
+package model
+
+import "strings"
+
+// DefaultAxonErrorPrefixes are the line prefixes that mark an Axon output line as an error
+var DefaultAxonErrorPrefixes = []string{"ERROR:", "FATAL:"}
+
+// AxonOutputMatcher decides whether output from a successful (exit code 0) axon
+// command still reports a failure
+// By default only lines starting with one of ErrorPrefixes (case-insensitive,
+// after leading whitespace) count, so benign warnings that merely mention
+// "error" or "failed" don't fail an install. Strict restores the old behavior
+// of failing on either word anywhere in the output.
+type AxonOutputMatcher struct {
+	ErrorPrefixes []string // nil means DefaultAxonErrorPrefixes
+	Strict        bool
+}
+
+// Errors returns the output lines the matcher treats as errors
+func (m AxonOutputMatcher) Errors(output string) []string {
+	prefixes := m.ErrorPrefixes
+	if len(prefixes) == 0 {
+		prefixes = DefaultAxonErrorPrefixes
+	}
+
+	var matches []string
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if m.Strict {
+			lower := strings.ToLower(trimmed)
+			if strings.Contains(lower, "error") || strings.Contains(lower, "failed") {
+				matches = append(matches, trimmed)
+			}
+			continue
+		}
+		for _, prefix := range prefixes {
+			if len(trimmed) >= len(prefix) && strings.EqualFold(trimmed[:len(prefix)], prefix) {
+				matches = append(matches, trimmed)
+				break
+			}
+		}
+	}
+	return matches
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// CacheFile is one file in an installed model's cache directory
+type CacheFile struct {
+	Path      string `json:"path"` // Relative to the model directory
+	SizeBytes int64  `json:"sizeBytes"`
+}
+
+// ListModelDir lists the files in the cache directory a model was installed to
+// The directory is the one holding the resolved ONNX file, or the expected
+// location if none was found. Returns the directory and its files, sorted by path.
+func ListModelDir(modelSpec, cacheDir string, filenames []string, opts release.Options) (string, []CacheFile, error) {
+	var dir string
+	if modelPath, err := GetPath(modelSpec, cacheDir, filenames, opts); err == nil {
+		dir = filepath.Dir(modelPath)
+	} else {
+		repoModel, version, ok := strings.Cut(modelSpec, "@")
+		if !ok {
+			return "", nil, fmt.Errorf("invalid model spec format: %s", modelSpec)
+		}
+		dir = filepath.Dir(GetModelPath(repoModel, version, cacheDir))
+	}
+
+	var files []CacheFile
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, relErr := filepath.Rel(dir, path)
+		if relErr != nil {
+			rel = path
+		}
+		files = append(files, CacheFile{Path: rel, SizeBytes: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return dir, nil, fmt.Errorf("failed to list model directory %s: %w", dir, err)
+	}
+	return dir, files, nil
+}
+
+// RemoveModel removes one model from Axon's cache, in whichever layout Axon installed it
+// cacheDir is Axon's cache root (empty means the default ~/.axon/cache).
+func RemoveModel(modelSpec, cacheDir string) error {
+	dirs, err := modelDirs(modelSpec, cacheDir)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to remove %s from the Axon cache: %w", modelSpec, err)
+		}
+	}
+	return nil
+}
+
+// PurgeCache removes every model from Axon's cache
+// cacheDir is Axon's cache root (empty means the default ~/.axon/cache); only
+// its models directory is removed, so Axon's other state survives.
+func PurgeCache(cacheDir string) error {
+	dir := modelsDir(cacheDir)
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to purge Axon model cache %s: %w", dir, err)
+	}
+	return nil
+}
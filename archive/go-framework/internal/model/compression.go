@@ -0,0 +1,60 @@
+package model
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"sync"
+)
+
+// CompressionCounts counts one run's gzipped inference requests per model ID
+// Runner.Run puts a new one on InferenceOptions.Compressed, so repeated runs don't add up.
+type CompressionCounts struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// add counts a compressed request for modelID; a nil CompressionCounts counts nothing
+func (c *CompressionCounts) add(modelID string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[string]int)
+	}
+	c.counts[modelID]++
+}
+
+// Counts returns how many inference requests were sent gzipped, per model ID
+func (c *CompressionCounts) Counts() map[string]int {
+	counts := make(map[string]int)
+	if c == nil {
+		return counts
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, n := range c.counts {
+		counts[id] = n
+	}
+	return counts
+}
+
+// encodeRequestBody gzips payload if it has at least gzipThreshold bytes (see InferenceOptions.GzipThreshold)
+// It reports whether the returned body is compressed.
+func encodeRequestBody(payload []byte, gzipThreshold int) ([]byte, bool, error) {
+	if gzipThreshold <= 0 || len(payload) < gzipThreshold {
+		return payload, false, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(payload); err != nil {
+		return nil, false, fmt.Errorf("failed to gzip request body: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, false, fmt.Errorf("failed to gzip request body: %w", err)
+	}
+	return buf.Bytes(), true, nil
+}
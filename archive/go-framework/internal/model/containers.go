@@ -0,0 +1,48 @@
+package model
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ConverterContainer is a Docker container running (or left over from) the Axon converter image
+type ConverterContainer struct {
+	ID     string
+	Image  string
+	Status string // As docker ps reports it, e.g. "Up 2 hours" or "Exited (137) 5 minutes ago"
+}
+
+// ListConverterContainers returns every container, running or stopped, created from the converter image
+// Containers are matched by image name rather than docker's ancestor filter, so ones started
+// from a digest-pinned or differently tagged converter image are found too.
+func ListConverterContainers() ([]ConverterContainer, error) {
+	output, err := exec.Command("docker", "ps", "-a", "--no-trunc", "--format", "{{.ID}}\t{{.Image}}\t{{.Status}}").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Docker containers: %w", err)
+	}
+	var containers []ConverterContainer
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 || !strings.Contains(fields[1], "axon-converter") {
+			continue
+		}
+		containers = append(containers, ConverterContainer{ID: fields[0], Image: fields[1], Status: fields[2]})
+	}
+	return containers, nil
+}
+
+// RemoveConverterContainers force-removes containers, killing the ones still running
+func RemoveConverterContainers(containers []ConverterContainer) error {
+	if len(containers) == 0 {
+		return nil
+	}
+	args := []string{"rm", "-f"}
+	for _, c := range containers {
+		args = append(args, c.ID)
+	}
+	if output, err := exec.Command("docker", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove converter containers: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// converterRepo is the image Axon runs ONNX conversions in (it looks for the :latest tag)
+const converterRepo = "ghcr.io/mlos-foundation/axon-converter"
+
+// findPinnedConverter returns a local reference to the converter image pinned by digest, or "" if it isn't loaded
+func findPinnedConverter(digest string) string {
+	for _, ref := range []string{converterRepo + "@" + digest, digest} {
+		if verifyConverterDigest(ref, digest) == nil {
+			return ref
+		}
+	}
+	return ""
+}
+
+// verifyConverterDigest checks that ref is the converter image pinned by digest (always nil when digest is "")
+// The digest may be the image ID or a registry digest; ref must match one of them.
+func verifyConverterDigest(ref, digest string) error {
+	if digest == "" {
+		return nil
+	}
+	output, err := exec.Command("docker", "image", "inspect", "--format", "{{.Id}} {{range .RepoDigests}}{{.}} {{end}}", ref).Output()
+	if err != nil {
+		return fmt.Errorf("failed to inspect converter image %s: %w", ref, err)
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 {
+		return fmt.Errorf("failed to inspect converter image %s: no image ID", ref)
+	}
+	if fields[0] == digest {
+		return nil
+	}
+	for _, repoDigest := range fields[1:] {
+		if strings.HasSuffix(repoDigest, "@"+digest) {
+			return nil
+		}
+	}
+	return fmt.Errorf("converter image %s is %s, not the pinned %s", ref, fields[0], digest)
+}
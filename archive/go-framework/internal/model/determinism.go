@@ -0,0 +1,36 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CheckDeterminism sends a model's small test input twice and returns where the second response's
+// outputs differ from the first's (nil if they match). Numbers may differ by tolerance, relative to
+// the larger magnitude; 0 requires them to be identical. Timings and request IDs are not compared.
+// An error means a request failed, so nothing could be compared.
+func CheckDeterminism(modelIDForURL, modelName, modelType string, coreURL string, timeout time.Duration, inputKey string, tolerance float64, opts InferenceOptions) ([]string, error) {
+	input, err := buildTestInput(modelName, modelType, false, inputKey, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate test input: %w", err)
+	}
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	url := inferenceURL(coreURL, modelIDForURL, "include_outputs=true", opts)
+	first, _, err := postInference(url, modelIDForURL, payload, coreURL, timeout, opts)
+	if err != nil {
+		return nil, fmt.Errorf("first request: %w", err)
+	}
+	second, _, err := postInference(url, modelIDForURL, payload, coreURL, timeout, opts)
+	if err != nil {
+		return nil, fmt.Errorf("second request: %w", err)
+	}
+
+	diff := jsonDiff{tolerance: tolerance, reference: "first"}
+	diff.compare("$", first, second)
+	return diff.divergences, nil
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DefaultInferencePathTemplate is Core's native inference route; {model} is replaced by the URL-escaped model ID
+const DefaultInferencePathTemplate = "/models/{model}/inference"
+
+// inferenceURL returns the inference URL for modelID on coreURL, adding query (e.g. "stream=true") if set
+// The route is opts.PathTemplate, or the default without one. The template may carry its own query
+// string, in which case query is appended to it.
+func inferenceURL(coreURL, modelID, query string, opts InferenceOptions) string {
+	template := opts.PathTemplate
+	if template == "" {
+		template = DefaultInferencePathTemplate
+	}
+	u := coreURL + strings.ReplaceAll(template, "{model}", url.PathEscape(modelID))
+	if query == "" {
+		return u
+	}
+	if strings.Contains(u, "?") {
+		return u + "&" + query
+	}
+	return u + "?" + query
+}
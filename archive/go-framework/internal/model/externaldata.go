@@ -0,0 +1,154 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// externalDataLocationKey is how an ONNX TensorProto.external_data entry with key "location"
+// is encoded: field 1 (key), length 8, "location", then field 2 (value) whose length follows
+var externalDataLocationKey = []byte("\x0a\x08location\x12")
+
+// maxExternalDataLocation bounds the file name length read after a location key
+const maxExternalDataLocation = 4096
+
+// externalDataScanChunk is how much of the ONNX file is scanned at a time
+const externalDataScanChunk = 1 << 20
+
+// shardPattern matches sharded ONNX exports like model-00001-of-00003.onnx
+var shardPattern = regexp.MustCompile(`^(.*)-(\d+)-of-(\d+)\.onnx$`)
+
+// ExternalDataFiles returns the files an ONNX model needs next to it: the external-data files its
+// tensors reference (model.onnx_data, model.onnx.data, ...) and, for sharded exports, every shard.
+// Paths are relative to the model's directory, sorted, and include files that are missing.
+func ExternalDataFiles(modelPath string) ([]string, error) {
+	seen := make(map[string]bool)
+
+	locations, err := externalDataLocations(modelPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, location := range locations {
+		seen[location] = true
+	}
+
+	if match := shardPattern.FindStringSubmatch(filepath.Base(modelPath)); match != nil {
+		total, _ := strconv.Atoi(match[3])
+		for i := 1; i <= total; i++ {
+			shard := fmt.Sprintf("%s-%0*d-of-%s.onnx", match[1], len(match[2]), i, match[3])
+			if shard != filepath.Base(modelPath) {
+				seen[shard] = true
+			}
+		}
+	}
+
+	files := make([]string, 0, len(seen))
+	for file := range seen {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// CheckExternalData fails if any file the model needs next to it is missing
+// Core would otherwise register the graph and only fail when it tries to load the weights.
+func CheckExternalData(modelPath string) error {
+	files, err := ExternalDataFiles(modelPath)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(filepath.Dir(modelPath), file)); err != nil {
+			missing = append(missing, file)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s needs %d external data file(s) that are missing: %s",
+			filepath.Base(modelPath), len(missing), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// externalDataLocations scans an ONNX file for external-data locations without parsing the whole
+// protobuf, reading it in chunks so multi-GB models aren't loaded into memory
+func externalDataLocations(modelPath string) ([]string, error) {
+	f, err := os.Open(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open model: %w", err)
+	}
+	defer f.Close()
+
+	var locations []string
+	seen := make(map[string]bool)
+	// Keep the tail of the previous chunk so a key straddling the boundary is still found
+	overlap := len(externalDataLocationKey) + 2 + maxExternalDataLocation
+	buf := make([]byte, 0, externalDataScanChunk+overlap)
+	chunk := make([]byte, externalDataScanChunk)
+	for {
+		n, readErr := f.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		atEOF := readErr == io.EOF
+
+		// Only decode keys whose value is fully in buf, unless nothing more is coming
+		limit := len(buf) - overlap
+		if atEOF {
+			limit = len(buf)
+		}
+		offset := 0
+		for offset < limit {
+			i := bytes.Index(buf[offset:], externalDataLocationKey)
+			if i < 0 || offset+i >= limit {
+				break
+			}
+			start := offset + i + len(externalDataLocationKey)
+			if location, ok := readProtoString(buf[start:]); ok && !seen[location] {
+				seen[location] = true
+				locations = append(locations, location)
+			}
+			offset = start
+		}
+
+		if atEOF {
+			return locations, nil
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read model: %w", readErr)
+		}
+		if limit > 0 {
+			buf = append(buf[:0], buf[limit:]...)
+		}
+	}
+}
+
+// readProtoString decodes a varint length followed by that many bytes of a plausible file name
+func readProtoString(b []byte) (string, bool) {
+	length, shift, i := 0, 0, 0
+	for ; i < len(b) && i < 3; i++ {
+		length |= int(b[i]&0x7f) << shift
+		shift += 7
+		if b[i]&0x80 == 0 {
+			break
+		}
+	}
+	if i >= len(b) || b[i]&0x80 != 0 {
+		return "", false
+	}
+	i++
+	if length == 0 || length > maxExternalDataLocation || i+length > len(b) {
+		return "", false
+	}
+	location := string(b[i : i+length])
+	// External data must sit beside the model; anything else is not a location we understand
+	if strings.ContainsAny(location, "\x00\n") || filepath.IsAbs(location) || strings.HasPrefix(filepath.Clean(location), "..") {
+		return "", false
+	}
+	return location, true
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// locationEntry encodes an external_data entry with key "location" the way ONNX writes it
+func locationEntry(location string) []byte {
+	entry := append([]byte{}, externalDataLocationKey...)
+	entry = append(entry, byte(len(location))) // Single-byte varint: every location here is < 128 bytes
+	return append(entry, location...)
+}
+
+// writeModel writes an ONNX stand-in to dir/name: filler bytes around the given external_data entries
+func writeModel(t *testing.T, dir, name string, entries ...[]byte) string {
+	t.Helper()
+	var data []byte
+	for _, entry := range entries {
+		data = append(data, "\x08\x01graph-bytes"...)
+		data = append(data, entry...)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func touch(t *testing.T, path string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("weights"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckExternalDataReportsMissingShard(t *testing.T) {
+	dir := t.TempDir()
+	modelPath := writeModel(t, dir, "model-00001-of-00003.onnx", locationEntry("model.onnx_data"))
+	touch(t, filepath.Join(dir, "model-00002-of-00003.onnx"))
+	touch(t, filepath.Join(dir, "model.onnx_data"))
+
+	files, err := ExternalDataFiles(modelPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"model-00002-of-00003.onnx", "model-00003-of-00003.onnx", "model.onnx_data"}
+	if !reflect.DeepEqual(files, want) {
+		t.Fatalf("ExternalDataFiles = %q, want %q", files, want)
+	}
+
+	err = CheckExternalData(modelPath)
+	if err == nil {
+		t.Fatal("CheckExternalData passed with shard 3 missing")
+	}
+	if !strings.Contains(err.Error(), "1 external data file(s) that are missing: model-00003-of-00003.onnx") {
+		t.Errorf("error should name only the missing shard: %v", err)
+	}
+
+	touch(t, filepath.Join(dir, "model-00003-of-00003.onnx"))
+	if err := CheckExternalData(modelPath); err != nil {
+		t.Errorf("CheckExternalData with every file present: %v", err)
+	}
+}
+
+func TestExternalDataLocationsAcrossChunkBoundary(t *testing.T) {
+	entry := locationEntry("model.onnx_data")
+	// From the whole entry just before the boundary, through the key and then the value straddling it,
+	// to the whole entry just after it
+	for start := externalDataScanChunk - len(entry) - 1; start <= externalDataScanChunk+1; start++ {
+		data := make([]byte, start, start+len(entry)+16)
+		data = append(data, entry...)
+		data = append(data, "\x08\x01trailing"...)
+		path := filepath.Join(t.TempDir(), "model.onnx")
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		locations, err := externalDataLocations(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(locations, []string{"model.onnx_data"}) {
+			t.Errorf("entry at offset %d (chunk boundary %+d): locations = %q", start, start-externalDataScanChunk, locations)
+		}
+	}
+}
+
+func TestExternalDataLocationsSpanningChunks(t *testing.T) {
+	// Entries over several chunks, each name repeated further on, are found once each
+	var data bytes.Buffer
+	var want []string
+	for i := 0; data.Len() < 3*externalDataScanChunk; i++ {
+		location := "weights-" + strings.Repeat("x", i%50) + ".bin"
+		if i < 50 {
+			want = append(want, location)
+		}
+		data.Write(locationEntry(location))
+		data.Write(make([]byte, 4093)) // Stagger the entries against the chunk size
+	}
+	path := filepath.Join(t.TempDir(), "model.onnx")
+	if err := os.WriteFile(path, data.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	locations, err := externalDataLocations(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(locations, want) {
+		t.Errorf("locations = %q, want %q", locations, want)
+	}
+}
+
+func TestExternalDataLocationsRejectsPathsOutsideModelDir(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "model")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	modelPath := writeModel(t, dir, "model.onnx",
+		locationEntry("../outside.bin"),
+		locationEntry("nested/../../outside.bin"),
+		locationEntry("/etc/passwd"),
+		locationEntry("weights/model.onnx_data"),
+	)
+
+	files, err := ExternalDataFiles(modelPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"weights/model.onnx_data"}; !reflect.DeepEqual(files, want) {
+		t.Fatalf("ExternalDataFiles = %q, want %q", files, want)
+	}
+
+	// A file outside the model directory never satisfies (or is looked up for) the check
+	touch(t, filepath.Join(root, "outside.bin"))
+	if err := CheckExternalData(modelPath); err == nil || !strings.Contains(err.Error(), "weights/model.onnx_data") {
+		t.Errorf("CheckExternalData = %v, want weights/model.onnx_data reported missing", err)
+	}
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Model formats an install can produce (see DetectFormat)
+const (
+	FormatONNX        = "onnx"
+	FormatPyTorch     = "pytorch"     // Axon fell back to the PyTorch checkpoint
+	FormatSafetensors = "safetensors" // Axon fell back to the safetensors checkpoint
+)
+
+// Native-format files Axon leaves behind when ONNX conversion falls back, by format
+var fallbackFormatFiles = []struct {
+	format   string
+	patterns []string
+}{
+	{FormatSafetensors, []string{"model.safetensors", "model-*-of-*.safetensors", "*.safetensors"}},
+	{FormatPyTorch, []string{"pytorch_model.bin", "pytorch_model-*-of-*.bin", "model.pt", "*.pt", "*.pth"}},
+}
+
+// DetectFormat reports which format an installed model is in: FormatONNX if one of filenames is
+// present, else the fallback format Axon produced, or "" if the cache has no model files at all
+func DetectFormat(modelSpec, cacheDir string, filenames []string) (string, error) {
+	dirs, err := modelDirs(modelSpec, cacheDir)
+	if err != nil {
+		return "", err
+	}
+	if len(filenames) == 0 {
+		filenames = DefaultModelFilenames
+	}
+	for _, dir := range dirs {
+		if findModelFile(dir, filenames) != "" {
+			return FormatONNX, nil
+		}
+	}
+	for _, fallback := range fallbackFormatFiles {
+		for _, dir := range dirs {
+			if findModelFile(dir, fallback.patterns) != "" {
+				return fallback.format, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// modelDirs returns the cache directories a model spec may be installed in, preferred first
+func modelDirs(modelSpec, cacheDir string) ([]string, error) {
+	repoModel, version, ok := strings.Cut(modelSpec, "@")
+	if !ok || strings.Contains(version, "@") {
+		return nil, fmt.Errorf("invalid model spec format: %s", modelSpec)
+	}
+	baseDir := filepath.Dir(GetModelPath(repoModel, version, cacheDir))
+	// Alternative path format
+	altDir := filepath.Join(modelsDir(cacheDir),
+		strings.ReplaceAll(strings.ReplaceAll(modelSpec, "/", "-"), "@", "-"))
+	return []string{baseDir, altDir}, nil
+}
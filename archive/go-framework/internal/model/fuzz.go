@@ -0,0 +1,65 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// maxFuzzSeqLen bounds the random sequence lengths sent by fuzz inference
+const maxFuzzSeqLen = 128
+
+// vocabSizes keeps fuzzed token IDs inside each test model's vocabulary
+var vocabSizes = map[string]int{
+	"gpt2":    50257,
+	"bert":    30522,
+	"roberta": 50265,
+	"t5":      32128,
+}
+
+// defaultVocabSize is used for models without a known vocabulary
+const defaultVocabSize = 30000
+
+// RunFuzzInference sends one randomized but valid input (random length, token IDs within the vocab)
+// rng drives all randomness so a run can be reproduced from its seed
+func RunFuzzInference(modelIDForURL, modelName, modelType string, rng *rand.Rand, coreURL string, timeout time.Duration, inputKey string, opts InferenceOptions) error {
+	input, err := buildTestInput(modelName, modelType, false, inputKey, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate test input: %w", err)
+	}
+
+	vocab, ok := vocabSizes[modelName]
+	if !ok {
+		vocab = defaultVocabSize
+	}
+	seqLen := 1 + rng.Intn(maxFuzzSeqLen)
+
+	// Keep the model's input names, but give every tensor the same random length
+	for key := range input {
+		values := make([]int, seqLen)
+		switch key {
+		case "attention_mask":
+			for i := range values {
+				values[i] = 1
+			}
+		case "token_type_ids":
+			// All zeros (single segment)
+		default:
+			for i := range values {
+				values[i] = rng.Intn(vocab)
+			}
+		}
+		input[key] = values
+	}
+
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	if _, _, err := postInference(inferenceURL(coreURL, modelIDForURL, "", opts), modelIDForURL, payload, coreURL, timeout, opts); err != nil {
+		return fmt.Errorf("seq_len=%d: %w", seqLen, err)
+	}
+	return nil
+}
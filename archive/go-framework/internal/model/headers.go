@@ -0,0 +1,21 @@
+package model
+
+import (
+	"net/http"
+	"strings"
+)
+
+// applyInferenceHeaders adds the headers opts sets for modelID to an inference request
+// Per-model headers override headers of the same name. Host is applied to req.Host, since net/http
+// ignores it in req.Header.
+func applyInferenceHeaders(req *http.Request, modelID string, opts InferenceOptions) {
+	for _, headers := range []map[string]string{opts.Headers, opts.ModelHeaders[modelID]} {
+		for key, value := range headers {
+			if strings.EqualFold(key, "Host") {
+				req.Host = value
+				continue
+			}
+			req.Header.Set(key, value)
+		}
+	}
+}
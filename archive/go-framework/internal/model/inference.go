@@ -1,84 +1,300 @@
 package model
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
 )
 
 // RunInference runs an inference test for a model
 // modelIDForURL is the full model spec (e.g., "hf/distilgpt2@latest") used in the URL
 // modelName is the short name (e.g., "gpt2") used for generating test input
-func RunInference(modelIDForURL, modelName, modelType string, large bool, port int) error {
+// coreURL is the base URL of Core (e.g., "http://127.0.0.1:18080")
+// timeout bounds the whole request, including reading the response
+// inputKey is the JSON key Core expects the token IDs under (DefaultInputKey if empty)
+// With the OpenAI API style, the model is exercised through /v1/completions and /v1/chat/completions instead.
+// It returns the size of the response body in bytes (of both responses with the OpenAI style).
+// Success is a 200, or the status opts.ExpectedStatuses sets for the model (see ResponseStatus).
+func RunInference(modelIDForURL, modelName, modelType string, large bool, coreURL string, timeout time.Duration, inputKey string, opts InferenceOptions) (int64, error) {
+	size, err := runInference(modelIDForURL, modelName, modelType, large, coreURL, timeout, inputKey, opts)
+	return size, checkExpectedStatus(modelIDForURL, err, opts)
+}
+
+func runInference(modelIDForURL, modelName, modelType string, large bool, coreURL string, timeout time.Duration, inputKey string, opts InferenceOptions) (int64, error) {
+	if opts.APIStyle == APIStyleOpenAI {
+		return runOpenAIInference(modelIDForURL, large, coreURL, timeout, opts)
+	}
+
 	// Generate test input based on model type (use short name)
-	input, err := generateTestInput(modelName, modelType, large)
+	input, err := buildTestInput(modelName, modelType, large, inputKey, opts)
 	if err != nil {
-		return fmt.Errorf("failed to generate test input: %w", err)
+		return 0, fmt.Errorf("failed to generate test input: %w", err)
 	}
 
 	// Prepare JSON payload
 	payload, err := json.Marshal(input)
 	if err != nil {
-		return fmt.Errorf("failed to marshal input: %w", err)
+		return 0, fmt.Errorf("failed to marshal input: %w", err)
 	}
 
-	// URL-encode the full model_id for use in the URL path
-	// Core stores models with the full model_id (e.g., "hf/distilgpt2@latest")
-	encodedModelID := url.PathEscape(modelIDForURL)
+	// Core stores models with the full model_id (e.g., "hf/distilgpt2@latest"); inferenceURL escapes it
+	_, size, err := postInference(inferenceURL(coreURL, modelIDForURL, "", opts), modelIDForURL, payload, coreURL, timeout, opts)
+	return size, err
+}
+
+// RunBatchInference sends batchSize stacked copies of the small test input in a single request
+// and verifies Core returns one output per example
+func RunBatchInference(modelIDForURL, modelName, modelType string, batchSize int, coreURL string, timeout time.Duration, inputKey string, opts InferenceOptions) error {
+	if batchSize < 1 {
+		return fmt.Errorf("invalid batch size: %d", batchSize)
+	}
 
-	// Make HTTP request (use explicit IPv4 to avoid IPv6 resolution issues in CI)
-	url := fmt.Sprintf("http://127.0.0.1:%d/models/%s/inference", port, encodedModelID)
-	req, err := http.NewRequest("POST", url, strings.NewReader(string(payload)))
+	input, err := buildTestInput(modelName, modelType, false, inputKey, opts)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to generate test input: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	// Stack every input tensor into shape [batchSize, seq]
+	batched := make(map[string]interface{}, len(input))
+	for key, value := range input {
+		stacked := make([]interface{}, batchSize)
+		for i := range stacked {
+			stacked[i] = value
+		}
+		batched[key] = stacked
+	}
+
+	payload, err := json.Marshal(batched)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batched input: %w", err)
+	}
+
+	// include_outputs=true so the batch dimension of the outputs can be checked
+	result, _, err := postInference(inferenceURL(coreURL, modelIDForURL, "include_outputs=true", opts), modelIDForURL, payload, coreURL, timeout, opts)
+	if err != nil {
+		return err
+	}
+
+	outputs, ok := result["outputs"].(map[string]interface{})
+	if !ok || len(outputs) == 0 {
+		return fmt.Errorf("batched response contains no outputs")
+	}
+	for name, tensor := range outputs {
+		rows, ok := tensor.([]interface{})
+		if !ok || len(rows) != batchSize {
+			return fmt.Errorf("batched output %q has %d entries, expected %d", name, len(rows), batchSize)
+		}
+	}
+
+	return nil
+}
+
+// postInference POSTs a JSON payload to an inference URL and returns the decoded response
+// The exchange is captured while opts.Recorder is recording.
+func postInference(url, modelID string, payload []byte, coreURL string, timeout time.Duration, opts InferenceOptions) (map[string]interface{}, int64, error) {
+	if opts.Recorder.recording() {
+		url = withIncludeOutputs(url)
+	}
+	result, size, err := sendInference(url, modelID, payload, coreURL, timeout, opts)
+	opts.Recorder.record(url, coreURL, modelID, payload, result, err)
+	return result, size, err
+}
+
+// sendInference does the POST for postInference and ReplayExchange
+// Large payloads are gzipped when opts.GzipThreshold is set (see encodeRequestBody).
+// It also returns the bytes of response body read (as decoded, if Core compressed it).
+func sendInference(url, modelID string, payload []byte, coreURL string, timeout time.Duration, opts InferenceOptions) (map[string]interface{}, int64, error) {
+	body, compressed, err := encodeRequestBody(payload, opts.GzipThreshold)
+	if err != nil {
+		return nil, 0, err
+	}
+	if compressed {
+		opts.Compressed.add(modelID)
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
 
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+	req.Header.Set("Content-Type", "application/json")
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
 	}
+	applyInferenceHeaders(req, modelID, opts)
+	logHTTPRequest(req, payload, opts)
+
+	client := release.NewCoreClient(timeout, opts.Release)
 
+	start := time.Now()
 	resp, err := client.Do(req)
 	if err != nil {
-		// Check if Core server is still running (use explicit IPv4)
-		healthURL := fmt.Sprintf("http://127.0.0.1:%d/health", port)
-		healthReq, _ := http.NewRequest("GET", healthURL, nil)
-		healthResp, healthErr := client.Do(healthReq)
+		logHTTPError(err, start, opts)
+		// Check if Core server is still running (quickly, regardless of the inference timeout)
+		healthURL := coreURL + "/health"
+		healthClient := release.NewCoreClient(healthCheckTimeout, opts.Release)
+		healthResp, healthErr := healthClient.Get(healthURL)
 		if healthErr != nil {
-			fmt.Printf("   ERROR: Core server health check failed: %v\n", healthErr)
-			fmt.Printf("   Core server may have crashed during inference\n")
+			fmt.Fprintf(opts.Release.Out(), "   ERROR: Core server health check failed: %v\n", healthErr)
+			fmt.Fprintf(opts.Release.Out(), "   Core server may have crashed during inference\n")
 		} else {
 			healthResp.Body.Close()
-			fmt.Printf("   Core server is still running (health check passed)\n")
+			fmt.Fprintf(opts.Release.Out(), "   Core server is still running (health check passed)\n")
 		}
-		return fmt.Errorf("request failed: %w", err)
+		// Keep a hung request distinguishable from one that never connected
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, 0, fmt.Errorf("timed out after %s: %w", timeout, err)
+		}
+		return nil, 0, fmt.Errorf("connection error: %w", err)
 	}
 	defer func() {
 		_ = resp.Body.Close() // Ignore close errors on response body
 	}()
+	// With --verbose-http the body is logged as it was read below, once this returns
+	respBody, captured := captureBody(resp.Body, opts)
+	defer logHTTPResponse(resp, captured, start, opts)
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("inference failed with status %d", resp.StatusCode)
+		// Core puts the actual reason in the body
+		errBody, _ := io.ReadAll(io.LimitReader(respBody, maxErrorBodyBytes+1))
+		text := truncateBody(errBody)
+		if compressed && resp.StatusCode == http.StatusUnsupportedMediaType {
+			text += " (request body was gzipped; this Core may not accept Content-Encoding: gzip)"
+		}
+		return nil, int64(len(errBody)), &HTTPStatusError{StatusCode: resp.StatusCode, Body: text}
+	}
+	counted := &countingReader{r: respBody}
+
+	// Generation endpoints may stream even when not asked to; a single JSON decode can't read that
+	if contentType := resp.Header.Get("Content-Type"); isStreamingContentType(contentType) {
+		_, last, err := readStream(counted, contentType, start)
+		if err != nil {
+			return nil, counted.n, err
+		}
+		if last == nil {
+			last = map[string]interface{}{}
+		}
+		return last, counted.n, nil
 	}
 
-	// Parse response to check for errors
+	// Parse response to check for errors; the rest of the body is drained so its size is complete
 	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+	err = json.NewDecoder(counted).Decode(&result)
+	_, _ = io.Copy(io.Discard, counted)
+	if err != nil {
+		return nil, counted.n, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if status, ok := result["status"].(string); ok && status == "error" {
-		return fmt.Errorf("inference error: %v", result["message"])
+		return nil, counted.n, fmt.Errorf("inference error: %v", result["message"])
 	}
 
-	return nil
+	return result, counted.n, nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// healthCheckTimeout bounds the health probe sent after a failed inference request
+const healthCheckTimeout = 5 * time.Second
+
+// Failure kinds returned by ClassifyFailure
+const (
+	FailureTimeout    = "timeout"
+	FailureConnection = "connection"
+	FailureServer     = "server_error"
+	FailureRejected   = "rejected"
+	FailureOther      = "other"
+)
+
+// HTTPStatusError is returned when Core answers an inference request with a non-200 status
+type HTTPStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("inference failed with status %d: %s", e.StatusCode, e.Body)
 }
 
-func generateTestInput(modelID, modelType string, large bool) (map[string]interface{}, error) {
+// ClassifyFailure buckets an inference error as a timeout, lost connection, 5xx, 4xx or other failure
+func ClassifyFailure(err error) string {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		if statusErr.StatusCode >= 500 {
+			return FailureServer
+		}
+		return FailureRejected
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return FailureTimeout
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return FailureConnection
+	}
+	return FailureOther
+}
+
+// maxErrorBodyBytes caps how much of an error response is kept in error messages
+const maxErrorBodyBytes = 512
+
+// truncateBody trims a response body for inclusion in an error message
+func truncateBody(body []byte) string {
+	text := strings.TrimSpace(string(body))
+	if len(text) > maxErrorBodyBytes {
+		return text[:maxErrorBodyBytes] + "... (truncated)"
+	}
+	if text == "" {
+		return "(empty body)"
+	}
+	return text
+}
+
+// DefaultInputKey is the JSON key current Core builds read token IDs from
+const DefaultInputKey = "input_ids"
+
+// buildTestInput generates the test input and moves the token IDs under inputKey,
+// so Core API revisions that expect a different key (e.g. "inputs") can be tested without a code change
+func buildTestInput(modelName, modelType string, large bool, inputKey string, opts InferenceOptions) (map[string]interface{}, error) {
+	input, err := generateTestInput(modelName, modelType, large, opts)
+	if err != nil {
+		return nil, err
+	}
+	if inputKey != "" && inputKey != DefaultInputKey {
+		if ids, ok := input[DefaultInputKey]; ok {
+			delete(input, DefaultInputKey)
+			input[inputKey] = ids
+		}
+	}
+	return input, nil
+}
+
+func generateTestInput(modelID, modelType string, large bool, opts InferenceOptions) (map[string]interface{}, error) {
+	// Token IDs encoded from --prompt take precedence over the built-in sequences
+	if ids, ok := promptInputIDs(modelID, large, opts); ok {
+		return tokenInputs(modelID, modelType, ids)
+	}
+
 	// Base token sequences for different models
 	var inputIDs []int
 
@@ -89,9 +305,6 @@ func generateTestInput(modelID, modelType string, large bool) (map[string]interf
 		} else {
 			inputIDs = []int{15496, 11, 337, 43, 48, 2640, 0}
 		}
-		return map[string]interface{}{
-			"input_ids": inputIDs,
-		}, nil
 
 	case "bert":
 		if large {
@@ -99,16 +312,6 @@ func generateTestInput(modelID, modelType string, large bool) (map[string]interf
 		} else {
 			inputIDs = []int{101, 7592, 2088, 102}
 		}
-		attentionMask := make([]int, len(inputIDs))
-		for i := range attentionMask {
-			attentionMask[i] = 1
-		}
-		tokenTypeIDs := make([]int, len(inputIDs))
-		return map[string]interface{}{
-			"input_ids":      inputIDs,
-			"attention_mask": attentionMask,
-			"token_type_ids": tokenTypeIDs,
-		}, nil
 
 	case "roberta":
 		if large {
@@ -116,9 +319,6 @@ func generateTestInput(modelID, modelType string, large bool) (map[string]interf
 		} else {
 			inputIDs = []int{0, 31414, 232, 328, 2}
 		}
-		return map[string]interface{}{
-			"input_ids": inputIDs,
-		}, nil
 
 	case "t5":
 		if large {
@@ -126,19 +326,70 @@ func generateTestInput(modelID, modelType string, large bool) (map[string]interf
 		} else {
 			inputIDs = []int{37, 1962, 10}
 		}
+
+	default:
+		// No known tokenizer: the input is shaped by the model's input type (see typedInputs)
+		inputIDs = genericTokenIDs(large)
+	}
+	return tokenInputs(modelID, modelType, inputIDs)
+}
+
+// genericTokenIDs is the token sequence sent to models without built-in test input
+// IDs from 1000 up are ordinary tokens in any real vocabulary, clear of the special
+// tokens (padding, unknown, start/end) most vocabularies begin with.
+func genericTokenIDs(large bool) []int {
+	n := 4
+	if large {
+		n = 16
+	}
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = 1000 + i
+	}
+	return ids
+}
+
+// tokenInputs builds the input tensors a model takes for a token sequence
+// The built-in models have known inputs (BERT also needs an attention mask and
+// token type IDs); any other model gets the inputs its ModelSpec type implies.
+func tokenInputs(modelID, modelType string, inputIDs []int) (map[string]interface{}, error) {
+	switch modelID {
+	case "bert":
+		return map[string]interface{}{
+			"input_ids":      inputIDs,
+			"attention_mask": onesLike(inputIDs),
+			"token_type_ids": make([]int, len(inputIDs)), // Single segment
+		}, nil
+	case "gpt2", "roberta", "t5":
 		return map[string]interface{}{
 			"input_ids": inputIDs,
 		}, nil
+	}
+	return typedInputs(modelID, modelType, inputIDs)
+}
 
-	default:
-		// Default: single input with small sequence
-		if large {
-			inputIDs = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
-		} else {
-			inputIDs = []int{1, 2, 3}
-		}
+// typedInputs builds inputs from a model's type: "single" models take only input_ids,
+// "multi" models also an all-ones attention mask
+func typedInputs(modelID, modelType string, inputIDs []int) (map[string]interface{}, error) {
+	switch modelType {
+	case "single":
 		return map[string]interface{}{
 			"input_ids": inputIDs,
 		}, nil
+	case "multi":
+		return map[string]interface{}{
+			"input_ids":      inputIDs,
+			"attention_mask": onesLike(inputIDs),
+		}, nil
+	}
+	return nil, fmt.Errorf("no input generator for model type %q (model %s)", modelType, modelID)
+}
+
+// onesLike returns an all-ones mask as long as ids
+func onesLike(ids []int) []int {
+	mask := make([]int, len(ids))
+	for i := range mask {
+		mask[i] = 1
 	}
+	return mask
 }
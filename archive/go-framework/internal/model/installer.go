@@ -8,16 +8,27 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
 )
 
 // Install installs a model using Axon with progress indicator
-func Install(modelSpec string, testAllModels bool) (bool, error) {
+// cacheDir is Axon's cache root (empty means the default ~/.axon/cache)
+// filenames are the accepted ONNX filenames (see GetPath)
+// mirror, if set, is where the converter image is fetched from instead of GitHub
+// converterDigest, if set, pins the converter image to a sha256: digest (--converter-digest)
+// The returned Transfer is the converter image download (zero if it was already loaded)
+// matcher decides whether stderr of a successful axon install still reports a failure
+func Install(modelSpec string, testAllModels bool, cacheDir string, filenames []string, mirror, converterDigest string, matcher AxonOutputMatcher, opts release.Options) (bool, release.Transfer, error) {
+	var converter release.Transfer // Converter image fetched for this install, if any
+
 	// Parse model spec: "repo/model@version"
 	parts := strings.Split(modelSpec, "@")
 	if len(parts) != 2 {
-		return false, fmt.Errorf("invalid model spec format: %s", modelSpec)
+		return false, converter, fmt.Errorf("invalid model spec format: %s", modelSpec)
 	}
 
 	// Skip vision and multimodal models unless testAllModels is true
@@ -26,51 +37,52 @@ func Install(modelSpec string, testAllModels bool) (bool, error) {
 		if strings.Contains(repoModel, "resnet") ||
 			strings.Contains(repoModel, "vgg") ||
 			strings.Contains(repoModel, "clip") {
-			return false, nil // Skip
+			return false, converter, nil // Skip
 		}
 	}
 
 	// Check if model is already installed using our path resolution
 	// This will try multiple path formats
-	if existingPath, err := GetPath(modelSpec); err == nil {
-		fmt.Printf("✅ Model already installed at: %s\n", existingPath)
-		return false, nil // Already installed
+	if existingPath, err := GetPath(modelSpec, cacheDir, filenames, opts); err == nil {
+		fmt.Fprintf(opts.Out(), "✅ Model already installed at: %s\n", existingPath)
+		return false, converter, nil // Already installed
 	}
 
 	// Install using Axon
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return false, fmt.Errorf("failed to get home directory: %w", err)
+		return false, converter, fmt.Errorf("failed to get home directory: %w", err)
 	}
 
 	// Check if Docker is available (Axon needs it for ONNX conversion)
 	dockerCmd := exec.Command("docker", "--version")
 	if dockerOut, dockerErr := dockerCmd.CombinedOutput(); dockerErr != nil {
-		fmt.Printf("⚠️  Docker CLI not available: %v\n", dockerErr)
-		fmt.Printf("   Axon may fallback to native format (non-ONNX)\n")
+		fmt.Fprintf(opts.Out(), "⚠️  Docker CLI not available: %v\n", dockerErr)
+		fmt.Fprintf(opts.Out(), "   Axon may fallback to native format (non-ONNX)\n")
 	} else {
-		fmt.Printf("   Docker CLI: %s\n", strings.TrimSpace(string(dockerOut)))
+		fmt.Fprintf(opts.Out(), "   Docker CLI: %s\n", strings.TrimSpace(string(dockerOut)))
 	}
 	
 	// Check if Docker daemon is actually running (can we run containers?)
 	dockerPsCmd := exec.Command("docker", "ps")
 	if dockerPsOut, dockerPsErr := dockerPsCmd.CombinedOutput(); dockerPsErr != nil {
-		fmt.Printf("⚠️  Docker daemon not accessible: %v\n", dockerPsErr)
-		fmt.Printf("   Output: %s\n", strings.TrimSpace(string(dockerPsOut)))
-		fmt.Printf("   Axon WILL fallback to native Python (which will fail without torch)\n")
+		fmt.Fprintf(opts.Out(), "⚠️  Docker daemon not accessible: %v\n", dockerPsErr)
+		fmt.Fprintf(opts.Out(), "   Output: %s\n", strings.TrimSpace(string(dockerPsOut)))
+		fmt.Fprintf(opts.Out(), "   Axon WILL fallback to native Python (which will fail without torch)\n")
 	} else {
-		fmt.Printf("   Docker daemon: Running ✓\n")
+		fmt.Fprintf(opts.Out(), "   Docker daemon: Running ✓\n")
 	}
 
 	axonBin := filepath.Join(homeDir, ".local", "bin", "axon")
 	
 	// Download and load Axon converter image from release artifacts
-	fmt.Printf("   Loading Axon converter image from release...\n")
-	if err := loadConverterImage("v3.1.1"); err != nil {
-		fmt.Printf("⚠️  Failed to load converter image: %v\n", err)
-		fmt.Printf("   Axon may still try to pull it automatically\n")
+	fmt.Fprintf(opts.Out(), "   Loading Axon converter image from release...\n")
+	converter, err = loadConverterImage("v3.1.1", mirror, converterDigest, opts)
+	if err != nil {
+		fmt.Fprintf(opts.Out(), "⚠️  Failed to load converter image: %v\n", err)
+		fmt.Fprintf(opts.Out(), "   Axon may still try to pull it automatically\n")
 	} else {
-		fmt.Printf("✅ Converter image loaded successfully\n")
+		fmt.Fprintf(opts.Out(), "✅ Converter image loaded successfully\n")
 	}
 	
 	// Install model (no --format flag as Axon doesn't support it)
@@ -78,26 +90,28 @@ func Install(modelSpec string, testAllModels bool) (bool, error) {
 	cmd := exec.Command(axonBin, "install", modelSpec)
 	
 	// Ensure environment is inherited (including PATH, DOCKER_HOST, etc.)
-	cmd.Env = os.Environ()
+	cmd.Env = axonEnv(cacheDir)
 	
 	// Set working directory to home (where .axon cache is)
 	cmd.Dir = homeDir
 	
+	release.LogCommand(cmd, opts)
+
 	// Stream output in real-time and capture for error reporting
 	var stdout, stderr strings.Builder
 	var stdoutPipe, stderrPipe io.ReadCloser
 	stdoutPipe, err = cmd.StdoutPipe()
 	if err != nil {
-		return false, fmt.Errorf("failed to create stdout pipe: %w", err)
+		return false, converter, fmt.Errorf("failed to create stdout pipe: %w", err)
 	}
 	stderrPipe, err = cmd.StderrPipe()
 	if err != nil {
-		return false, fmt.Errorf("failed to create stderr pipe: %w", err)
+		return false, converter, fmt.Errorf("failed to create stderr pipe: %w", err)
 	}
 
 	// Start command
 	if err := cmd.Start(); err != nil {
-		return false, fmt.Errorf("failed to start axon install: %w", err)
+		return false, converter, fmt.Errorf("failed to start axon install: %w", err)
 	}
 
 	// Stream output with progress filtering
@@ -111,7 +125,7 @@ func Install(modelSpec string, testAllModels bool) (bool, error) {
 			stdout.WriteString(line + "\n")
 			// Show meaningful progress messages
 			if isProgressMessage(line) {
-				fmt.Printf("   %s\n", line)
+				fmt.Fprintf(opts.Out(), "   %s\n", line)
 			}
 		}
 	}()
@@ -127,7 +141,7 @@ func Install(modelSpec string, testAllModels bool) (bool, error) {
 			if strings.Contains(lineLower, "error") || 
 			   strings.Contains(lineLower, "warning") ||
 			   strings.Contains(lineLower, "failed") {
-				fmt.Printf("   ⚠️  %s\n", line)
+				fmt.Fprintf(opts.Out(), "   ⚠️  %s\n", line)
 			}
 		}
 	}()
@@ -153,102 +167,100 @@ func Install(modelSpec string, testAllModels bool) (bool, error) {
 			if len(stdoutStr) > 500 {
 				lines := strings.Split(stdoutStr, "\n")
 				if len(lines) > 10 {
-					fmt.Printf("\nAxon stdout (last 10 lines):\n%s\n", strings.Join(lines[len(lines)-10:], "\n"))
+					fmt.Fprintf(opts.Out(), "\nAxon stdout (last 10 lines):\n%s\n", strings.Join(lines[len(lines)-10:], "\n"))
 				} else {
-					fmt.Printf("\nAxon stdout:\n%s\n", stdoutStr)
+					fmt.Fprintf(opts.Out(), "\nAxon stdout:\n%s\n", stdoutStr)
 				}
 			} else if len(stdoutStr) > 0 {
-				fmt.Printf("\nAxon stdout: %s\n", stdoutStr)
+				fmt.Fprintf(opts.Out(), "\nAxon stdout: %s\n", stdoutStr)
 			}
 			
 			if err != nil {
 				// Log captured stderr on error
 				if len(stderrStr) > 0 {
-					fmt.Printf("Axon stderr: %s\n", stderrStr)
+					fmt.Fprintf(opts.Out(), "Axon stderr: %s\n", stderrStr)
 				}
 				
 				// List cache directory to help debug
-				homeDirDebug, _ := os.UserHomeDir()
-				cacheDirDebug := filepath.Join(homeDirDebug, ".axon", "cache", "models")
-				fmt.Printf("\n📁 Checking axon cache: %s\n", cacheDirDebug)
+				cacheDirDebug := modelsDir(cacheDir)
+				fmt.Fprintf(opts.Out(), "\n📁 Checking axon cache: %s\n", cacheDirDebug)
 				
 				if entries, readErr := os.ReadDir(cacheDirDebug); readErr == nil {
-					fmt.Printf("   Cache contains %d entries:\n", len(entries))
+					fmt.Fprintf(opts.Out(), "   Cache contains %d entries:\n", len(entries))
 					for i, entry := range entries {
 						if i >= 10 {
-							fmt.Printf("   ... and %d more\n", len(entries)-10)
+							fmt.Fprintf(opts.Out(), "   ... and %d more\n", len(entries)-10)
 							break
 						}
-						fmt.Printf("   - %s (dir: %v)\n", entry.Name(), entry.IsDir())
+						fmt.Fprintf(opts.Out(), "   - %s (dir: %v)\n", entry.Name(), entry.IsDir())
 					}
 				} else {
-					fmt.Printf("   ⚠️  Cannot read cache directory: %v\n", readErr)
+					fmt.Fprintf(opts.Out(), "   ⚠️  Cannot read cache directory: %v\n", readErr)
 				}
 				
-				return false, fmt.Errorf("axon install failed: %w", err)
+				return false, converter, fmt.Errorf("axon install failed: %w", err)
 			}
 			
 			// Check for errors in output even if exit code is 0
-			if strings.Contains(stderrStr, "error") || strings.Contains(stderrStr, "failed") {
-				fmt.Printf("\nAxon stderr (contains errors):\n%s\n", stderrStr)
-				return false, fmt.Errorf("axon install reported errors: %s", stderrStr)
+			if errLines := matcher.Errors(stderrStr); len(errLines) > 0 {
+				fmt.Fprintf(opts.Out(), "\nAxon stderr (contains errors):\n%s\n", stderrStr)
+				return false, converter, fmt.Errorf("axon install reported errors: %s", strings.Join(errLines, "; "))
 			}
 			
 			// Check for Docker/ONNX conversion issues
 			outputStr := stdoutStr + "\n" + stderrStr
 			if strings.Contains(outputStr, "ONNX conversion failed") {
-				fmt.Printf("❌ ONNX conversion failed during installation\n")
+				fmt.Fprintf(opts.Out(), "❌ ONNX conversion failed during installation\n")
 				if strings.Contains(outputStr, "ModuleNotFoundError") {
-					fmt.Printf("   Docker converter image may be broken or not pulled\n")
+					fmt.Fprintf(opts.Out(), "   Docker converter image may be broken or not pulled\n")
 				}
 				if strings.Contains(outputStr, "execution_format: pytorch") {
-					fmt.Printf("   ⚠️  Axon fell back to PyTorch format (MLOS Core won't support this)\n")
+					fmt.Fprintf(opts.Out(), "   ⚠️  Axon fell back to PyTorch format (MLOS Core won't support this)\n")
 				}
 			}
 			
-			fmt.Printf("\n✅ Axon install completed (exit code 0)\n")
+			fmt.Fprintf(opts.Out(), "\n✅ Axon install completed (exit code 0)\n")
 			
 			// Verify model was actually installed
-			modelPath, verifyErr := GetPath(modelSpec)
+			modelPath, verifyErr := GetPath(modelSpec, cacheDir, filenames, opts)
 			if verifyErr != nil {
 				// Log output to help debug
-				fmt.Printf("⚠️  Model path verification failed: %v\n", verifyErr)
+				fmt.Fprintf(opts.Out(), "⚠️  Model path verification failed: %v\n", verifyErr)
 				
 				// List actual contents of axon cache to help debug
-				homeDir, _ := os.UserHomeDir()
-				cacheDir := filepath.Join(homeDir, ".axon", "cache", "models")
-				fmt.Printf("   Listing axon cache directory: %s\n", cacheDir)
+				cacheModelsDir := modelsDir(cacheDir)
+				fmt.Fprintf(opts.Out(), "   Listing axon cache directory: %s\n", cacheModelsDir)
 				
 				// Walk the directory tree to find actual files
 				var foundFiles []string
-				walkErr := filepath.Walk(cacheDir, func(path string, info os.FileInfo, err error) error {
+				walkErr := filepath.Walk(cacheModelsDir, func(path string, info os.FileInfo, err error) error {
 					if err != nil {
 						return nil // Skip errors
 					}
 					if !info.IsDir() {
-						relPath, _ := filepath.Rel(cacheDir, path)
+						relPath, _ := filepath.Rel(cacheModelsDir, path)
 						foundFiles = append(foundFiles, relPath)
 					}
 					return nil
 				})
 				if walkErr != nil {
-					fmt.Printf("   ⚠️  Error walking cache directory: %v\n", walkErr)
+					fmt.Fprintf(opts.Out(), "   ⚠️  Error walking cache directory: %v\n", walkErr)
 				}
 				
 				if len(foundFiles) > 0 {
-					fmt.Printf("   Found %d files in cache:\n", len(foundFiles))
+					fmt.Fprintf(opts.Out(), "   Found %d files in cache:\n", len(foundFiles))
 					for i, file := range foundFiles {
 						if i >= 15 {
-							fmt.Printf("   ... and %d more files\n", len(foundFiles)-15)
+							fmt.Fprintf(opts.Out(), "   ... and %d more files\n", len(foundFiles)-15)
 							break
 						}
-						fmt.Printf("   - %s\n", file)
+						fmt.Fprintf(opts.Out(), "   - %s\n", file)
 					}
 					
 					// Check specifically for the expected model path
-					expectedPath := filepath.Join(cacheDir, "hf", "distilgpt2", "latest", "model.onnx")
+					expectedPath := filepath.Join(cacheModelsDir, "hf", "distilgpt2", "latest", "model.onnx")
 					if _, err := os.Stat(expectedPath); os.IsNotExist(err) {
-						fmt.Printf("   ❌ Expected file missing: hf/distilgpt2/latest/model.onnx\n")
+						fmt.Fprintf(opts.Out(), "   ❌ Expected file missing: hf/distilgpt2/latest/model.onnx\n")
 						
 						// Look for any .onnx files
 						onnxFiles := []string{}
@@ -258,26 +270,26 @@ func Install(modelSpec string, testAllModels bool) (bool, error) {
 							}
 						}
 						if len(onnxFiles) > 0 {
-							fmt.Printf("   Found .onnx files: %v\n", onnxFiles)
+							fmt.Fprintf(opts.Out(), "   Found .onnx files: %v\n", onnxFiles)
 						} else {
-							fmt.Printf("   ⚠️  No .onnx files found in cache\n")
-							fmt.Printf("   Model may be in PyTorch format (check for .pt or .bin files)\n")
+							fmt.Fprintf(opts.Out(), "   ⚠️  No .onnx files found in cache\n")
+							fmt.Fprintf(opts.Out(), "   Model may be in PyTorch format (check for .pt or .bin files)\n")
 						}
 					}
 				} else {
-					fmt.Printf("   ⚠️  No files found in cache directory\n")
+					fmt.Fprintf(opts.Out(), "   ⚠️  No files found in cache directory\n")
 				}
 				
-				return false, fmt.Errorf("installation succeeded but model not found at expected path: %w", verifyErr)
+				return false, converter, fmt.Errorf("installation succeeded but model not found at expected path: %w", verifyErr)
 			}
 			
 			// Log successful path for debugging
-			fmt.Printf("✅ Model installed at: %s\n", modelPath)
+			fmt.Fprintf(opts.Out(), "✅ Model installed at: %s\n", modelPath)
 			
-			return true, nil
+			return true, converter, nil
 		case <-timeout.C:
 			// Show that we're still waiting (if no progress messages shown)
-			fmt.Printf("   ⏳ Still installing...\n")
+			fmt.Fprintf(opts.Out(), "   ⏳ Still installing...\n")
 			// Continue waiting
 		}
 	}
@@ -313,50 +325,78 @@ func isProgressMessage(line string) bool {
 	return false
 }
 
+// DefaultModelFilenames are the ONNX files accepted for an installed model, in order of preference
+// Patterns use filepath.Match syntax; for sharded exports the first shard is picked
+var DefaultModelFilenames = []string{
+	"model.onnx",
+	"model_quantized.onnx",
+	"model_int8.onnx",
+	"model_fp16.onnx",
+	"model-00001-of-*.onnx",
+}
+
 // GetPath returns the path to an installed model
-func GetPath(modelSpec string) (string, error) {
-	parts := strings.Split(modelSpec, "@")
-	if len(parts) != 2 {
-		return "", fmt.Errorf("invalid model spec format: %s", modelSpec)
+// cacheDir is Axon's cache root (empty means the default ~/.axon/cache)
+// filenames are the candidate ONNX filenames in order of preference (nil means DefaultModelFilenames)
+// If none is found, the files that are there are listed to opts.Out() for debugging.
+func GetPath(modelSpec, cacheDir string, filenames []string, opts release.Options) (string, error) {
+	dirs, err := modelDirs(modelSpec, cacheDir)
+	if err != nil {
+		return "", err
+	}
+	if len(filenames) == 0 {
+		filenames = DefaultModelFilenames
 	}
-
-	repoModel := parts[0]
-	version := parts[1]
 
 	// MLOS Core requires ONNX format - no fallback to PyTorch
-	modelPath := GetModelPath(repoModel, version)
-	if _, err := os.Stat(modelPath); err == nil {
-		return modelPath, nil
-	}
-	
-	// Try alternative path format
-	homeDir, _ := os.UserHomeDir()
-	altPath := filepath.Join(homeDir, ".axon", "cache", "models", 
-		strings.ReplaceAll(strings.ReplaceAll(modelSpec, "/", "-"), "@", "-"), "model.onnx")
-	if _, err2 := os.Stat(altPath); err2 == nil {
-		return altPath, nil
+	baseDir, altDir := dirs[0], dirs[1]
+	for _, dir := range dirs {
+		if modelPath := findModelFile(dir, filenames); modelPath != "" {
+			return modelPath, nil
+		}
 	}
 	
 	// ONNX file not found - this is a hard error
 	// Check what files actually exist to help debug
-	baseDir := filepath.Join(homeDir, ".axon", "cache", "models", repoModel, version)
+	var found []string
 	if entries, readErr := os.ReadDir(baseDir); readErr == nil && len(entries) > 0 {
-		fmt.Printf("❌ ONNX model not found, but found these files:\n")
+		fmt.Fprintf(opts.Out(), "❌ ONNX model not found, but found these files:\n")
 		for i, entry := range entries {
+			found = append(found, entry.Name())
 			if i >= 10 {
-				fmt.Printf("   ... and %d more\n", len(entries)-10)
-				break
+				continue
 			}
-			fmt.Printf("   - %s\n", entry.Name())
+			fmt.Fprintf(opts.Out(), "   - %s\n", entry.Name())
+		}
+		if len(entries) > 10 {
+			fmt.Fprintf(opts.Out(), "   ... and %d more\n", len(entries)-10)
 		}
 		if hasAnyFile(baseDir, "pytorch_model.bin", "model.safetensors", "model.pt") {
-			fmt.Printf("❌ PyTorch format found - Docker ONNX conversion FAILED\n")
-			fmt.Printf("   MLOS Core requires ONNX format\n")
-			fmt.Printf("   Check Docker logs during 'axon install' for conversion errors\n")
+			fmt.Fprintf(opts.Out(), "❌ PyTorch format found - Docker ONNX conversion FAILED\n")
+			fmt.Fprintf(opts.Out(), "   MLOS Core requires ONNX format\n")
+			fmt.Fprintf(opts.Out(), "   Check Docker logs during 'axon install' for conversion errors\n")
 		}
 	}
 	
-	return "", fmt.Errorf("ONNX model not found (MLOS Core requires ONNX format): tried %s, %s", modelPath, altPath)
+	if len(found) == 0 {
+		return "", fmt.Errorf("ONNX model not found (MLOS Core requires ONNX format): none of [%s] in %s or %s",
+			strings.Join(filenames, ", "), baseDir, altDir)
+	}
+	return "", fmt.Errorf("ONNX model not found (MLOS Core requires ONNX format): none of [%s] in %s (found: %s)",
+		strings.Join(filenames, ", "), baseDir, strings.Join(found, ", "))
+}
+
+// findModelFile returns the first file in dir matching the candidate patterns, or "" if none do
+func findModelFile(dir string, filenames []string) string {
+	for _, pattern := range filenames {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil || len(matches) == 0 {
+			continue
+		}
+		sort.Strings(matches)
+		return matches[0]
+	}
+	return ""
 }
 
 // hasAnyFile checks if any of the given files exist in the directory
@@ -370,12 +410,23 @@ func hasAnyFile(dir string, filenames ...string) bool {
 }
 
 // loadConverterImage downloads and loads the Axon converter Docker image from release artifacts
-func loadConverterImage(axonVersion string) error {
-	// Check if image is already loaded
-	checkCmd := exec.Command("docker", "images", "-q", "ghcr.io/mlos-foundation/axon-converter")
-	if output, err := checkCmd.Output(); err == nil && len(strings.TrimSpace(string(output))) > 0 {
-		fmt.Printf("   Converter image already loaded\n")
-		return nil
+// With converterDigest set, it fails if the loaded image is not the one pinned ("" uses the version tag as loaded).
+func loadConverterImage(axonVersion, mirror, converterDigest string, opts release.Options) (release.Transfer, error) {
+	// Check if image is already loaded (with a pinned digest, only that exact image counts)
+	if converterDigest != "" {
+		if ref := findPinnedConverter(converterDigest); ref != "" {
+			fmt.Fprintf(opts.Out(), "   Pinned converter image %s already loaded\n", converterDigest)
+			if err := exec.Command("docker", "tag", ref, converterRepo+":latest").Run(); err != nil {
+				return release.Transfer{}, fmt.Errorf("failed to tag image: %w", err)
+			}
+			return release.Transfer{}, nil
+		}
+	} else {
+		checkCmd := exec.Command("docker", "images", "-q", converterRepo)
+		if output, err := checkCmd.Output(); err == nil && len(strings.TrimSpace(string(output))) > 0 {
+			fmt.Fprintf(opts.Out(), "   Converter image already loaded\n")
+			return release.Transfer{}, nil
+		}
 	}
 	
 	// Determine platform for artifact name
@@ -386,76 +437,99 @@ func loadConverterImage(axonVersion string) error {
 		} else if runtime.GOARCH == "arm64" {
 			platform = "linux-arm64"
 		} else {
-			return fmt.Errorf("unsupported architecture: %s", runtime.GOARCH)
+			return release.Transfer{}, fmt.Errorf("unsupported architecture: %s", runtime.GOARCH)
 		}
 	} else if runtime.GOOS == "darwin" {
 		// On macOS, use linux-amd64 (Docker Desktop runs Linux VMs)
 		platform = "linux-amd64"
 	} else {
-		return fmt.Errorf("unsupported OS: %s", runtime.GOOS)
+		return release.Transfer{}, fmt.Errorf("unsupported OS: %s", runtime.GOOS)
 	}
 	
 	// Download converter image artifact from Axon release
 	converterArtifact := fmt.Sprintf("axon-converter-%s-%s.tar.gz", strings.TrimPrefix(axonVersion, "v"), platform)
-	converterPath := filepath.Join("/tmp", converterArtifact)
+	tmpDir, err := release.RunTempDir()
+	if err != nil {
+		return release.Transfer{}, err
+	}
+	converterPath := filepath.Join(tmpDir, converterArtifact)
 	
-	fmt.Printf("   Downloading %s...\n", converterArtifact)
-	downloadCmd := exec.Command("gh", "release", "download", axonVersion,
-		"--repo", "mlOS-foundation/axon",
-		"--pattern", converterArtifact,
-		"--dir", "/tmp",
-		"--clobber") // Overwrite if exists
-	if _, err := downloadCmd.CombinedOutput(); err != nil {
-		// Fallback to curl for public repos (gh requires auth even for public repos)
-		fmt.Printf("   gh download failed, trying curl for public release...\n")
-		downloadURL := fmt.Sprintf("https://github.com/mlOS-foundation/axon/releases/download/%s/%s", axonVersion, converterArtifact)
-		curlCmd := exec.Command("curl", "-L", "-f", "-#", "-o", converterPath, downloadURL)
-		curlCmd.Stderr = os.Stderr // Show curl's progress bar
-		if curlErr := curlCmd.Run(); curlErr != nil {
-			return fmt.Errorf("failed to download converter artifact (gh: %v, curl: %v)", err, curlErr)
+	fmt.Fprintf(opts.Out(), "   Downloading %s...\n", converterArtifact)
+	start := time.Now()
+	if mirror != "" {
+		if err := release.FetchAsset(mirror, "mlOS-foundation/axon", axonVersion, converterArtifact, converterPath, opts); err != nil {
+			return release.Transfer{}, fmt.Errorf("failed to fetch converter artifact from mirror: %w", err)
 		}
-		fmt.Printf("   ✅ Downloaded via curl\n")
+	} else if err := release.DownloadGitHubAsset("mlOS-foundation/axon", axonVersion, converterArtifact, tmpDir, opts); err != nil {
+		return release.Transfer{}, fmt.Errorf("failed to download converter artifact: %w", err)
 	}
 	defer os.Remove(converterPath) // Cleanup after loading
+	transfer := release.TransferOf(converterPath, start)
 	
 	// Load image into Docker
-	fmt.Printf("   Loading image into Docker...\n")
+	fmt.Fprintf(opts.Out(), "   Loading image into Docker...\n")
 	loadCmd := exec.Command("docker", "load", "-i", converterPath)
 	if output, err := loadCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to load image: %w, output: %s", err, string(output))
+		return release.Transfer{}, fmt.Errorf("failed to load image: %w, output: %s", err, string(output))
 	} else {
-		fmt.Printf("   %s\n", strings.TrimSpace(string(output)))
+		fmt.Fprintf(opts.Out(), "   %s\n", strings.TrimSpace(string(output)))
 	}
 	
+	// The version tag is mutable; a pinned digest makes sure the artifact is the image expected
+	versionTag := fmt.Sprintf("%s:%s", converterRepo, strings.TrimPrefix(axonVersion, "v"))
+	if err := verifyConverterDigest(versionTag, converterDigest); err != nil {
+		return release.Transfer{}, err
+	}
+
 	// Tag as :latest (Axon looks for this tag)
-	fmt.Printf("   Tagging as :latest for Axon compatibility...\n")
-	versionTag := fmt.Sprintf("ghcr.io/mlos-foundation/axon-converter:%s", strings.TrimPrefix(axonVersion, "v"))
-	latestTag := "ghcr.io/mlos-foundation/axon-converter:latest"
+	fmt.Fprintf(opts.Out(), "   Tagging as :latest for Axon compatibility...\n")
+	latestTag := converterRepo + ":latest"
 	tagCmd := exec.Command("docker", "tag", versionTag, latestTag)
 	if err := tagCmd.Run(); err != nil {
-		return fmt.Errorf("failed to tag image: %w", err)
+		return release.Transfer{}, fmt.Errorf("failed to tag image: %w", err)
 	}
 	
-	return nil
+	return transfer, nil
 }
 
 // GetModelPath returns the expected path for a model
 // Matches bash script: ~/.axon/cache/models/${model_id%@*}/${model_id##*@}/model.onnx
 // For "hf/distilgpt2@latest": ~/.axon/cache/models/hf/distilgpt2/latest/model.onnx
-func GetModelPath(repoModel, version string) string {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		// Fallback to current directory if home directory cannot be determined
-		homeDir = "."
-	}
-	// Format: ~/.axon/cache/models/{repoModel}/{version}/model.onnx
+func GetModelPath(repoModel, version, cacheDir string) string {
+	// Format: {cacheDir}/models/{repoModel}/{version}/model.onnx
 	// Example: hf/distilgpt2 + latest -> ~/.axon/cache/models/hf/distilgpt2/latest/model.onnx
-	return filepath.Join(homeDir, ".axon", "cache", "models", repoModel, version, "model.onnx")
+	return filepath.Join(modelsDir(cacheDir), repoModel, version, "model.onnx")
+}
+
+// modelsDir returns the models directory inside Axon's cache
+// An empty cacheDir means Axon's default (~/.axon/cache)
+func modelsDir(cacheDir string) string {
+	if cacheDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			// Fallback to current directory if home directory cannot be determined
+			homeDir = "."
+		}
+		cacheDir = filepath.Join(homeDir, ".axon", "cache")
+	}
+	return filepath.Join(cacheDir, "models")
+}
+
+// axonEnv returns the environment for axon subprocesses, pointing Axon at cacheDir if set
+func axonEnv(cacheDir string) []string {
+	env := os.Environ()
+	if cacheDir != "" {
+		env = append(env, fmt.Sprintf("AXON_CACHE_DIR=%s", cacheDir))
+	}
+	return env
 }
 
 // Register registers a model with MLOS Core using axon register command
 // modelSpec should be the full model spec (e.g., "hf/distilgpt2@latest")
-func Register(modelSpec string, port int) error {
+// coreURL is the base URL of Core (e.g., "http://127.0.0.1:18080")
+// cacheDir is Axon's cache root (empty means the default ~/.axon/cache)
+// matcher decides whether the output of a successful axon register still reports a failure
+func Register(modelSpec, coreURL, cacheDir string, matcher AxonOutputMatcher, opts release.Options) error {
 	// Use axon register command (proper flow: install -> register -> inference)
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -463,14 +537,14 @@ func Register(modelSpec string, port int) error {
 	}
 	
 	axonBin := filepath.Join(homeDir, ".local", "bin", "axon")
-	coreURL := fmt.Sprintf("http://127.0.0.1:%d", port)
 	
 	cmd := exec.Command(axonBin, "register", modelSpec)
 	// Set MLOS_CORE_ENDPOINT environment variable (axon register uses this, not a flag)
-	env := os.Environ()
+	env := axonEnv(cacheDir)
 	env = append(env, fmt.Sprintf("MLOS_CORE_ENDPOINT=%s", coreURL))
 	cmd.Env = env
 	cmd.Dir = homeDir
+	release.LogCommand(cmd, opts)
 	
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -478,8 +552,8 @@ func Register(modelSpec string, port int) error {
 	}
 
 	// Check for error in output
-	if strings.Contains(strings.ToLower(string(output)), "error") {
-		return fmt.Errorf("registration failed: %s", string(output))
+	if errLines := matcher.Errors(string(output)); len(errLines) > 0 {
+		return fmt.Errorf("registration failed: %s", strings.Join(errLines, "; "))
 	}
 
 	return nil
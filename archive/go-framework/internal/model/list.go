@@ -0,0 +1,62 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// ListModels returns the IDs of the models Core reports as registered
+// Returns nil (and no error) if Core has no model-list endpoint
+func ListModels(coreURL string, opts release.Options) ([]string, error) {
+	client := release.NewCoreClient(10*time.Second, opts)
+
+	resp, err := client.Get(coreURL + "/models")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Ignore close errors on response body
+	}()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("model list request failed with status %d", resp.StatusCode)
+	}
+
+	var body interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to parse model list: %w", err)
+	}
+
+	// Either a bare array or wrapped as {"models": [...]}
+	if wrapped, ok := body.(map[string]interface{}); ok {
+		body = wrapped["models"]
+	}
+	entries, ok := body.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unrecognized model list format")
+	}
+
+	ids := []string{}
+	for _, entry := range entries {
+		switch v := entry.(type) {
+		case string:
+			ids = append(ids, v)
+		case map[string]interface{}:
+			// Entries are objects keyed by whichever ID field this Core version uses
+			for _, key := range []string{"model_id", "id", "name"} {
+				if id, ok := v[key].(string); ok && id != "" {
+					ids = append(ids, id)
+					break
+				}
+			}
+		}
+	}
+	return ids, nil
+}
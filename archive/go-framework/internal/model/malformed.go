@@ -0,0 +1,33 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// MalformedPayload is an invalid inference request body Core should reject with a 4xx
+type MalformedPayload struct {
+	Name string
+	Body []byte
+}
+
+// MalformedPayloads returns the invalid bodies sent by the malformed-payload failure injection
+// inputKey is the JSON key Core reads token IDs from (DefaultInputKey if empty)
+func MalformedPayloads(inputKey string) []MalformedPayload {
+	if inputKey == "" {
+		inputKey = DefaultInputKey
+	}
+	return []MalformedPayload{
+		{Name: "truncated JSON", Body: []byte(fmt.Sprintf(`{"%s": [101, 2023, `, inputKey))},
+		{Name: "empty body", Body: []byte{}},
+		{Name: "string instead of tensor", Body: []byte(fmt.Sprintf(`{"%s": "not a tensor"}`, inputKey))},
+		{Name: "no inputs", Body: []byte(`{}`)},
+	}
+}
+
+// RunRawInference POSTs body to a model's inference endpoint as-is, without generating an input
+// Used to check how Core handles bodies the harness would never normally send.
+func RunRawInference(modelIDForURL string, body []byte, coreURL string, timeout time.Duration, opts InferenceOptions) error {
+	_, _, err := postInference(inferenceURL(coreURL, modelIDForURL, "", opts), modelIDForURL, body, coreURL, timeout, opts)
+	return err
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// ManifestEntry identifies the exact model file a run tested
+type ManifestEntry struct {
+	Path      string `json:"path"`
+	Variant   string `json:"variant"` // Which candidate file was used (e.g. model_quantized.onnx)
+	SHA256    string `json:"sha256"`
+	SizeBytes int64  `json:"sizeBytes"`
+
+	// Files the model loads from beside it: external tensor data or other shards (see ExternalDataFiles)
+	ExternalData []string `json:"externalData,omitempty"`
+}
+
+// HashModel locates the installed ONNX file for a model spec and hashes it
+func HashModel(modelSpec, cacheDir string, filenames []string, opts release.Options) (ManifestEntry, error) {
+	modelPath, err := GetPath(modelSpec, cacheDir, filenames, opts)
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+
+	f, err := os.Open(modelPath)
+	if err != nil {
+		return ManifestEntry{}, fmt.Errorf("failed to open model: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return ManifestEntry{}, fmt.Errorf("failed to hash model: %w", err)
+	}
+
+	externalData, err := ExternalDataFiles(modelPath)
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+
+	return ManifestEntry{
+		Path:         modelPath,
+		Variant:      filepath.Base(modelPath),
+		SHA256:       hex.EncodeToString(h.Sum(nil)),
+		SizeBytes:    size,
+		ExternalData: externalData,
+	}, nil
+}
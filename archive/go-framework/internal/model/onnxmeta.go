@@ -0,0 +1,220 @@
+package model
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ONNXMetadata is what ReadONNXMetadata extracts from an ONNX model's protobuf
+type ONNXMetadata struct {
+	Inputs     []string          // Graph inputs a caller must feed, in graph order (initializers excluded)
+	Outputs    []string          // Graph outputs, in graph order
+	Producer   string            // producer_name, e.g. "pytorch"
+	Properties map[string]string // metadata_props, e.g. license or author when the exporter set them
+}
+
+// ONNX protobuf field numbers ReadONNXMetadata needs (see onnx.proto)
+const (
+	onnxModelProducer    = 2  // ModelProto.producer_name
+	onnxModelGraph       = 7  // ModelProto.graph
+	onnxModelProps       = 14 // ModelProto.metadata_props
+	onnxGraphInit        = 5  // GraphProto.initializer
+	onnxGraphInput       = 11 // GraphProto.input
+	onnxGraphOutput      = 12 // GraphProto.output
+	onnxValueInfoName    = 1  // ValueInfoProto.name
+	onnxTensorName       = 8  // TensorProto.name
+	onnxStringEntryKey   = 1  // StringStringEntryProto.key
+	onnxStringEntryValue = 2  // StringStringEntryProto.value
+)
+
+// maxONNXString bounds the names and property values read; anything longer is not metadata
+const maxONNXString = 1 << 16
+
+// ReadONNXMetadata reads an ONNX model's graph input and output names and its metadata properties
+// Only the fields it needs are decoded; weights are skipped over, so multi-GB models aren't loaded
+// into memory. Older exporters list initializers among the graph inputs; those are left out.
+func ReadONNXMetadata(modelPath string) (ONNXMetadata, error) {
+	f, err := os.Open(modelPath)
+	if err != nil {
+		return ONNXMetadata{}, fmt.Errorf("failed to open model: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return ONNXMetadata{}, fmt.Errorf("failed to open model: %w", err)
+	}
+
+	p := &protoReader{r: bufio.NewReaderSize(f, 64*1024)}
+	meta := ONNXMetadata{Properties: make(map[string]string)}
+	var inputs []string
+	initializers := make(map[string]bool)
+
+	err = p.message(info.Size(), func(field int, length int64) error {
+		switch field {
+		case onnxModelProducer:
+			s, err := p.str(length)
+			meta.Producer = s
+			return err
+		case onnxModelProps:
+			var key, value string
+			err := p.message(length, func(field int, length int64) error {
+				var err error
+				switch field {
+				case onnxStringEntryKey:
+					key, err = p.str(length)
+				case onnxStringEntryValue:
+					value, err = p.str(length)
+				default:
+					err = p.skip(length)
+				}
+				return err
+			})
+			if key != "" {
+				meta.Properties[key] = value
+			}
+			return err
+		case onnxModelGraph:
+			return p.message(length, func(field int, length int64) error {
+				switch field {
+				case onnxGraphInput, onnxGraphOutput:
+					name, err := p.nameField(length, onnxValueInfoName)
+					if field == onnxGraphInput {
+						inputs = append(inputs, name)
+					} else {
+						meta.Outputs = append(meta.Outputs, name)
+					}
+					return err
+				case onnxGraphInit:
+					name, err := p.nameField(length, onnxTensorName)
+					initializers[name] = true
+					return err
+				}
+				return p.skip(length)
+			})
+		}
+		return p.skip(length)
+	})
+	if err != nil {
+		return ONNXMetadata{}, fmt.Errorf("failed to parse %s as ONNX: %w", modelPath, err)
+	}
+
+	for _, name := range inputs {
+		if !initializers[name] {
+			meta.Inputs = append(meta.Inputs, name)
+		}
+	}
+	if len(meta.Inputs) == 0 && len(meta.Outputs) == 0 {
+		return ONNXMetadata{}, fmt.Errorf("%s has no graph inputs or outputs; is it an ONNX model?", modelPath)
+	}
+	return meta, nil
+}
+
+// protoReader decodes protobuf wire format from a stream, tracking how much has been read
+type protoReader struct {
+	r   *bufio.Reader
+	pos int64
+}
+
+// message calls onField for every length-delimited field of the next length bytes
+// onField must consume exactly the field's length bytes. Scalar fields are skipped.
+func (p *protoReader) message(length int64, onField func(field int, length int64) error) error {
+	end := p.pos + length
+	for p.pos < end {
+		tag, err := p.varint()
+		if err != nil {
+			return err
+		}
+		field, wire := int(tag>>3), tag&7
+		switch wire {
+		case 0: // varint
+			if _, err := p.varint(); err != nil {
+				return err
+			}
+		case 1: // 64-bit
+			err = p.skip(8)
+		case 5: // 32-bit
+			err = p.skip(4)
+		case 2: // length-delimited
+			var n uint64
+			n, err = p.varint()
+			if err == nil {
+				if int64(n) < 0 || p.pos+int64(n) > end {
+					return fmt.Errorf("field %d overruns its message at byte %d", field, p.pos)
+				}
+				err = onField(field, int64(n))
+			}
+		default:
+			return fmt.Errorf("unsupported wire type %d at byte %d", wire, p.pos)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if p.pos != end {
+		return fmt.Errorf("message overruns its length at byte %d", p.pos)
+	}
+	return nil
+}
+
+// nameField reads the string field number field of the next length-byte message, skipping the rest
+func (p *protoReader) nameField(length int64, field int) (string, error) {
+	var name string
+	err := p.message(length, func(f int, length int64) error {
+		if f != field {
+			return p.skip(length)
+		}
+		var err error
+		name, err = p.str(length)
+		return err
+	})
+	return name, err
+}
+
+func (p *protoReader) varint() (uint64, error) {
+	var v uint64
+	for shift := uint(0); shift < 64; shift += 7 {
+		b, err := p.r.ReadByte()
+		if err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		p.pos++
+		v |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("malformed varint at byte %d", p.pos)
+}
+
+func (p *protoReader) str(length int64) (string, error) {
+	if length > maxONNXString {
+		return "", fmt.Errorf("string of %d bytes at byte %d is too long", length, p.pos)
+	}
+	buf := make([]byte, length)
+	n, err := io.ReadFull(p.r, buf)
+	p.pos += int64(n)
+	if err != nil {
+		return "", unexpectedEOF(err)
+	}
+	return string(buf), nil
+}
+
+func (p *protoReader) skip(length int64) error {
+	n, err := p.r.Discard(int(length))
+	p.pos += int64(n)
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	return nil
+}
+
+// unexpectedEOF reports a file that ends inside a field as truncated
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// pbVarint encodes v as a protobuf varint
+func pbVarint(v uint64) []byte {
+	var b []byte
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+// pbBytes encodes a length-delimited field (wire type 2)
+func pbBytes(field int, body []byte) []byte {
+	b := pbVarint(uint64(field)<<3 | 2)
+	b = append(b, pbVarint(uint64(len(body)))...)
+	return append(b, body...)
+}
+
+// pbInt encodes a varint field (wire type 0)
+func pbInt(field int, v uint64) []byte {
+	return append(pbVarint(uint64(field)<<3), pbVarint(v)...)
+}
+
+func concat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+// testONNXModel is a small ModelProto: an old-style graph that lists its weight among the inputs,
+// with a node, type info, raw weights and an opset import the reader has to skip over
+func testONNXModel() []byte {
+	valueInfo := func(name string) []byte {
+		tensorType := pbBytes(1, concat(pbInt(1, 7), pbBytes(2, pbBytes(1, pbInt(1, 1))))) // TypeProto.tensor_type: int64 [1]
+		return concat(pbBytes(onnxValueInfoName, []byte(name)), pbBytes(2, tensorType))
+	}
+	graph := concat(
+		pbBytes(1, concat(pbBytes(1, []byte("input_ids")), pbBytes(2, []byte("logits")), pbBytes(4, []byte("Gather")))), // NodeProto
+		pbBytes(2, []byte("main_graph")),
+		pbBytes(onnxGraphInit, concat(pbInt(1, 4), pbInt(2, 1), pbBytes(onnxTensorName, []byte("weight")), pbBytes(9, make([]byte, 64)))),
+		pbBytes(onnxGraphInput, valueInfo("input_ids")),
+		pbBytes(onnxGraphInput, valueInfo("weight")),
+		pbBytes(onnxGraphOutput, valueInfo("logits")),
+	)
+	return concat(
+		pbInt(1, 8), // ir_version
+		pbBytes(onnxModelProducer, []byte("pytorch")),
+		pbBytes(8, pbInt(2, 17)), // opset_import
+		pbBytes(onnxModelProps, concat(pbBytes(onnxStringEntryKey, []byte("license")), pbBytes(onnxStringEntryValue, []byte("mit")))),
+		pbBytes(onnxModelGraph, graph),
+	)
+}
+
+func writeONNX(t *testing.T, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "model.onnx")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadONNXMetadata(t *testing.T) {
+	meta, err := ReadONNXMetadata(writeONNX(t, testONNXModel()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ONNXMetadata{
+		Inputs:     []string{"input_ids"}, // weight is an initializer
+		Outputs:    []string{"logits"},
+		Producer:   "pytorch",
+		Properties: map[string]string{"license": "mit"},
+	}
+	if !reflect.DeepEqual(meta, want) {
+		t.Errorf("ReadONNXMetadata = %+v, want %+v", meta, want)
+	}
+}
+
+// TestReadONNXMetadataTruncated cuts the model at every byte; the graph comes last, so every cut
+// loses it or ends inside a field, and each must be an error
+func TestReadONNXMetadataTruncated(t *testing.T) {
+	model := testONNXModel()
+	for i := 0; i < len(model); i++ {
+		if _, err := ReadONNXMetadata(writeONNX(t, model[:i])); err == nil {
+			t.Errorf("model cut to %d of %d bytes parsed without error", i, len(model))
+		}
+	}
+}
+
+func TestReadONNXMetadataMalformed(t *testing.T) {
+	graph := pbBytes(onnxGraphOutput, pbBytes(onnxValueInfoName, []byte("logits")))
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{"overlong varint", bytes.Repeat([]byte{0xff}, 11), "malformed varint"},
+		{"overlong length", concat(pbVarint(onnxModelProducer<<3|2), bytes.Repeat([]byte{0x80}, 10), []byte{1}), "malformed varint"},
+		{"length past the file", concat(pbVarint(onnxModelProducer<<3|2), pbVarint(1<<40), []byte("pytorch")), "overruns its message"},
+		{"negative length", concat(pbVarint(onnxModelProducer<<3|2), pbVarint(1<<63|1), []byte("pytorch")), "overruns its message"},
+		{"field past its message", pbBytes(onnxModelGraph, concat(pbVarint(onnxGraphOutput<<3|2), pbVarint(200), graph)), "overruns its message"},
+		{"scalar past its message", concat(pbBytes(onnxModelGraph, concat(graph, pbVarint(1<<3))), pbVarint(1<<40)), "overruns its length"},
+		{"group wire type", concat(graph, pbVarint(3<<3|3)), "unsupported wire type 3"},
+		{"oversized string", pbBytes(onnxModelProducer, make([]byte, maxONNXString+1)), "too long"},
+		{"no graph", pbBytes(onnxModelProducer, []byte("pytorch")), "no graph inputs or outputs"},
+		{"empty file", nil, "no graph inputs or outputs"},
+		{"not protobuf", []byte("PK\x03\x04 this is a zip archive"), "failed to parse"},
+	}
+	for _, tt := range tests {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		_, err := ReadONNXMetadata(writeONNX(t, tt.data))
+		runtime.ReadMemStats(&after)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+		// The 64 KiB read buffer aside, nothing near a claimed length may be allocated
+		if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1<<20 {
+			t.Errorf("%s: allocated %d bytes", tt.name, allocated)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// API styles RunInference can exercise (see InferenceOptions.APIStyle)
+const (
+	APIStyleNative = "native" // POST /models/{id}/inference with token IDs
+	APIStyleOpenAI = "openai" // POST /v1/completions and /v1/chat/completions with text
+)
+
+// defaultOpenAIPrompts are the small and large texts sent to the OpenAI-compatible routes without --prompt
+var defaultOpenAIPrompts = struct{ small, large string }{
+	small: "Hello, my name is",
+	large: strings.TrimSpace(strings.Repeat("Hello, my name is ", 4)),
+}
+
+// openAIMaxTokens keeps generated completions short; the tests check the schema, not the text
+const openAIMaxTokens = 16
+
+// runOpenAIInference sends the prompt to /v1/completions and as a user message to
+// /v1/chat/completions, and checks both answers follow the OpenAI response schema
+// The test's latency and response size therefore cover both requests.
+func runOpenAIInference(modelIDForURL string, large bool, coreURL string, timeout time.Duration, opts InferenceOptions) (int64, error) {
+	prompt, fallback := opts.OpenAIPrompt, defaultOpenAIPrompts.small
+	if large {
+		prompt, fallback = opts.OpenAILargePrompt, defaultOpenAIPrompts.large
+	}
+	if prompt == "" {
+		prompt = fallback
+	}
+
+	completion := map[string]interface{}{
+		"model":      modelIDForURL,
+		"prompt":     prompt,
+		"max_tokens": openAIMaxTokens,
+	}
+	completionBytes, err := postOpenAI(coreURL+"/v1/completions", modelIDForURL, completion, coreURL, timeout, opts, validateCompletion)
+	if err != nil {
+		return completionBytes, fmt.Errorf("/v1/completions: %w", err)
+	}
+
+	chat := map[string]interface{}{
+		"model": modelIDForURL,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+		"max_tokens": openAIMaxTokens,
+	}
+	chatBytes, err := postOpenAI(coreURL+"/v1/chat/completions", modelIDForURL, chat, coreURL, timeout, opts, validateChatCompletion)
+	if err != nil {
+		return completionBytes + chatBytes, fmt.Errorf("/v1/chat/completions: %w", err)
+	}
+	return completionBytes + chatBytes, nil
+}
+
+// postOpenAI sends one OpenAI-style request and validates the decoded response
+// It returns the response size in bytes.
+func postOpenAI(url, modelID string, request map[string]interface{}, coreURL string, timeout time.Duration, opts InferenceOptions, validate func(map[string]interface{}) error) (int64, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	result, size, err := postInference(url, modelID, payload, coreURL, timeout, opts)
+	if err != nil {
+		return size, err
+	}
+	if err := validate(result); err != nil {
+		return size, fmt.Errorf("response does not match the OpenAI schema: %w", err)
+	}
+	return size, nil
+}
+
+// validateCompletion checks a /v1/completions response: a "text_completion" object whose choices carry text
+func validateCompletion(response map[string]interface{}) error {
+	return validateOpenAIResponse(response, "text_completion", func(choice map[string]interface{}) error {
+		if _, ok := choice["text"].(string); !ok {
+			return fmt.Errorf("has no text")
+		}
+		return nil
+	})
+}
+
+// validateChatCompletion checks a /v1/chat/completions response: a "chat.completion" object whose
+// choices carry an assistant message
+func validateChatCompletion(response map[string]interface{}) error {
+	return validateOpenAIResponse(response, "chat.completion", func(choice map[string]interface{}) error {
+		message, ok := choice["message"].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("has no message")
+		}
+		if role, _ := message["role"].(string); role != "assistant" {
+			return fmt.Errorf("message role is %q, expected \"assistant\"", role)
+		}
+		if _, ok := message["content"].(string); !ok {
+			return fmt.Errorf("message has no content")
+		}
+		return nil
+	})
+}
+
+// validateOpenAIResponse checks the fields every OpenAI completion response shares,
+// then each choice with checkChoice
+func validateOpenAIResponse(response map[string]interface{}, object string, checkChoice func(map[string]interface{}) error) error {
+	if got, _ := response["object"].(string); got != object {
+		return fmt.Errorf("object is %q, expected %q", got, object)
+	}
+	if id, _ := response["id"].(string); id == "" {
+		return fmt.Errorf("missing id")
+	}
+	if _, ok := response["created"].(float64); !ok {
+		return fmt.Errorf("missing created timestamp")
+	}
+	if _, ok := response["model"].(string); !ok {
+		return fmt.Errorf("missing model")
+	}
+
+	choices, ok := response["choices"].([]interface{})
+	if !ok || len(choices) == 0 {
+		return fmt.Errorf("no choices")
+	}
+	for i, raw := range choices {
+		choice, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("choice %d is not an object", i)
+		}
+		if _, ok := choice["index"].(float64); !ok {
+			return fmt.Errorf("choice %d has no index", i)
+		}
+		// finish_reason is null while a choice is unfinished, but the key is always present
+		if reason, present := choice["finish_reason"]; !present {
+			return fmt.Errorf("choice %d has no finish_reason", i)
+		} else if _, ok := reason.(string); reason != nil && !ok {
+			return fmt.Errorf("choice %d finish_reason is not a string", i)
+		}
+		if err := checkChoice(choice); err != nil {
+			return fmt.Errorf("choice %d %w", i, err)
+		}
+	}
+
+	// usage is optional, but its counts must be integers when present
+	if raw, present := response["usage"]; present && raw != nil {
+		usage, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("usage is not an object")
+		}
+		for _, key := range []string{"prompt_tokens", "completion_tokens", "total_tokens"} {
+			n, ok := usage[key].(float64)
+			if !ok || n < 0 || n != float64(int64(n)) {
+				return fmt.Errorf("usage.%s is not a non-negative integer", key)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package model
+
+import "github.com/mlOS-foundation/system-test/internal/release"
+
+// InferenceOptions are the run's settings for building, sending and checking inference requests
+// The zero value sends uncompressed requests. Every function that talks to an inference endpoint
+// takes them as its last parameter, the way Install takes the cache directory and mirror.
+type InferenceOptions struct {
+	GzipThreshold int                    // Gzip request bodies of at least this many bytes (0: never; --gzip-requests)
+	Compressed    *CompressionCounts     // Counts the requests sent gzipped (nil: not counted)
+	PromptInputs  map[string]PromptInput // Token IDs encoded from --prompt, per model name (nil: built-in inputs)
+	PathTemplate  string                 // Native inference route with a {model} placeholder ("": DefaultInferencePathTemplate)
+	Release       release.Options        // Settings for talking to Core, e.g. accepting self-signed certificates
+
+	// Print every inference request and response in full as it happens (--verbose-http)
+	// Unlike --record, nothing is written to a file; it is for debugging an API mismatch interactively.
+	VerboseHTTP bool
+
+	// Statuses other than 200 that RunInference counts as success, for negative tests
+	// Keyed by the model ID used in the request URL; models not in it must get 200.
+	ExpectedStatuses map[string]int
+
+	// Core's native or OpenAI-compatible routes for RunInference (--api-style; "" is APIStyleNative)
+	// Batched, streamed, fuzzed and malformed requests always use the native route.
+	APIStyle          string
+	OpenAIPrompt      string // Small text for the OpenAI routes ("": built-in)
+	OpenAILargePrompt string // Large text for the OpenAI routes ("": built-in)
+
+	// Captures the inference requests sent for --record (nil: not recording; see Recorder)
+	Recorder *Recorder
+
+	// Extra headers sent with every inference request, for gated or multi-tenant Core deployments
+	Headers      map[string]string
+	ModelHeaders map[string]map[string]string // Model ID used in the request URL -> headers, on top of Headers
+}
@@ -0,0 +1,300 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Session is a recorded sequence of inference exchanges (--record), replayable against another Core build (--replay)
+type Session struct {
+	CoreVersion string             `json:"coreVersion"`
+	RecordedAt  time.Time          `json:"recordedAt"`
+	Exchanges   []RecordedExchange `json:"exchanges"`
+}
+
+// RecordedExchange is one inference request and what Core answered
+type RecordedExchange struct {
+	ModelID  string          `json:"modelId"`
+	Path     string          `json:"path"` // URL path and query, relative to the Core URL
+	Request  json.RawMessage `json:"request"`
+	Status   int             `json:"status"`             // HTTP status, 0 if no response was received
+	Response json.RawMessage `json:"response,omitempty"` // Decoded response (successful requests only)
+	Error    string          `json:"error,omitempty"`
+}
+
+// replayTolerance is the relative difference below which two numbers in a response count as equal
+// Outputs of the same model on another Core build (or execution provider) differ in the last float bits.
+const replayTolerance = 1e-4
+
+// maxDivergencesPerExchange bounds how many differences are listed for one replayed request
+const maxDivergencesPerExchange = 5
+
+// volatileResponseKeys name response fields expected to differ between runs; they are not compared
+var volatileResponseKeys = []string{"time", "latency", "duration", "timestamp", "request_id"}
+
+// Recorder captures the inference requests sent with it on InferenceOptions.Recorder (--record)
+// While it records, inference URLs ask for include_outputs=true so the outputs can be compared
+// on replay. Runner.Run makes a new one for each run's inference tests and stops it after them,
+// so memory checks, fuzzing and replays are neither recorded nor sent with include_outputs.
+type Recorder struct {
+	mu        sync.Mutex
+	exchanges []RecordedExchange
+	stopped   bool
+}
+
+// Stop ends the recording; requests sent afterwards are neither changed nor captured
+func (rec *Recorder) Stop() {
+	if rec == nil {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.stopped = true
+}
+
+// Exchanges returns the captured exchanges in the order they were sent
+func (rec *Recorder) Exchanges() []RecordedExchange {
+	if rec == nil {
+		return nil
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return append([]RecordedExchange(nil), rec.exchanges...)
+}
+
+// recording reports whether rec is capturing exchanges; a nil Recorder never is
+func (rec *Recorder) recording() bool {
+	if rec == nil {
+		return false
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return !rec.stopped
+}
+
+// record captures one inference request and its outcome if rec is recording
+func (rec *Recorder) record(requestURL, coreURL, modelID string, payload []byte, result map[string]interface{}, err error) {
+	if !rec.recording() {
+		return
+	}
+	exchange := RecordedExchange{
+		ModelID: modelID,
+		Path:    strings.TrimPrefix(requestURL, coreURL),
+		Request: append(json.RawMessage(nil), payload...),
+	}
+	exchange.Status, exchange.Response, exchange.Error = exchangeOutcome(result, err)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if !rec.stopped {
+		rec.exchanges = append(rec.exchanges, exchange)
+	}
+}
+
+// exchangeOutcome turns an inference result into the status, response and error stored for it
+func exchangeOutcome(result map[string]interface{}, err error) (int, json.RawMessage, string) {
+	if err != nil {
+		var statusErr *HTTPStatusError
+		if errors.As(err, &statusErr) {
+			return statusErr.StatusCode, nil, err.Error()
+		}
+		return 0, nil, err.Error()
+	}
+	response, marshalErr := json.Marshal(result)
+	if marshalErr != nil {
+		return 200, nil, fmt.Sprintf("failed to encode response: %v", marshalErr)
+	}
+	return 200, response, ""
+}
+
+// withIncludeOutputs adds include_outputs=true to an inference URL that doesn't set it
+func withIncludeOutputs(requestURL string) string {
+	if strings.Contains(requestURL, "include_outputs=") {
+		return requestURL
+	}
+	if strings.Contains(requestURL, "?") {
+		return requestURL + "&include_outputs=true"
+	}
+	return requestURL + "?include_outputs=true"
+}
+
+// WriteSession writes a recorded session as indented JSON
+func WriteSession(path string, session Session) error {
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+// LoadSession reads a session written by WriteSession
+func LoadSession(path string) (Session, error) {
+	var session Session
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return session, fmt.Errorf("failed to read session: %w", err)
+	}
+	if err := json.Unmarshal(data, &session); err != nil {
+		return session, fmt.Errorf("failed to parse session %s: %w", path, err)
+	}
+	if len(session.Exchanges) == 0 {
+		return session, fmt.Errorf("session %s has no recorded exchanges", path)
+	}
+	return session, nil
+}
+
+// ReplayExchange sends a recorded request to coreURL again and returns how the new response
+// diverges from the recorded one (nil if it matches)
+func ReplayExchange(exchange RecordedExchange, coreURL string, timeout time.Duration, opts InferenceOptions) []string {
+	result, _, err := sendInference(coreURL+exchange.Path, exchange.ModelID, exchange.Request, coreURL, timeout, opts)
+	status, response, errText := exchangeOutcome(result, err)
+
+	if status != exchange.Status {
+		divergence := fmt.Sprintf("status %d, recorded %d", status, exchange.Status)
+		if errText != "" {
+			divergence += ": " + errText
+		}
+		return []string{divergence}
+	}
+	if exchange.Error != "" || errText != "" {
+		// Both failed the same way; the error text itself is allowed to change between builds
+		return nil
+	}
+
+	var recorded, replayed interface{}
+	if err := json.Unmarshal(exchange.Response, &recorded); err != nil {
+		return []string{fmt.Sprintf("recorded response is not valid JSON: %v", err)}
+	}
+	if err := json.Unmarshal(response, &replayed); err != nil {
+		return []string{fmt.Sprintf("response is not valid JSON: %v", err)}
+	}
+	diff := jsonDiff{tolerance: replayTolerance, reference: "recorded"}
+	diff.compare("$", recorded, replayed)
+	return diff.divergences
+}
+
+// jsonDiff collects where a response differs from a reference response, up to maxDivergencesPerExchange
+// Numbers are compared within tolerance and volatile keys (timings, request IDs) are skipped.
+type jsonDiff struct {
+	tolerance   float64 // Relative difference allowed between numbers (0: exact)
+	reference   string  // What the reference response is called in divergences, e.g. "recorded"
+	divergences []string
+}
+
+// compare appends where replayed differs from recorded, the reference, at path (e.g. $.outputs[0])
+func (d *jsonDiff) compare(path string, recorded, replayed interface{}) {
+	if len(d.divergences) >= maxDivergencesPerExchange {
+		return
+	}
+	switch want := recorded.(type) {
+	case map[string]interface{}:
+		got, ok := replayed.(map[string]interface{})
+		if !ok {
+			d.divergences = append(d.divergences, fmt.Sprintf("%s: expected an object, got %s", path, jsonKind(replayed)))
+			return
+		}
+		keys := make([]string, 0, len(want)+len(got))
+		for key := range want {
+			keys = append(keys, key)
+		}
+		for key := range got {
+			if _, ok := want[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if isVolatileKey(key) {
+				continue
+			}
+			wantValue, inWant := want[key]
+			gotValue, inGot := got[key]
+			switch {
+			case !inGot:
+				d.divergences = append(d.divergences, fmt.Sprintf("%s.%s: missing", path, key))
+			case !inWant:
+				d.divergences = append(d.divergences, fmt.Sprintf("%s.%s: not in the %s response", path, key, d.reference))
+			default:
+				d.compare(path+"."+key, wantValue, gotValue)
+			}
+			if len(d.divergences) >= maxDivergencesPerExchange {
+				return
+			}
+		}
+	case []interface{}:
+		got, ok := replayed.([]interface{})
+		if !ok {
+			d.divergences = append(d.divergences, fmt.Sprintf("%s: expected an array, got %s", path, jsonKind(replayed)))
+			return
+		}
+		if len(got) != len(want) {
+			d.divergences = append(d.divergences, fmt.Sprintf("%s: %d elements, %s %d", path, len(got), d.reference, len(want)))
+			return
+		}
+		for i := range want {
+			d.compare(fmt.Sprintf("%s[%d]", path, i), want[i], got[i])
+		}
+	case float64:
+		got, ok := replayed.(float64)
+		if !ok {
+			d.divergences = append(d.divergences, fmt.Sprintf("%s: expected a number, got %s", path, jsonKind(replayed)))
+			return
+		}
+		if !numbersMatch(want, got, d.tolerance) {
+			d.divergences = append(d.divergences, fmt.Sprintf("%s: %g, %s %g", path, got, d.reference, want))
+		}
+	default:
+		if recorded != replayed {
+			d.divergences = append(d.divergences, fmt.Sprintf("%s: %v, %s %v", path, replayed, d.reference, recorded))
+		}
+	}
+}
+
+// numbersMatch compares two numbers within tolerance of the larger magnitude (at least 1)
+func numbersMatch(a, b, tolerance float64) bool {
+	if a == b {
+		return true
+	}
+	scale := math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+	return math.Abs(a-b) <= tolerance*scale
+}
+
+// isVolatileKey reports whether a response field is expected to change from run to run
+func isVolatileKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, volatile := range volatileResponseKeys {
+		if strings.Contains(lower, volatile) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonKind names the JSON type of a decoded value for divergence messages
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case float64:
+		return "a number"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+func TestRecorderCapturesOnlyWhileRecording(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		queries = append(queries, req.URL.RawQuery)
+		_, _ = w.Write([]byte(`{"outputs":[1]}`))
+	}))
+	defer server.Close()
+
+	recorder := &Recorder{}
+	opts := InferenceOptions{Recorder: recorder, Release: release.Options{Output: io.Discard}}
+	send := func() {
+		t.Helper()
+		if _, _, err := postInference(server.URL+"/models/m/inference", "m", []byte(`{}`), server.URL, time.Second, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	send()
+	recorder.Stop()
+	send()
+	opts.Recorder = nil
+	send()
+
+	wantQueries := []string{"include_outputs=true", "", ""}
+	for i, want := range wantQueries {
+		if queries[i] != want {
+			t.Errorf("request %d query = %q, want %q", i, queries[i], want)
+		}
+	}
+	exchanges := recorder.Exchanges()
+	if len(exchanges) != 1 {
+		t.Fatalf("recorded %d exchanges, want only the one sent before Stop", len(exchanges))
+	}
+	if exchanges[0].Path != "/models/m/inference?include_outputs=true" || exchanges[0].Status != 200 {
+		t.Errorf("recorded %+v", exchanges[0])
+	}
+}
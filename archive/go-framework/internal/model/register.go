@@ -0,0 +1,21 @@
+package model
+
+import "strings"
+
+// alreadyRegisteredMarkers are phrases Core and axon use when refusing to register a model twice
+var alreadyRegisteredMarkers = []string{"already registered", "already exists", "already loaded", "status 409"}
+
+// IsAlreadyRegistered reports whether a Register error is a well-defined "already registered"
+// answer rather than a real failure
+func IsAlreadyRegistered(err error) bool {
+	if err == nil {
+		return false
+	}
+	text := strings.ToLower(err.Error())
+	for _, marker := range alreadyRegisteredMarkers {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
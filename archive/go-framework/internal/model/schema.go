@@ -0,0 +1,108 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// InputSpec describes one input tensor a registered model expects
+type InputSpec struct {
+	Name     string  `json:"name"`
+	Shape    []int64 `json:"shape,omitempty"`
+	DataType string  `json:"dtype,omitempty"`
+}
+
+// FetchInputSchema asks Core for the expected inputs of a registered model
+// Returns nil (and no error) if Core doesn't expose an input schema
+func FetchInputSchema(modelID, coreURL string, opts release.Options) ([]InputSpec, error) {
+	infoURL := fmt.Sprintf("%s/models/%s", coreURL, url.PathEscape(modelID))
+	client := release.NewCoreClient(10*time.Second, opts)
+
+	resp, err := client.Get(infoURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query model info: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Ignore close errors on response body
+	}()
+
+	// Older Cores have no per-model endpoint
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("model info request failed with status %d", resp.StatusCode)
+	}
+
+	var info struct {
+		Inputs json.RawMessage `json:"inputs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to parse model info: %w", err)
+	}
+	if len(info.Inputs) == 0 || string(info.Inputs) == "null" {
+		return nil, nil
+	}
+
+	// Inputs are either full specs or just a list of names
+	var specs []InputSpec
+	if err := json.Unmarshal(info.Inputs, &specs); err == nil {
+		return specs, nil
+	}
+	var names []string
+	if err := json.Unmarshal(info.Inputs, &names); err != nil {
+		return nil, fmt.Errorf("unrecognized inputs format in model info: %s", string(info.Inputs))
+	}
+	for _, name := range names {
+		specs = append(specs, InputSpec{Name: name})
+	}
+	return specs, nil
+}
+
+// ValidateTestInput checks the generated test input against a model's input schema
+// so a mismatch is reported by field name instead of as an opaque HTTP 400
+func ValidateTestInput(modelName, modelType, inputKey string, schema []InputSpec, opts InferenceOptions) error {
+	if len(schema) == 0 {
+		return nil // Nothing to validate against
+	}
+
+	input, err := buildTestInput(modelName, modelType, false, inputKey, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate test input: %w", err)
+	}
+
+	expected := make(map[string]bool, len(schema))
+	var expectedNames []string
+	for _, spec := range schema {
+		expected[spec.Name] = true
+		expectedNames = append(expectedNames, spec.Name)
+	}
+
+	var provided []string
+	for name := range input {
+		provided = append(provided, name)
+	}
+	sort.Strings(provided)
+
+	for _, name := range provided {
+		if !expected[name] {
+			return fmt.Errorf("input mismatch: test input field %q is not an input of the model (expects: %s)",
+				name, strings.Join(expectedNames, ", "))
+		}
+	}
+	for _, spec := range schema {
+		if _, ok := input[spec.Name]; !ok {
+			return fmt.Errorf("input mismatch: model expects input %q which the test input does not provide (provides: %s)",
+				spec.Name, strings.Join(provided, ", "))
+		}
+	}
+
+	return nil
+}
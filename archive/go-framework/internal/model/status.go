@@ -0,0 +1,50 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// expectedStatus returns the status an inference request for modelID must get
+func expectedStatus(modelID string, opts InferenceOptions) int {
+	if status, ok := opts.ExpectedStatuses[modelID]; ok {
+		return status
+	}
+	return http.StatusOK
+}
+
+// checkExpectedStatus turns the outcome of an inference request into RunInference's error
+// when the model expects a status other than 200: that status is success, anything else is not.
+func checkExpectedStatus(modelID string, err error, opts InferenceOptions) error {
+	expected := expectedStatus(modelID, opts)
+	if expected == http.StatusOK {
+		return err
+	}
+	var statusErr *HTTPStatusError
+	switch {
+	case err == nil:
+		return fmt.Errorf("expected status %d, but inference succeeded with 200", expected)
+	case errors.As(err, &statusErr) && statusErr.StatusCode == expected:
+		return nil
+	case errors.As(err, &statusErr):
+		return fmt.Errorf("expected status %d: %w", expected, err)
+	}
+	return err
+}
+
+// ResponseStatus returns the HTTP status behind RunInference's result for modelID (0 if Core never answered)
+func ResponseStatus(modelID string, err error, opts InferenceOptions) int {
+	if err == nil {
+		return expectedStatus(modelID, opts)
+	}
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+	if kind := ClassifyFailure(err); kind == FailureTimeout || kind == FailureConnection {
+		return 0
+	}
+	// Anything else went wrong after a 200 (an unreadable body, an error in it, or an unexpected success)
+	return http.StatusOK
+}
@@ -0,0 +1,195 @@
+package model
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// StreamStats holds the token timing of one streamed inference
+type StreamStats struct {
+	Tokens             int
+	TimeToFirstTokenMs float64
+	MeanInterTokenMs   float64 // Mean gap between consecutive chunks
+	MaxInterTokenMs    float64
+	TotalMs            float64
+}
+
+// ErrNotStreaming is returned when Core answers a streaming request with a buffered response
+var ErrNotStreaming = errors.New("Core did not stream the response")
+
+// Streaming content types Core may answer with
+const (
+	contentTypeEventStream = "text/event-stream"    // Server-sent events, one "data:" line per chunk
+	contentTypeNDJSON      = "application/x-ndjson" // Chunked JSON, one object per line
+)
+
+// isStreamingContentType reports whether a Content-Type header denotes a token stream
+func isStreamingContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == contentTypeEventStream || mediaType == contentTypeNDJSON
+}
+
+// RunStreamingInference requests a streamed inference and times the tokens as they arrive
+// Returns ErrNotStreaming if Core answers with a buffered JSON response instead.
+func RunStreamingInference(modelIDForURL, modelName, modelType string, coreURL string, timeout time.Duration, inputKey string, opts InferenceOptions) (StreamStats, error) {
+	input, err := buildTestInput(modelName, modelType, false, inputKey, opts)
+	if err != nil {
+		return StreamStats{}, fmt.Errorf("failed to generate test input: %w", err)
+	}
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return StreamStats{}, fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", inferenceURL(coreURL, modelIDForURL, "stream=true", opts), strings.NewReader(string(payload)))
+	if err != nil {
+		return StreamStats{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", contentTypeEventStream+", "+contentTypeNDJSON)
+	applyInferenceHeaders(req, modelIDForURL, opts)
+	logHTTPRequest(req, payload, opts)
+
+	start := time.Now()
+	resp, err := release.NewCoreClient(timeout, opts.Release).Do(req)
+	if err != nil {
+		logHTTPError(err, start, opts)
+		return StreamStats{}, fmt.Errorf("connection error: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Ignore close errors on response body
+	}()
+	respBody, captured := captureBody(resp.Body, opts)
+	defer logHTTPResponse(resp, captured, start, opts)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(respBody, maxErrorBodyBytes+1))
+		return StreamStats{}, &HTTPStatusError{StatusCode: resp.StatusCode, Body: truncateBody(body)}
+	}
+	if !isStreamingContentType(resp.Header.Get("Content-Type")) {
+		return StreamStats{}, ErrNotStreaming
+	}
+
+	stats, _, err := readStream(respBody, resp.Header.Get("Content-Type"), start)
+	return stats, err
+}
+
+// readStream consumes an event stream or NDJSON body, timing each chunk relative to start
+// It returns the last decoded chunk, fails on an error chunk, and fails if no tokens arrived.
+func readStream(body io.Reader, contentType string, start time.Time) (StreamStats, map[string]interface{}, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	var stats StreamStats
+	var last map[string]interface{}
+	var previous time.Time
+	var gapTotalMs float64
+	gaps := 0
+
+	handleChunk := func(data string) (bool, error) {
+		data = strings.TrimSpace(data)
+		if data == "" {
+			return false, nil
+		}
+		if data == "[DONE]" {
+			return true, nil
+		}
+
+		now := time.Now()
+		var chunk map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &chunk); err == nil {
+			if status, ok := chunk["status"].(string); ok && status == "error" {
+				return true, fmt.Errorf("inference error mid-stream after %d tokens: %v", stats.Tokens, chunk["message"])
+			}
+			if msg, ok := chunk["error"]; ok && msg != nil {
+				return true, fmt.Errorf("inference error mid-stream after %d tokens: %v", stats.Tokens, msg)
+			}
+			last = chunk
+		}
+
+		// A chunk may carry several tokens ("tokens": [...]); anything else counts as one
+		tokens := 1
+		if list, ok := chunk["tokens"].([]interface{}); ok {
+			tokens = len(list)
+		}
+		if tokens == 0 {
+			return false, nil
+		}
+
+		if stats.Tokens == 0 {
+			stats.TimeToFirstTokenMs = msSince(start, now)
+		} else {
+			gap := msSince(previous, now)
+			gapTotalMs += gap
+			gaps++
+			if gap > stats.MaxInterTokenMs {
+				stats.MaxInterTokenMs = gap
+			}
+		}
+		stats.Tokens += tokens
+		previous = now
+		return false, nil
+	}
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	var event []string // data lines of the current server-sent event
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		var done bool
+		var err error
+		if mediaType == contentTypeEventStream {
+			switch {
+			case line == "":
+				// A blank line dispatches the event; multi-line data is joined with newlines
+				done, err = handleChunk(strings.Join(event, "\n"))
+				event = event[:0]
+			case strings.HasPrefix(line, "data:"):
+				event = append(event, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			}
+		} else {
+			done, err = handleChunk(line)
+		}
+		if err != nil {
+			return stats, nil, err
+		}
+		if done {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return stats, nil, fmt.Errorf("stream interrupted after %d tokens: %w", stats.Tokens, err)
+	}
+	if len(event) > 0 {
+		// Stream closed without the final blank line
+		if _, err := handleChunk(strings.Join(event, "\n")); err != nil {
+			return stats, nil, err
+		}
+	}
+
+	if stats.Tokens == 0 {
+		return stats, nil, fmt.Errorf("stream ended without any tokens")
+	}
+	stats.TotalMs = msSince(start, time.Now())
+	if gaps > 0 {
+		stats.MeanInterTokenMs = gapTotalMs / float64(gaps)
+	}
+	return stats, last, nil
+}
+
+// msSince returns the milliseconds from a to b with sub-millisecond precision
+func msSince(a, b time.Time) float64 {
+	return float64(b.Sub(a).Microseconds()) / 1000
+}
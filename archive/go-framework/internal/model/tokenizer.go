@@ -0,0 +1,323 @@
+package model
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// TokenizerFiles are the tokenizer files looked for next to a model, in preference order
+var TokenizerFiles = []string{"tokenizer.json", "vocab.txt", "vocab.json"}
+
+// Tokenizer kinds, named after the Hugging Face tokenizer models they approximate
+const (
+	tokenizerWordPiece = "wordpiece" // BERT: "##" marks word continuations
+	tokenizerBPE       = "bpe"       // GPT-2/RoBERTa byte-level BPE: "Ġ" marks a preceding space
+	tokenizerUnigram   = "unigram"   // T5 SentencePiece: "▁" marks the start of a word
+)
+
+// Tokenizer turns a prompt into token IDs using a model's vocabulary
+// It matches the longest vocabulary entry at each position instead of applying the
+// model's merge rules, which yields real (if not always canonical) tokens for plain text.
+type Tokenizer struct {
+	kind      string
+	vocab     map[string]int
+	lowercase bool
+	unk       int   // -1 if the vocabulary has no unknown token
+	prefix    []int // Special tokens framing every encoded prompt
+	suffix    []int
+}
+
+// FindTokenizer returns the first of TokenizerFiles present in the model's cache directory
+func FindTokenizer(modelSpec, cacheDir string, filenames []string, opts release.Options) (string, error) {
+	modelPath, err := GetPath(modelSpec, cacheDir, filenames, opts)
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Dir(modelPath)
+	// Exported ONNX files sometimes sit in a subdirectory (e.g. onnx/) below the tokenizer
+	for _, d := range []string{dir, filepath.Dir(dir)} {
+		for _, name := range TokenizerFiles {
+			path := filepath.Join(d, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no tokenizer (%s) found next to %s", strings.Join(TokenizerFiles, ", "), modelPath)
+}
+
+// LoadTokenizer reads a Hugging Face tokenizer.json, a WordPiece vocab.txt or a BPE vocab.json
+func LoadTokenizer(path string) (*Tokenizer, error) {
+	var t *Tokenizer
+	var err error
+	switch filepath.Base(path) {
+	case "vocab.txt":
+		t, err = loadVocabTxt(path)
+	case "vocab.json":
+		t, err = loadVocabJSON(path)
+	default:
+		t, err = loadTokenizerJSON(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer %s: %w", path, err)
+	}
+	if len(t.vocab) == 0 {
+		return nil, fmt.Errorf("tokenizer %s has an empty vocabulary", path)
+	}
+	t.setSpecialTokens()
+	return t, nil
+}
+
+// lowercasePattern finds a lowercasing step in a tokenizer.json normalizer
+var lowercasePattern = regexp.MustCompile(`"lowercase"\s*:\s*true|"type"\s*:\s*"Lowercase"`)
+
+func loadTokenizerJSON(path string) (*Tokenizer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Normalizer json.RawMessage `json:"normalizer"`
+		Model      struct {
+			Type  string          `json:"type"`
+			Vocab json.RawMessage `json:"vocab"`
+		} `json:"model"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	t := &Tokenizer{vocab: make(map[string]int), lowercase: lowercasePattern.Match(file.Normalizer)}
+	switch file.Model.Type {
+	case "WordPiece", "BPE":
+		t.kind = tokenizerWordPiece
+		if file.Model.Type == "BPE" {
+			t.kind = tokenizerBPE
+		}
+		if err := json.Unmarshal(file.Model.Vocab, &t.vocab); err != nil {
+			return nil, fmt.Errorf("invalid %s vocab: %w", file.Model.Type, err)
+		}
+	case "Unigram":
+		// Unigram vocabularies are [piece, score] pairs; the ID is the position
+		var pieces [][]interface{}
+		if err := json.Unmarshal(file.Model.Vocab, &pieces); err != nil {
+			return nil, fmt.Errorf("invalid Unigram vocab: %w", err)
+		}
+		t.kind = tokenizerUnigram
+		for id, entry := range pieces {
+			if len(entry) > 0 {
+				if piece, ok := entry[0].(string); ok {
+					t.vocab[piece] = id
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported tokenizer model %q", file.Model.Type)
+	}
+	return t, nil
+}
+
+func loadVocabTxt(path string) (*Tokenizer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	t := &Tokenizer{kind: tokenizerWordPiece, vocab: make(map[string]int), lowercase: true}
+	scanner := bufio.NewScanner(f)
+	for id := 0; scanner.Scan(); id++ {
+		token := strings.TrimRight(scanner.Text(), "\r")
+		t.vocab[token] = id
+		// A cased vocabulary has capitalized words outside its [SPECIAL] tokens
+		if !strings.HasPrefix(token, "[") && strings.IndexFunc(token, unicode.IsUpper) >= 0 {
+			t.lowercase = false
+		}
+	}
+	return t, scanner.Err()
+}
+
+func loadVocabJSON(path string) (*Tokenizer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t := &Tokenizer{kind: tokenizerBPE, vocab: make(map[string]int)}
+	if err := json.Unmarshal(data, &t.vocab); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// setSpecialTokens picks the unknown token and the framing the model family expects
+func (t *Tokenizer) setSpecialTokens() {
+	t.unk = -1
+	for _, name := range []string{"[UNK]", "<unk>"} {
+		if id, ok := t.vocab[name]; ok {
+			t.unk = id
+			break
+		}
+	}
+
+	cls, hasCLS := t.vocab["[CLS]"]
+	sep, hasSEP := t.vocab["[SEP]"]
+	bos, hasBOS := t.vocab["<s>"]
+	eos, hasEOS := t.vocab["</s>"]
+	switch {
+	case t.kind == tokenizerWordPiece && hasCLS && hasSEP:
+		t.prefix, t.suffix = []int{cls}, []int{sep} // BERT: [CLS] ... [SEP]
+	case t.kind == tokenizerBPE && hasBOS && hasEOS:
+		t.prefix, t.suffix = []int{bos}, []int{eos} // RoBERTa: <s> ... </s> (GPT-2 has neither)
+	case t.kind == tokenizerUnigram && hasEOS:
+		t.suffix = []int{eos} // T5: ... </s>
+	}
+}
+
+// Encode turns text into token IDs, framed with the model's special tokens
+func (t *Tokenizer) Encode(text string) ([]int, error) {
+	if t.lowercase {
+		text = strings.ToLower(text)
+	}
+
+	var ids []int
+	for i, word := range splitWords(text) {
+		ids = append(ids, t.encodeWord(word.text, t.wordPrefix(i, word.spaceBefore))...)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%q produced no tokens", text)
+	}
+
+	framed := append(append(append([]int{}, t.prefix...), ids...), t.suffix...)
+	return framed, nil
+}
+
+// wordPrefix returns the marker the vocabulary puts on the first piece of a word
+func (t *Tokenizer) wordPrefix(index int, spaceBefore bool) string {
+	switch t.kind {
+	case tokenizerBPE:
+		if index > 0 && spaceBefore {
+			return "Ġ"
+		}
+	case tokenizerUnigram:
+		if index == 0 || spaceBefore {
+			return "▁"
+		}
+	}
+	return ""
+}
+
+// encodeWord splits one word into the longest vocabulary pieces, left to right
+func (t *Tokenizer) encodeWord(word, prefix string) []int {
+	runes := []rune(word)
+	var ids []int
+	for start := 0; start < len(runes); {
+		first := start == 0
+		end := len(runes)
+		id := -1
+		for ; end > start; end-- {
+			piece := string(runes[start:end])
+			switch {
+			case first:
+				piece = prefix + piece
+			case t.kind == tokenizerWordPiece:
+				piece = "##" + piece
+			}
+			if found, ok := t.vocab[piece]; ok {
+				id = found
+				break
+			}
+		}
+
+		if id < 0 {
+			// SentencePiece may keep the word marker as a token of its own
+			if first && prefix != "" {
+				if markerID, ok := t.vocab[prefix]; ok {
+					ids = append(ids, markerID)
+					prefix = ""
+					continue
+				}
+			}
+			// WordPiece maps a word it can't split to a single unknown token
+			if t.kind == tokenizerWordPiece {
+				if t.unk >= 0 {
+					return []int{t.unk}
+				}
+				return nil
+			}
+			// Otherwise the character becomes an unknown token, or is dropped if there is none
+			if t.unk >= 0 {
+				ids = append(ids, t.unk)
+			}
+			prefix = ""
+			start++
+			continue
+		}
+
+		ids = append(ids, id)
+		prefix = ""
+		start = end
+	}
+	return ids
+}
+
+// promptWord is one pre-tokenized word and whether whitespace preceded it
+type promptWord struct {
+	text        string
+	spaceBefore bool
+}
+
+// splitWords splits text into runs of letters/digits and single punctuation characters
+func splitWords(text string) []promptWord {
+	var words []promptWord
+	var current []rune
+	space := false
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, promptWord{text: string(current), spaceBefore: space})
+			current = nil
+			space = false
+		}
+	}
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+			space = true
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			current = append(current, r)
+		default:
+			flush()
+			words = append(words, promptWord{text: string(r), spaceBefore: space})
+			space = false
+		}
+	}
+	flush()
+	return words
+}
+
+// PromptInput is the token IDs encoded from --prompt that a model's small and large inference tests
+// send instead of the built-in ones
+type PromptInput struct {
+	Small []int
+	Large []int
+}
+
+// promptInputIDs returns the prompt token IDs set for a model in opts, if any
+func promptInputIDs(modelName string, large bool, opts InferenceOptions) ([]int, bool) {
+	prompt, ok := opts.PromptInputs[modelName]
+	if !ok {
+		return nil, false
+	}
+	if large {
+		return prompt.Large, true
+	}
+	return prompt.Small, true
+}
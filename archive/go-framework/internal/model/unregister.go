@@ -0,0 +1,87 @@
+package model
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// unregisterTimeout bounds Core's unregister request
+const unregisterTimeout = 30 * time.Second
+
+// Unregister removes a model from Core
+// It calls DELETE /models/{id} and falls back to `axon unregister` when Core has no such endpoint
+func Unregister(modelSpec, coreURL, cacheDir string, matcher AxonOutputMatcher, opts release.Options) error {
+	status, err := unregisterViaAPI(modelSpec, coreURL, opts)
+	if err == nil {
+		return nil
+	}
+	if !unregisterEndpointMissing(status) {
+		return err
+	}
+
+	if axonErr := unregisterViaAxon(modelSpec, coreURL, cacheDir, matcher, opts); axonErr != nil {
+		return fmt.Errorf("%v; axon fallback: %w", err, axonErr)
+	}
+	return nil
+}
+
+// unregisterEndpointMissing reports whether a status means Core can't unregister over HTTP
+// 404 is included because older Cores have no DELETE route at all
+func unregisterEndpointMissing(status int) bool {
+	return status == http.StatusNotFound || status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented
+}
+
+// unregisterViaAPI sends DELETE /models/{id} to Core and returns the response status (0 if none)
+func unregisterViaAPI(modelSpec, coreURL string, opts release.Options) (int, error) {
+	url := fmt.Sprintf("%s/models/%s", coreURL, url.PathEscape(modelSpec))
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := release.NewCoreClient(unregisterTimeout, opts).Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("connection error: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Ignore close errors on response body
+	}()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes+1))
+		return resp.StatusCode, fmt.Errorf("unregister failed with status %d: %s", resp.StatusCode, truncateBody(body))
+	}
+	return resp.StatusCode, nil
+}
+
+// unregisterViaAxon runs `axon unregister` against Core
+func unregisterViaAxon(modelSpec, coreURL, cacheDir string, matcher AxonOutputMatcher, opts release.Options) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	axonBin := filepath.Join(homeDir, ".local", "bin", "axon")
+	cmd := exec.Command(axonBin, "unregister", modelSpec)
+	cmd.Env = append(axonEnv(cacheDir), fmt.Sprintf("MLOS_CORE_ENDPOINT=%s", coreURL))
+	cmd.Dir = homeDir
+	release.LogCommand(cmd, opts)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("axon unregister failed: %w, output: %s", err, string(output))
+	}
+	if errLines := matcher.Errors(string(output)); len(errLines) > 0 {
+		return fmt.Errorf("unregistration failed: %s", strings.Join(errLines, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// captureBody returns the reader to consume a response body through and, when verbose HTTP logging
+// is on, the buffer the bytes read from it are copied into, so the body is read only once
+func captureBody(body io.Reader, opts InferenceOptions) (io.Reader, *bytes.Buffer) {
+	if !opts.VerboseHTTP {
+		return body, nil
+	}
+	captured := &bytes.Buffer{}
+	return io.TeeReader(body, captured), captured
+}
+
+// logHTTPRequest prints an inference request when verbose HTTP logging is on
+// payload is the body before any gzip compression, so it stays readable.
+func logHTTPRequest(req *http.Request, payload []byte, opts InferenceOptions) {
+	if !opts.VerboseHTTP {
+		return
+	}
+	fmt.Fprintf(opts.Release.Out(), "   ➡️  %s %s\n", req.Method, req.URL)
+	if req.Host != "" && req.Host != req.URL.Host {
+		fmt.Fprintf(opts.Release.Out(), "      Host: %s\n", req.Host)
+	}
+	printHTTPHeaders(opts.Release.Out(), req.Header)
+	fmt.Fprintf(opts.Release.Out(), "      (%d bytes)\n%s\n", len(payload), indentBody(payload))
+}
+
+// logHTTPResponse prints a response when verbose HTTP logging is on, with the body as far as it was read
+// It does nothing if the body was not captured (see captureBody), which it only is when logging is on.
+func logHTTPResponse(resp *http.Response, captured *bytes.Buffer, start time.Time, opts InferenceOptions) {
+	if captured == nil {
+		return
+	}
+	fmt.Fprintf(opts.Release.Out(), "   ⬅️  %s (%s)\n", resp.Status, time.Since(start).Round(time.Millisecond))
+	printHTTPHeaders(opts.Release.Out(), resp.Header)
+	fmt.Fprintf(opts.Release.Out(), "      (%d bytes)\n%s\n", captured.Len(), indentBody(captured.Bytes()))
+}
+
+// logHTTPError prints why an inference request got no response when verbose HTTP logging is on
+func logHTTPError(err error, start time.Time, opts InferenceOptions) {
+	if !opts.VerboseHTTP {
+		return
+	}
+	fmt.Fprintf(opts.Release.Out(), "   ⬅️  no response after %s: %v\n", time.Since(start).Round(time.Millisecond), err)
+}
+
+// printHTTPHeaders prints headers to w sorted by name, with credentials redacted
+func printHTTPHeaders(w io.Writer, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			if strings.EqualFold(name, "Cookie") || strings.EqualFold(name, "Set-Cookie") {
+				value = "<redacted>"
+			}
+			fmt.Fprintf(w, "      %s: %s\n", name, release.RedactValue(name, value))
+		}
+	}
+}
+
+// indentBody indents a logged body under its request or response line
+func indentBody(body []byte) string {
+	if len(body) == 0 {
+		return "      <empty>"
+	}
+	lines := strings.Split(strings.TrimRight(string(body), "\n"), "\n")
+	return "      " + strings.Join(lines, "\n      ")
+}
@@ -0,0 +1,90 @@
+package monitor
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// GPU attribution outcomes for ResourceUsage.GPUStatus
+const (
+	GPUAttributed = "attributed" // Core or one of its children holds GPU memory
+	GPUNoProcess  = "no_process" // There is a GPU, but none of its compute processes is Core's
+	GPUNone       = "no_gpu"     // nvidia-smi is missing or found no GPU
+)
+
+// ProcessGPUMemoryMB sums the GPU memory held by pid and its descendants, as nvidia-smi reports
+// per compute process. Unlike --query-gpu, this leaves out other processes sharing the GPU.
+// The status is GPUNone without an NVIDIA GPU and GPUNoProcess if Core holds no GPU memory
+// (including a Core in Docker, whose processes are not children of the docker client).
+func ProcessGPUMemoryMB(pid int) (float64, string, error) {
+	output, err := exec.Command("nvidia-smi", "--query-compute-apps=pid,used_memory", "--format=csv,noheader,nounits").Output()
+	if err != nil {
+		return 0, GPUNone, nil
+	}
+
+	pids, err := processTree(pid)
+	if err != nil {
+		return 0, GPUNoProcess, err
+	}
+
+	var total float64
+	found := false
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) < 2 {
+			continue
+		}
+		appPID, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+		if err != nil || !pids[appPID] {
+			continue
+		}
+		// "[N/A]" when the driver can't attribute memory (e.g. without permission); count the process anyway
+		usedMB, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+		if err == nil {
+			total += usedMB
+		}
+		found = true
+	}
+	if !found {
+		return 0, GPUNoProcess, nil
+	}
+	return total, GPUAttributed, nil
+}
+
+// processTree returns pid and every process descended from it
+func processTree(pid int) (map[int]bool, error) {
+	output, err := exec.Command("ps", "-e", "-o", "pid=,ppid=").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	children := make(map[int][]int)
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		child, err1 := strconv.Atoi(fields[0])
+		parent, err2 := strconv.Atoi(fields[1])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		children[parent] = append(children[parent], child)
+	}
+
+	tree := map[int]bool{pid: true}
+	queue := []int{pid}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, child := range children[next] {
+			if !tree[child] {
+				tree[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+	return tree, nil
+}
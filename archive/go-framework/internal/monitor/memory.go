@@ -0,0 +1,75 @@
+package monitor
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// vmStatPageSize pulls the page size out of vm_stat's header line
+var vmStatPageSize = regexp.MustCompile(`page size of (\d+) bytes`)
+
+// SystemMemory returns the machine's total and currently available memory in MB
+// available is -1 when the OS doesn't report it; callers should fall back to total.
+func SystemMemory() (totalMB, availableMB float64, err error) {
+	totalMB, err = getTotalMemory()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read total memory: %w", err)
+	}
+	availableMB, err = getAvailableMemory()
+	if err != nil {
+		return totalMB, -1, nil
+	}
+	return totalMB, availableMB, nil
+}
+
+func getAvailableMemory() (float64, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		// Free, inactive and speculative pages can all be handed to a new process
+		output, err := exec.Command("vm_stat").Output()
+		if err != nil {
+			return 0, err
+		}
+		match := vmStatPageSize.FindStringSubmatch(string(output))
+		if match == nil {
+			return 0, fmt.Errorf("failed to parse vm_stat page size")
+		}
+		pageSize, _ := strconv.ParseFloat(match[1], 64)
+		var pages float64
+		for _, line := range strings.Split(string(output), "\n") {
+			name, value, ok := strings.Cut(line, ":")
+			if !ok {
+				continue
+			}
+			switch name {
+			case "Pages free", "Pages inactive", "Pages speculative":
+				n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "."), 64)
+				if err != nil {
+					return 0, err
+				}
+				pages += n
+			}
+		}
+		return pages * pageSize / (1024 * 1024), nil
+	case "linux":
+		// free -m: Mem: total used free shared buff/cache available
+		output, err := exec.Command("free", "-m").Output()
+		if err != nil {
+			return 0, err
+		}
+		lines := strings.Split(string(output), "\n")
+		if len(lines) > 1 {
+			fields := strings.Fields(lines[1])
+			if len(fields) > 6 {
+				return strconv.ParseFloat(fields[6], 64)
+			}
+		}
+		return 0, fmt.Errorf("failed to parse free output")
+	default:
+		return 0, fmt.Errorf("unsupported OS")
+	}
+}
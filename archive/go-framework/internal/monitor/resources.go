@@ -2,10 +2,12 @@ package monitor
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -14,6 +16,31 @@ type Process struct {
 	PID    int
 	Cmd    *exec.Cmd
 	Binary string
+
+	// Files the process's output is written to (empty if not captured)
+	StdoutLog string
+	StderrLog string
+
+	mu    sync.Mutex
+	state *os.ProcessState // Set once Wait returns
+}
+
+// Wait waits for the process to exit, like Cmd.Wait, and records how it exited for Exited
+// Use it instead of Cmd.Wait whenever other goroutines may be checking the process.
+func (p *Process) Wait() error {
+	err := p.Cmd.Wait()
+	p.mu.Lock()
+	p.state = p.Cmd.ProcessState
+	p.mu.Unlock()
+	return err
+}
+
+// Exited reports whether Wait saw the process exit on its own (not killed by a signal)
+// It is safe to call while another goroutine is in Wait.
+func (p *Process) Exited() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state != nil && p.state.Exited()
 }
 
 // ResourceUsage contains resource usage metrics
@@ -21,6 +48,10 @@ type ResourceUsage struct {
 	CPUPercent    float64
 	MemoryMB      float64
 	MemoryPercent float64
+
+	// GPU memory held by the process and its children (see ProcessGPUMemoryMB)
+	GPUMemoryMB float64
+	GPUStatus   string // GPUAttributed, GPUNoProcess or GPUNone
 }
 
 // MonitorProcess monitors resource usage of a process
@@ -37,6 +68,11 @@ func MonitorProcess(process *Process, duration time.Duration) (*ResourceUsage, e
 	var totalMemory float64
 	sampleCount := 0
 
+	// GPU memory is averaged over the samples that found Core on the GPU
+	var totalGPU float64
+	gpuSamples := 0
+	gpuStatus := GPUNone
+
 	for i := 0; i < samples; i++ {
 		cpu, mem, err := getProcessStats(process.PID)
 		if err == nil {
@@ -44,6 +80,15 @@ func MonitorProcess(process *Process, duration time.Duration) (*ResourceUsage, e
 			totalMemory += mem
 			sampleCount++
 		}
+		if gpu, status, err := ProcessGPUMemoryMB(process.PID); err == nil {
+			if status == GPUAttributed {
+				totalGPU += gpu
+				gpuSamples++
+			}
+			if status != GPUNone && gpuStatus != GPUAttributed {
+				gpuStatus = status
+			}
+		}
 		time.Sleep(interval)
 	}
 
@@ -65,10 +110,17 @@ func MonitorProcess(process *Process, duration time.Duration) (*ResourceUsage, e
 		memPercent = (avgMemory / totalMem) * 100.0
 	}
 
+	avgGPU := 0.0
+	if gpuSamples > 0 {
+		avgGPU = totalGPU / float64(gpuSamples)
+	}
+
 	return &ResourceUsage{
 		CPUPercent:    avgCPU,
 		MemoryMB:      avgMemory,
 		MemoryPercent: memPercent,
+		GPUMemoryMB:   avgGPU,
+		GPUStatus:     gpuStatus,
 	}, nil
 }
 
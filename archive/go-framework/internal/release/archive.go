@@ -0,0 +1,243 @@
+package release
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// coreArchiveExtensions returns the Core release archive formats to try for an OS, most likely first
+// Releases ship as .tar.gz; Windows (and possibly future) releases as .zip.
+func coreArchiveExtensions(osName string) []string {
+	if osName == "windows" {
+		return []string{".zip", ".tar.gz"}
+	}
+	return []string{".tar.gz", ".zip"}
+}
+
+// zipMagic is the signature at the start of a zip file's first local header
+var zipMagic = []byte("PK\x03\x04")
+
+// extractArchive unpacks a .zip or .tar.gz archive into destDir
+// The format is sniffed from the file's first bytes, so a misnamed asset still extracts.
+func extractArchive(archivePath, destDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	header := make([]byte, len(zipMagic))
+	n, _ := io.ReadFull(f, header)
+	f.Close()
+
+	isZip := bytes.Equal(header[:n], zipMagic)
+	if n < len(zipMagic) {
+		isZip = strings.HasSuffix(archivePath, ".zip")
+	}
+	if isZip {
+		return extractZip(archivePath, destDir)
+	}
+
+	return extractTarGz(archivePath, destDir)
+}
+
+// extractTarGz unpacks a gzip-compressed tarball into destDir without needing a tar binary
+// File modes are kept (the Core binary must stay executable), as are symlinks and hard links
+// that stay inside destDir; see archiveTarget for what is refused.
+func extractTarGz(archivePath, destDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("%s is not gzip-compressed: %w", filepath.Base(archivePath), err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("corrupt archive %s: %w", filepath.Base(archivePath), err)
+		}
+
+		target, err := archiveTarget(destDir, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(tr, target, os.FileMode(hdr.Mode).Perm()); err != nil {
+				return fmt.Errorf("failed to extract %s: %w", hdr.Name, err)
+			}
+		case tar.TypeSymlink:
+			if err := writeArchiveSymlink(destDir, hdr.Linkname, target); err != nil {
+				return fmt.Errorf("failed to extract %s: %w", hdr.Name, err)
+			}
+		case tar.TypeLink:
+			source, err := archiveTarget(destDir, hdr.Linkname)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			_ = os.Remove(target) // Re-extracting over an earlier run
+			if err := os.Link(source, target); err != nil {
+				return fmt.Errorf("failed to extract %s: %w", hdr.Name, err)
+			}
+		default:
+			// Device nodes, FIFOs and pax metadata have no place in a release archive
+		}
+	}
+}
+
+// extractZip unpacks a zip archive into destDir, keeping file modes (the Core binary must stay executable)
+func extractZip(archivePath, destDir string) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		target, err := archiveTarget(destDir, f.Name)
+		if err != nil {
+			return err
+		}
+		mode := f.Mode()
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case mode&os.ModeSymlink != 0:
+			if err := extractZipSymlink(f, destDir, target); err != nil {
+				return err
+			}
+		default:
+			if err := extractZipFile(f, target, mode.Perm()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func extractZipFile(f *zip.File, target string, perm os.FileMode) error {
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if err := writeArchiveFile(src, target, perm); err != nil {
+		return fmt.Errorf("failed to extract %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+func extractZipSymlink(f *zip.File, destDir, target string) error {
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	link, err := io.ReadAll(src)
+	if err != nil {
+		return err
+	}
+	if err := writeArchiveSymlink(destDir, string(link), target); err != nil {
+		return fmt.Errorf("failed to extract %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// writeArchiveFile writes an extracted file with the archived permissions
+func writeArchiveFile(src io.Reader, target string, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	if perm == 0 {
+		perm = 0644 // Archives made on Windows carry no Unix permissions
+	}
+	_ = os.Remove(target) // Don't write through a symlink left by an earlier run
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Chmod(target, perm) // OpenFile's mode is filtered by the umask
+}
+
+// writeArchiveSymlink recreates an archived symlink, replacing whatever is at target
+// Links that are absolute or resolve outside destDir are refused: later entries could be
+// written through them, and Core would load files from wherever they point.
+func writeArchiveSymlink(destDir, link, target string) error {
+	if filepath.IsAbs(link) || strings.HasPrefix(link, "/") {
+		return fmt.Errorf("symlink to absolute path %q", link)
+	}
+	if !withinDir(destDir, filepath.Join(filepath.Dir(target), filepath.FromSlash(link))) {
+		return fmt.Errorf("symlink to %q escapes %s", link, destDir)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	_ = os.Remove(target) // Re-extracting over an earlier run
+	return os.Symlink(link, target)
+}
+
+// archiveTarget resolves an archive entry name under destDir, rejecting entries that escape it
+// Besides names like ../x, that includes names whose parent directory is a symlink already on disk,
+// since writing through it would land wherever it points.
+func archiveTarget(destDir, name string) (string, error) {
+	target := filepath.Join(destDir, filepath.FromSlash(name))
+	if !withinDir(destDir, target) {
+		return "", fmt.Errorf("archive entry %q escapes %s", name, destDir)
+	}
+
+	rel, err := filepath.Rel(filepath.Clean(destDir), target)
+	if err != nil || rel == "." {
+		return target, nil
+	}
+	parent := filepath.Clean(destDir)
+	parts := strings.Split(rel, string(os.PathSeparator))
+	for _, part := range parts[:len(parts)-1] {
+		parent = filepath.Join(parent, part)
+		info, err := os.Lstat(parent)
+		if err != nil {
+			break // Not created yet, so neither is anything below it
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("archive entry %q would be written through the symlink %s", name, parent)
+		}
+	}
+	return target, nil
+}
+
+// withinDir reports whether path (already cleaned, as filepath.Join leaves it) is dir or below it
+func withinDir(dir, path string) bool {
+	dir = filepath.Clean(dir)
+	return path == dir || strings.HasPrefix(path, dir+string(os.PathSeparator))
+}
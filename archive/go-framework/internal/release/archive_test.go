@@ -0,0 +1,325 @@
+package release
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// archiveEntry is one file, directory or link of a test archive
+type archiveEntry struct {
+	name string
+	body string
+	mode int64
+	kind byte   // tar.TypeReg (default), TypeDir, TypeSymlink or TypeLink
+	link string // Symlink or hard link target
+}
+
+// writeTarGz builds a .tar.gz fixture from entries
+func writeTarGz(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: e.mode, Typeflag: e.kind, Linkname: e.link, Size: int64(len(e.body))}
+		if hdr.Typeflag == 0 {
+			hdr.Typeflag = tar.TypeReg
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			hdr.Size = 0
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Size > 0 {
+			if _, err := tw.Write([]byte(e.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// releaseEntries is the layout of a Core release: one top-level directory, an executable and a versioned library
+var releaseEntries = []archiveEntry{
+	{name: "mlos-core-v1/", kind: tar.TypeDir, mode: 0755},
+	{name: "mlos-core-v1/build/mlos_core", body: "#!/bin/sh\n", mode: 0755},
+	{name: "mlos-core-v1/build/onnxruntime/lib/libonnxruntime.so.1.18.0", body: "elf", mode: 0644},
+	{name: "mlos-core-v1/build/onnxruntime/lib/libonnxruntime.so", kind: tar.TypeSymlink, link: "libonnxruntime.so.1.18.0"},
+	{name: "mlos-core-v1/docs/a/b/README.md", body: "docs", mode: 0644},
+}
+
+// checkReleaseLayout checks destDir holds releaseEntries with their modes and links
+func checkReleaseLayout(t *testing.T, destDir string, withSymlink bool) {
+	t.Helper()
+	root := filepath.Join(destDir, "mlos-core-v1")
+	info, err := os.Stat(filepath.Join(root, "build", "mlos_core"))
+	if err != nil {
+		t.Fatalf("binary not extracted: %v", err)
+	}
+	if info.Mode().Perm()&0111 == 0 {
+		t.Errorf("binary mode %v is not executable", info.Mode())
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "docs", "a", "b", "README.md")); err != nil || string(data) != "docs" {
+		t.Errorf("nested file = %q, %v", data, err)
+	}
+	if !withSymlink {
+		return
+	}
+	lib := filepath.Join(root, "build", "onnxruntime", "lib", "libonnxruntime.so")
+	if link, err := os.Readlink(lib); err != nil || link != "libonnxruntime.so.1.18.0" {
+		t.Errorf("library symlink = %q, %v", link, err)
+	}
+	if data, err := os.ReadFile(lib); err != nil || string(data) != "elf" {
+		t.Errorf("reading through the library symlink = %q, %v", data, err)
+	}
+}
+
+func TestExtractTarGzNestedDirsAndSymlinks(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "core.tar.gz")
+	writeTarGz(t, archive, releaseEntries)
+	destDir := t.TempDir()
+
+	if err := extractArchive(archive, destDir); err != nil {
+		t.Fatalf("extractArchive: %v", err)
+	}
+	checkReleaseLayout(t, destDir, true)
+
+	// Re-extracting over an earlier run replaces files and links
+	if err := extractArchive(archive, destDir); err != nil {
+		t.Fatalf("extractArchive again: %v", err)
+	}
+	checkReleaseLayout(t, destDir, true)
+}
+
+// outsidePlaceholder stands for an existing directory outside destDir in link targets (see withOutside)
+const outsidePlaceholder = "@outside"
+
+// withOutside returns entries with outsidePlaceholder link targets replaced by the absolute path outside
+func withOutside(entries []archiveEntry, outside string) []archiveEntry {
+	replaced := append([]archiveEntry(nil), entries...)
+	for i := range replaced {
+		if replaced[i].link == outsidePlaceholder {
+			replaced[i].link = outside
+		}
+	}
+	return replaced
+}
+
+func TestExtractTarGzRejectsEscapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []archiveEntry
+	}{
+		{"dot-dot name", []archiveEntry{{name: "../evil", body: "x"}}},
+		{"absolute symlink", []archiveEntry{{name: "link", kind: tar.TypeSymlink, link: "/tmp"}}},
+		{"dot-dot symlink", []archiveEntry{{name: "a/link", kind: tar.TypeSymlink, link: "../../outside"}}},
+		{"hard link outside", []archiveEntry{{name: "link", kind: tar.TypeLink, link: "../outside"}}},
+		{"write through escaping symlink", []archiveEntry{
+			{name: "link", kind: tar.TypeSymlink, link: outsidePlaceholder},
+			{name: "link/evil", body: "x"},
+		}},
+		{"write through in-tree symlink", []archiveEntry{
+			{name: "dir/", kind: tar.TypeDir, mode: 0755},
+			{name: "link", kind: tar.TypeSymlink, link: "dir"},
+			{name: "link/evil", body: "x"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			destDir := filepath.Join(root, "dest")
+			outside := filepath.Join(root, "outside")
+			if err := os.MkdirAll(outside, 0755); err != nil {
+				t.Fatal(err)
+			}
+			archive := filepath.Join(root, "core.tar.gz")
+			writeTarGz(t, archive, withOutside(tt.entries, outside))
+
+			if err := extractArchive(archive, destDir); err == nil {
+				t.Fatal("extractArchive accepted an entry escaping destDir")
+			}
+			for _, path := range []string{filepath.Join(root, "evil"), filepath.Join(outside, "evil")} {
+				if _, err := os.Lstat(path); err == nil {
+					t.Errorf("%s was written outside destDir", path)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractTarGzRejectsExistingSymlinkParent(t *testing.T) {
+	root := t.TempDir()
+	outside := filepath.Join(root, "outside")
+	destDir := filepath.Join(root, "dest")
+	if err := os.MkdirAll(outside, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Left by something other than this archive
+	if err := os.Symlink(outside, filepath.Join(destDir, "link")); err != nil {
+		t.Fatal(err)
+	}
+	archive := filepath.Join(root, "core.tar.gz")
+	writeTarGz(t, archive, []archiveEntry{{name: "link/evil", body: "x"}})
+
+	if err := extractArchive(archive, destDir); err == nil {
+		t.Fatal("extractArchive wrote through a symlink already in destDir")
+	}
+	if _, err := os.Lstat(filepath.Join(outside, "evil")); err == nil {
+		t.Error("a file was written through the symlink")
+	}
+}
+
+// writeZip builds a .zip fixture from entries (tar type flags pick directories and symlinks)
+func writeZip(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for _, e := range entries {
+		hdr := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		body := e.body
+		switch e.kind {
+		case tar.TypeDir:
+			hdr.SetMode(os.ModeDir | 0755)
+		case tar.TypeSymlink:
+			hdr.SetMode(os.ModeSymlink | 0777)
+			body = e.link // Zip stores a symlink's target as its contents
+		default:
+			hdr.SetMode(os.FileMode(e.mode))
+		}
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractZipNestedDirsAndSymlinks(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "core.zip")
+	writeZip(t, archive, releaseEntries)
+	destDir := t.TempDir()
+
+	if err := extractArchive(archive, destDir); err != nil {
+		t.Fatalf("extractArchive: %v", err)
+	}
+	checkReleaseLayout(t, destDir, true)
+}
+
+func TestExtractZipRejectsEscapingSymlinks(t *testing.T) {
+	for _, link := range []string{outsidePlaceholder, "../outside"} {
+		root := t.TempDir()
+		destDir := filepath.Join(root, "dest")
+		outside := filepath.Join(root, "outside")
+		if err := os.MkdirAll(outside, 0755); err != nil {
+			t.Fatal(err)
+		}
+		archive := filepath.Join(root, "core.zip")
+		writeZip(t, archive, withOutside([]archiveEntry{
+			{name: "link", kind: tar.TypeSymlink, link: link},
+			{name: "link/evil", body: "x", mode: 0644},
+		}, outside))
+
+		if err := extractArchive(archive, destDir); err == nil {
+			t.Errorf("extractArchive accepted a symlink to %s", link)
+		}
+		if _, err := os.Lstat(filepath.Join(outside, "evil")); err == nil {
+			t.Errorf("a file was written through the symlink to %s", link)
+		}
+	}
+}
+
+// TestDownloadCoreNormalizesNestedDir fetches each archive type from a local mirror and checks the
+// binary, found inside the archive's single top-level directory, ends up executable in build/
+func TestDownloadCoreNormalizesNestedDir(t *testing.T) {
+	entries := []archiveEntry{
+		{name: "mlos-core-v1/", kind: tar.TypeDir, mode: 0755},
+		{name: "mlos-core-v1/mlos_core", body: "#!/bin/sh\n", mode: 0755},
+		{name: "mlos-core-v1/lib/a/b.txt", body: "nested", mode: 0644},
+	}
+	for _, ext := range []string{".tar.gz", ".zip"} {
+		t.Run(ext, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir()) // DownloadCore installs into ~/.local/bin
+			t.Setenv("FORCE_CORE_PLATFORM", "")
+			const version = "v1.0.0"
+			mirror := t.TempDir()
+			assetDir := filepath.Join(mirror, "mlOS-foundation", "core-releases", "releases", "download", version)
+			if err := os.MkdirAll(assetDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			// Only this type is on the mirror, so DownloadCore has to fall back to it if it isn't its first choice
+			asset := filepath.Join(assetDir, fmt.Sprintf("mlos-core_%s_%s-%s%s", version, runtime.GOOS, runtime.GOARCH, ext))
+			if ext == ".zip" {
+				writeZip(t, asset, entries)
+			} else {
+				writeTarGz(t, asset, entries)
+			}
+
+			outputDir := t.TempDir()
+			if _, err := DownloadCore(version, outputDir, mirror, Options{}); err != nil {
+				t.Fatalf("DownloadCore: %v", err)
+			}
+			for _, path := range []string{
+				filepath.Join(outputDir, "mlos-core", "mlos-core-v1", "build", "mlos_core"),
+				filepath.Join(os.Getenv("HOME"), ".local", "bin", "mlos_core"),
+			} {
+				info, err := os.Stat(path)
+				if err != nil {
+					t.Errorf("binary missing: %v", err)
+					continue
+				}
+				if info.Mode().Perm()&0111 == 0 {
+					t.Errorf("%s mode %v is not executable", path, info.Mode())
+				}
+			}
+			if data, err := os.ReadFile(filepath.Join(outputDir, "mlos-core", "mlos-core-v1", "lib", "a", "b.txt")); err != nil || string(data) != "nested" {
+				t.Errorf("nested file = %q, %v", data, err)
+			}
+		})
+	}
+}
+
+// TestDownloadCoreReportsEveryArchiveType checks a mirror without the release names each archive
+// DownloadCore tried, not just the last one
+func TestDownloadCoreReportsEveryArchiveType(t *testing.T) {
+	t.Setenv("FORCE_CORE_PLATFORM", "")
+	_, err := DownloadCore("v1.0.0", t.TempDir(), t.TempDir(), Options{Output: io.Discard})
+	if err == nil {
+		t.Fatal("DownloadCore succeeded from an empty mirror")
+	}
+	for _, ext := range coreArchiveExtensions(runtime.GOOS) {
+		asset := fmt.Sprintf("mlos-core_v1.0.0_%s-%s%s", runtime.GOOS, runtime.GOARCH, ext)
+		if !strings.Contains(err.Error(), asset) {
+			t.Errorf("error %q does not mention %s", err, asset)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package release
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// AutoloadFailure is a model-load error Core logged while starting up
+type AutoloadFailure struct {
+	Model string // Model the line names, empty if it names none
+	Line  string
+}
+
+// autoloadErrorPattern matches the ways Core reports a model it could not load
+var autoloadErrorPattern = regexp.MustCompile(`(?i)(fail(ed|ure)?|error|unable|could not|cannot)\b.{0,40}\b(auto-?load|load)(ing|ed)?\b.{0,20}\bmodels?\b` +
+	`|\bmodels?\b.{0,40}\b(auto-?load|load)(ing)?\b.{0,20}\b(fail(ed|ure)?|error)` +
+	`|\b(auto-?load|load)(ing)?\b.{0,20}\b(fail(ed|ure)?|error)\b.{0,20}\bmodel`)
+
+// autoloadModelPatterns find the model a log line is about: a model_id field, a quoted name,
+// a bare repo/name ID (not a file path), or the word after "model"
+var autoloadModelPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)model(?:_id)?["']?\s*[=:]\s*["']?([^\s"',]+)`),
+	regexp.MustCompile(`["']([^"'\s]+)["']`),
+	regexp.MustCompile(`(?:^|[\s(\[])([A-Za-z0-9_-]+/[A-Za-z0-9_./-]+(?:@[A-Za-z0-9_.-]+)?)`),
+	regexp.MustCompile(`(?i)\bmodel\s+([A-Za-z0-9_.@/-]+)`),
+}
+
+// ScanAutoloadFailures scans what Core has logged so far for models it failed to load
+// Some Core configurations load models at startup; such failures leave Core healthy but the model missing.
+func ScanAutoloadFailures(stdoutLog, stderrLog string) []AutoloadFailure {
+	var failures []AutoloadFailure
+	for _, path := range []string{stdoutLog, stderrLog} {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if !autoloadErrorPattern.MatchString(line) {
+				continue
+			}
+			failures = append(failures, AutoloadFailure{Model: autoloadModel(line), Line: line})
+		}
+	}
+	return failures
+}
+
+// autoloadModel extracts the model a load-failure line names ("" if none is recognizable)
+func autoloadModel(line string) string {
+	for _, pattern := range autoloadModelPatterns {
+		if match := pattern.FindStringSubmatch(line); match != nil {
+			return strings.TrimRight(match[1], ".:;")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+package release
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// axonVersionPattern finds the version in `axon version` output, e.g. "axon version v3.1.9 (abc123)"
+var axonVersionPattern = regexp.MustCompile(`v?\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?`)
+
+// installedAxonVersion runs `axon version` and returns the version it reports
+func installedAxonVersion(axonBin string) (string, error) {
+	output, err := exec.Command(axonBin, "version").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to verify Axon installation: %w", err)
+	}
+	version := axonVersionPattern.FindString(string(output))
+	if version == "" {
+		return "", fmt.Errorf("could not find a version in `%s version` output: %q", axonBin, strings.TrimSpace(string(output)))
+	}
+	return version, nil
+}
+
+// axonVersionMatches reports whether installed is the requested version, ignoring a leading "v"
+// An empty or "latest" request matches anything, since the install script always installs the latest release.
+func axonVersionMatches(requested, installed string) bool {
+	if requested == "" || requested == "latest" {
+		return true
+	}
+	return strings.TrimPrefix(requested, "v") == strings.TrimPrefix(installed, "v")
+}
@@ -0,0 +1,73 @@
+package release
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writeFakeAxon writes a script to path that reports version like `axon version` does
+func writeFakeAxon(t *testing.T, path, version string) {
+	t.Helper()
+	script := "#!/bin/sh\necho \"axon version " + version + " (abc123)\"\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fakeInstaller stands in for installAxon: like the install script, it installs one fixed version
+func fakeInstaller(t *testing.T, version string, calls *int) func(string, Options) (Transfer, error) {
+	return func(axonBin string, _ Options) (Transfer, error) {
+		*calls++
+		writeFakeAxon(t, axonBin, version)
+		return Transfer{Bytes: 1}, nil
+	}
+}
+
+func TestEnsureAxonStrictVersions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake axon is a shell script")
+	}
+	opts := Options{StrictVersions: true, Output: io.Discard}
+
+	tests := []struct {
+		name      string
+		requested string
+		installed string // "" if no Axon is installed yet
+		latest    string // What the install script installs
+		wantErr   string
+		wantAxon  string // Version at axonBin afterwards
+		wantCalls int
+	}{
+		{"matching install kept", "v3.1.8", "v3.1.8", "v3.2.0", "", "v3.1.8", 0},
+		{"stale install replaced by latest", "v3.2.0", "v3.1.8", "v3.2.0", "", "v3.2.0", 1},
+		{"pinned older version keeps the working axon", "v3.1.8", "v3.1.9", "v3.2.0", "install script installed v3.2.0", "v3.1.9", 1},
+		{"fresh install of another version", "v3.1.8", "", "v3.2.0", "install script installed v3.2.0", "v3.2.0", 1},
+	}
+	for _, tt := range tests {
+		axonBin := filepath.Join(t.TempDir(), "axon")
+		if tt.installed != "" {
+			writeFakeAxon(t, axonBin, tt.installed)
+		}
+		calls := 0
+		_, err := ensureAxon(axonBin, tt.requested, fakeInstaller(t, tt.latest, &calls), opts)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+		if calls != tt.wantCalls {
+			t.Errorf("%s: installer ran %d times, want %d", tt.name, calls, tt.wantCalls)
+		}
+		if version, err := installedAxonVersion(axonBin); err != nil || version != tt.wantAxon {
+			t.Errorf("%s: axon afterwards = %q (%v), want %s", tt.name, version, err, tt.wantAxon)
+		}
+		if _, err := os.Stat(axonBin + ".stale"); !os.IsNotExist(err) {
+			t.Errorf("%s: the stale Axon was left beside the install", tt.name)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RemoveArtifacts deletes the Core and ONNX Runtime downloads from outputDir
+// Only paths created by DownloadCore, DownloadONNXRuntime and StartCore are touched;
+// reports, metrics and Core's logs stay in place. Returns the bytes freed.
+func RemoveArtifacts(outputDir string) (int64, error) {
+	var targets []string
+
+	coreDir := filepath.Join(outputDir, "mlos-core")
+	if entries, err := os.ReadDir(coreDir); err == nil {
+		for _, entry := range entries {
+			if entry.Name() == "logs" {
+				continue // Core's stdout/stderr logs, kept for debugging
+			}
+			targets = append(targets, filepath.Join(coreDir, entry.Name()))
+		}
+	}
+
+	if entries, err := os.ReadDir(outputDir); err == nil {
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), "onnxruntime") {
+				targets = append(targets, filepath.Join(outputDir, entry.Name()))
+			}
+		}
+	}
+
+	var freed int64
+	var failed []string
+	for _, path := range targets {
+		size := diskUsage(path)
+		if err := os.RemoveAll(path); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", path, err))
+			continue
+		}
+		freed += size
+	}
+	if len(failed) > 0 {
+		return freed, fmt.Errorf("failed to remove %d artifact(s): %s", len(failed), strings.Join(failed, "; "))
+	}
+	return freed, nil
+}
+
+// diskUsage returns the total size of the regular files under path
+func diskUsage(path string) int64 {
+	var total int64
+	filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
@@ -0,0 +1,75 @@
+package release
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// LogCommand prints cmd as a shell line that reproduces it, if opts.EchoCommands is set
+// Call it right before running cmd, after its Dir and Env are set.
+func LogCommand(cmd *exec.Cmd, opts Options) {
+	if !opts.EchoCommands {
+		return
+	}
+	fmt.Fprintf(opts.Out(), "🔧 $ %s\n", FormatCommand(cmd))
+}
+
+// FormatCommand renders cmd as a copy-pasteable shell line
+// Only environment variables that differ from this process's are shown, and
+// values that look like secrets (tokens, passwords, URL credentials) are redacted.
+func FormatCommand(cmd *exec.Cmd) string {
+	var parts []string
+	if cmd.Dir != "" {
+		parts = append(parts, "cd", shellQuote(cmd.Dir), "&&")
+	}
+
+	inherited := make(map[string]bool)
+	for _, kv := range os.Environ() {
+		inherited[kv] = true
+	}
+	for _, kv := range cmd.Env {
+		if inherited[kv] {
+			continue
+		}
+		key, value, _ := strings.Cut(kv, "=")
+		parts = append(parts, key+"="+shellQuote(RedactValue(key, value)))
+	}
+
+	parts = append(parts, shellQuote(cmd.Path))
+	for _, arg := range cmd.Args[1:] {
+		parts = append(parts, shellQuote(RedactValue("", arg)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// secretKeyPattern matches environment variable names whose values must not be printed
+var secretKeyPattern = regexp.MustCompile(`(?i)token|secret|passw|key|credential|auth`)
+
+// RedactValue hides the value of secret-looking variables and any credentials embedded in URLs
+func RedactValue(key, value string) string {
+	if key != "" && secretKeyPattern.MatchString(key) {
+		return "<redacted>"
+	}
+	if strings.Contains(value, "://") {
+		if u, err := url.Parse(value); err == nil && u.User != nil {
+			u.User = url.User("REDACTED")
+			return u.String()
+		}
+	}
+	return value
+}
+
+// shellSafe matches arguments that need no quoting in a POSIX shell
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote single-quotes s unless it is already shell-safe
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,14 @@
+package release
+
+import "path/filepath"
+
+// coreBinaryRoot returns the directory SetupONNXRuntime and Core's working directory use for a local binary
+// A Core checkout builds to <repo>/build/mlos_core, so its ONNX Runtime belongs in <repo>/build/onnxruntime
+// just as in a release; a binary anywhere else gets build/onnxruntime created beside it.
+func coreBinaryRoot(path string) string {
+	dir := filepath.Dir(path)
+	if filepath.Base(dir) == "build" {
+		return filepath.Dir(dir)
+	}
+	return dir
+}
@@ -0,0 +1,67 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// startupLogTailLines is how much of Core's output a startup-failure error carries
+const startupLogTailLines = 30
+
+// openCoreLogs creates <logName>-stdout.log and <logName>-stderr.log in the logs directory next to
+// extractDir, where the native path writes them too
+func openCoreLogs(extractDir, logName string) (stdout, stderr *os.File, err error) {
+	logDir := filepath.Join(filepath.Dir(extractDir), "logs")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	stdout, err = os.Create(filepath.Join(logDir, logName+"-stdout.log"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create stdout log: %w", err)
+	}
+	stderr, err = os.Create(filepath.Join(logDir, logName+"-stderr.log"))
+	if err != nil {
+		stdout.Close()
+		return nil, nil, fmt.Errorf("failed to create stderr log: %w", err)
+	}
+	return stdout, stderr, nil
+}
+
+// LogTail returns the last n non-blank lines of a log file (nil if it is missing or empty)
+func LogTail(path string, n int) []string {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimRight(line, "\r"))
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// logTailText formats the end of Core's stdout and stderr logs for an error message
+func logTailText(stdoutLog, stderrLog string) string {
+	var b strings.Builder
+	for _, l := range []struct{ name, path string }{{"stdout", stdoutLog}, {"stderr", stderrLog}} {
+		lines := LogTail(l.path, startupLogTailLines)
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n--- Core %s (last %d lines, %s) ---\n%s", l.name, len(lines), l.path, strings.Join(lines, "\n"))
+	}
+	if b.Len() == 0 {
+		return " (Core produced no output)"
+	}
+	return b.String()
+}
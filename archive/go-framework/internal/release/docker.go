@@ -0,0 +1,25 @@
+package release
+
+// DefaultCoreDockerImage is the image Core runs in when CORE_IN_DOCKER=true and no image is configured
+const DefaultCoreDockerImage = "ubuntu:22.04"
+
+// coreDockerImage returns the image startCoreInDocker runs Core in (opts.CoreDockerImage, or the default)
+func coreDockerImage(opts Options) string {
+	if opts.CoreDockerImage == "" {
+		return DefaultCoreDockerImage
+	}
+	return opts.CoreDockerImage
+}
+
+// dockerDepsScript installs curl and CA certificates only if the image lacks them
+// Images without apt-get are left as they are; Core itself only needs the bundled ONNX Runtime.
+const dockerDepsScript = `
+			if command -v curl > /dev/null 2>&1 && [ -s /etc/ssl/certs/ca-certificates.crt ]; then
+				echo "📦 curl and CA certificates already present, skipping install"
+			elif command -v apt-get > /dev/null 2>&1; then
+				echo "📦 Installing dependencies..."
+				apt-get update -qq && apt-get install -y -qq curl ca-certificates > /dev/null 2>&1
+			else
+				echo "⚠️  curl/CA certificates missing and no apt-get in the image; continuing without them"
+			fi
+`
@@ -2,13 +2,16 @@ package release
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -17,69 +20,121 @@ import (
 )
 
 // DownloadAxon downloads the specified Axon release version
-func DownloadAxon(version, outputDir string) error {
+// The returned Transfer is the installed CLI's size and install time (zero if Axon was already installed)
+// An installed Axon of another version is kept with a warning, or reinstalled under --strict-versions
+// (opts.StrictVersions), in which case a version the install script can't provide is an error and
+// the Axon that was installed before is put back.
+func DownloadAxon(version, outputDir string, opts Options) (Transfer, error) {
 	// Use Axon's install script which handles downloading
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return Transfer{}, fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	axonBin := filepath.Join(homeDir, ".local", "bin", "axon")
+	return ensureAxon(filepath.Join(homeDir, ".local", "bin", "axon"), version, installAxon, opts)
+}
 
+// ensureAxon makes sure axonBin is an Axon of the requested version, running install if it must (see DownloadAxon)
+// install is installAxon outside of tests.
+func ensureAxon(axonBin, version string, install func(string, Options) (Transfer, error), opts Options) (Transfer, error) {
 	// Check if Axon is already installed
+	var transfer Transfer
+	installed := false
 	if _, err := os.Stat(axonBin); os.IsNotExist(err) {
-		fmt.Printf("📥 Installing Axon CLI (~50MB)...\n")
-		
-		// Install Axon using the install script in background
-		cmd := exec.Command("bash", "-c", "curl -fsSL https://raw.githubusercontent.com/mlOS-foundation/axon/main/install.sh | bash > /tmp/axon-install.log 2>&1")
-		
-		// Start the command
-		if err := cmd.Start(); err != nil {
-			return fmt.Errorf("failed to start Axon install: %w", err)
-		}
-		
-		// Show progress while waiting
-		done := make(chan error)
-		go func() {
-			done <- cmd.Wait()
-		}()
-		
-		ticker := time.NewTicker(2 * time.Second)
-		defer ticker.Stop()
-		
-		for {
-			select {
-			case err := <-done:
-				if err != nil {
-					return fmt.Errorf("failed to install Axon: %w", err)
-				}
-				fmt.Printf("✅ Axon CLI installed\n")
-				return nil
-			case <-ticker.C:
-				fmt.Printf("   ... still installing ...\n")
-			}
+		if transfer, err = install(axonBin, opts); err != nil {
+			return Transfer{}, err
 		}
+		installed = true
 	}
 
 	// Verify installation
-	cmd := exec.Command(axonBin, "version")
-	output, err := cmd.Output()
+	installedVersion, err := installedAxonVersion(axonBin)
 	if err != nil {
-		return fmt.Errorf("failed to verify Axon installation: %w", err)
+		return Transfer{}, err
+	}
+	if axonVersionMatches(version, installedVersion) {
+		return transfer, nil
+	}
+	if !opts.StrictVersions {
+		fmt.Fprintf(opts.Out(), "WARN: Axon %s was requested but %s is installed at %s; the run uses %s (--strict-versions reinstalls or fails instead)\n", version, installedVersion, axonBin, installedVersion)
+		return transfer, nil
 	}
 
-	// Check if version matches (optional - install script installs latest)
-	_ = output // Version check can be added later if needed
+	if installed {
+		return Transfer{}, fmt.Errorf("Axon %s was requested but the install script installed %s (--strict-versions)", version, installedVersion)
+	}
 
-	return nil
+	// A stale install would otherwise shadow the requested version, so replace it once. The install
+	// script only provides the latest release, so keep the stale Axon aside to put back if it doesn't
+	// provide the requested one either.
+	fmt.Fprintf(opts.Out(), "⚠️  Axon %s at %s is not the requested %s; reinstalling\n", installedVersion, axonBin, version)
+	stale := axonBin + ".stale"
+	if err := os.Rename(axonBin, stale); err != nil {
+		return Transfer{}, fmt.Errorf("failed to move stale Axon %s aside: %w", installedVersion, err)
+	}
+	transfer, err = install(axonBin, opts)
+	if err == nil {
+		var reinstalled string
+		if reinstalled, err = installedAxonVersion(axonBin); err == nil && !axonVersionMatches(version, reinstalled) {
+			err = fmt.Errorf("Axon %s was requested but the install script installed %s (--strict-versions)", version, reinstalled)
+		}
+	}
+	if err != nil {
+		if restoreErr := os.Rename(stale, axonBin); restoreErr != nil {
+			return Transfer{}, fmt.Errorf("%w; failed to restore Axon %s from %s: %v", err, installedVersion, stale, restoreErr)
+		}
+		return Transfer{}, fmt.Errorf("%w (Axon %s at %s was left in place)", err, installedVersion, axonBin)
+	}
+	if err := os.Remove(stale); err != nil {
+		fmt.Fprintf(opts.Out(), "WARN: failed to remove stale Axon %s: %v\n", stale, err)
+	}
+	return transfer, nil
+}
+
+// installAxon runs Axon's install script, which installs the latest release to axonBin
+func installAxon(axonBin string, opts Options) (Transfer, error) {
+	fmt.Fprintf(opts.Out(), "📥 Installing Axon CLI (~50MB)...\n")
+	
+	// Install Axon using the install script in background
+	start := time.Now()
+	cmd := exec.Command("bash", "-c", "curl -fsSL https://raw.githubusercontent.com/mlOS-foundation/axon/main/install.sh | bash > /tmp/axon-install.log 2>&1")
+	
+	// Start the command
+	if err := cmd.Start(); err != nil {
+		return Transfer{}, fmt.Errorf("failed to start Axon install: %w", err)
+	}
+	
+	// Show progress while waiting
+	done := make(chan error)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				return Transfer{}, fmt.Errorf("failed to install Axon: %w", err)
+			}
+			fmt.Fprintf(opts.Out(), "✅ Axon CLI installed\n")
+			return TransferOf(axonBin, start), nil
+		case <-ticker.C:
+			fmt.Fprintf(opts.Out(), "   ... still installing ...\n")
+		}
+	}
 }
 
 // DownloadCore downloads the specified MLOS Core release version
-func DownloadCore(version, outputDir string) error {
+// mirror, if set, is used instead of GitHub (see FetchAsset)
+// The returned Transfer covers fetching the release archive
+func DownloadCore(version, outputDir, mirror string, opts Options) (Transfer, error) {
 	coreDir := filepath.Join(outputDir, "mlos-core")
 
 	if err := os.MkdirAll(coreDir, 0755); err != nil {
-		return fmt.Errorf("failed to create core directory: %w", err)
+		return Transfer{}, fmt.Errorf("failed to create core directory: %w", err)
 	}
 
 	// Determine platform-specific pattern
@@ -93,60 +148,50 @@ func DownloadCore(version, outputDir string) error {
 		if len(parts) == 2 {
 			osName = parts[0]
 			archName = parts[1]
-			fmt.Printf("🐧 Forcing platform: %s/%s (for Docker testing)\n", osName, archName)
+			fmt.Fprintf(opts.Out(), "🐧 Forcing platform: %s/%s (for Docker testing)\n", osName, archName)
 		}
 	}
 	
-	// Construct platform-specific pattern: mlos-core_VERSION_OS-ARCH.tar.gz
-	pattern := fmt.Sprintf("mlos-core_%s_%s-%s.tar.gz", version, osName, archName)
-	archivePath := ""
+	fmt.Fprintf(opts.Out(), "📥 Downloading MLOS Core for %s/%s...\n", osName, archName)
 
-	fmt.Printf("📥 Downloading MLOS Core for %s/%s...\n", osName, archName)
-
-	// Use gh CLI with platform-specific pattern
-	// Download from public core-releases repo (GITHUB_TOKEN can access public repos)
-	cmd := exec.Command("gh", "release", "download", version,
-		"--repo", "mlOS-foundation/core-releases",
-		"--pattern", pattern,
-		"--dir", coreDir)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
-		// If gh fails (e.g., not authenticated), try curl for public repo
-		fmt.Printf("gh download failed, trying curl for public release...\n")
-		
-		// Construct download URL for public repo
-		downloadURL := fmt.Sprintf("https://github.com/mlOS-foundation/core-releases/releases/download/%s/%s", 
-			version, pattern)
-		archivePathFull := filepath.Join(coreDir, pattern)
-		
-		curlCmd := exec.Command("curl", "-L", "-o", archivePathFull, downloadURL)
-		curlCmd.Stdout = os.Stdout
-		curlCmd.Stderr = os.Stderr
-		
-		if curlErr := curlCmd.Run(); curlErr != nil {
-			return fmt.Errorf("failed to download Core release for %s/%s (gh: %w, curl: %w)", osName, archName, err, curlErr)
+	// Construct platform-specific pattern: mlos-core_VERSION_OS-ARCH.tar.gz (or .zip)
+	// Every format that fails is reported, so the likely .tar.gz error isn't hidden behind the .zip one
+	var pattern string
+	var attempts []error
+	archivePath := ""
+	start := time.Now()
+	for _, ext := range coreArchiveExtensions(osName) {
+		pattern = fmt.Sprintf("mlos-core_%s_%s-%s%s", version, osName, archName, ext)
+		var err error
+		if mirror != "" {
+			err = FetchAsset(mirror, "mlOS-foundation/core-releases", version, pattern, filepath.Join(coreDir, pattern), opts)
+		} else {
+			err = downloadCoreFromGitHub(version, pattern, coreDir, osName, archName, opts)
 		}
-		
-		// Verify download succeeded
-		if _, statErr := os.Stat(archivePathFull); statErr != nil {
-			return fmt.Errorf("Core archive not found after curl download: %s", archivePathFull)
+		if err == nil {
+			attempts = nil
+			break
 		}
-		
-		fmt.Printf("✅ Downloaded via curl\n")
+		attempts = append(attempts, fmt.Errorf("%s: %w", pattern, err))
+	}
+	if len(attempts) > 0 {
+		source := "GitHub"
+		if mirror != "" {
+			source = "mirror " + mirror
+		}
+		return Transfer{}, fmt.Errorf("failed to fetch Core release for %s/%s from %s:\n%w", osName, archName, source, errors.Join(attempts...))
 	}
 
 	// Find the downloaded file - should match the exact pattern
 	archivePath = filepath.Join(coreDir, pattern)
+	transfer := TransferOf(archivePath, start)
 	if _, err := os.Stat(archivePath); os.IsNotExist(err) {
-		return fmt.Errorf("Core binary archive not found after download: %s", archivePath)
+		return Transfer{}, fmt.Errorf("Core binary archive not found after download: %s", archivePath)
 	}
 
 	// Extract archive (extract to coreDir, then handle nested structure)
-	extractCmd := exec.Command("tar", "-xzf", archivePath, "-C", coreDir)
-	if err := extractCmd.Run(); err != nil {
-		return fmt.Errorf("failed to extract Core archive: %w", err)
+	if err := extractArchive(archivePath, coreDir); err != nil {
+		return Transfer{}, fmt.Errorf("failed to extract Core archive: %w", err)
 	}
 
 	// Handle nested directory structure (archive may extract to a subdirectory)
@@ -186,7 +231,7 @@ func DownloadCore(version, outputDir string) error {
 	for _, path := range commonPaths {
 		if _, err := os.Stat(path); err == nil {
 			binaryPath = path
-			fmt.Printf("✅ Found Core binary at: %s\n", path)
+			fmt.Fprintf(opts.Out(), "✅ Found Core binary at: %s\n", path)
 			break
 		}
 	}
@@ -199,19 +244,19 @@ func DownloadCore(version, outputDir string) error {
 			lines := strings.Split(strings.TrimSpace(string(output)), "\n")
 			if len(lines) > 0 && lines[0] != "" {
 				binaryPath = lines[0]
-				fmt.Printf("✅ Found Core binary at: %s\n", binaryPath)
+				fmt.Fprintf(opts.Out(), "✅ Found Core binary at: %s\n", binaryPath)
 			}
 		}
 	}
 
 	if binaryPath == "" {
-		return fmt.Errorf("Core binary (mlos_core or mlos-server) not found in release archive (searched in %s)", extractDir)
+		return Transfer{}, fmt.Errorf("Core binary (mlos_core or mlos-server) not found in release archive (searched in %s)", extractDir)
 	}
 
 	// Copy to build directory (preserve original name - mlos_core)
 	buildDir := filepath.Join(extractDir, "build")
 	if err := os.MkdirAll(buildDir, 0755); err != nil {
-		return fmt.Errorf("failed to create build directory: %w", err)
+		return Transfer{}, fmt.Errorf("failed to create build directory: %w", err)
 	}
 
 	// Preserve original binary name (mlos_core, not mlos-server)
@@ -222,68 +267,119 @@ func DownloadCore(version, outputDir string) error {
 	if binaryPath != finalBinaryPath {
 		data, err := os.ReadFile(binaryPath)
 		if err != nil {
-			return fmt.Errorf("failed to read Core binary: %w", err)
+			return Transfer{}, fmt.Errorf("failed to read Core binary: %w", err)
 		}
 		if err := os.WriteFile(finalBinaryPath, data, 0755); err != nil {
-			return fmt.Errorf("failed to write Core binary: %w", err)
+			return Transfer{}, fmt.Errorf("failed to write Core binary: %w", err)
 		}
 	}
 
 	// Install to ~/.local/bin
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return Transfer{}, fmt.Errorf("failed to get home directory: %w", err)
 	}
 
 	localBin := filepath.Join(homeDir, ".local", "bin")
 	if err := os.MkdirAll(localBin, 0755); err != nil {
-		return fmt.Errorf("failed to create local bin directory: %w", err)
+		return Transfer{}, fmt.Errorf("failed to create local bin directory: %w", err)
 	}
 
 	// Use the same binary name (mlos_core) - binaryName already set above
 	installPath := filepath.Join(localBin, binaryName)
 	data2, err := os.ReadFile(finalBinaryPath)
 	if err != nil {
-		return fmt.Errorf("failed to read Core binary for installation: %w", err)
+		return Transfer{}, fmt.Errorf("failed to read Core binary for installation: %w", err)
 	}
 	if err := os.WriteFile(installPath, data2, 0755); err != nil {
-		return fmt.Errorf("failed to install Core binary: %w", err)
+		return Transfer{}, fmt.Errorf("failed to install Core binary: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// downloadCoreFromGitHub fetches the Core archive with gh, falling back to curl for the public repo
+func downloadCoreFromGitHub(version, pattern, coreDir, osName, archName string, opts Options) error {
+	// Use gh CLI with platform-specific pattern
+	// Download from public core-releases repo (GITHUB_TOKEN can access public repos)
+	err := ghWithRateLimit(pattern, opts, "release", "download", version,
+		"--repo", "mlOS-foundation/core-releases",
+		"--pattern", pattern,
+		"--dir", coreDir)
+
+	if err != nil {
+		// If gh fails (e.g., not authenticated), try curl for public repo
+		fmt.Fprintf(opts.Out(), "gh download failed, trying curl for public release...\n")
+		
+		// Construct download URL for public repo
+		downloadURL := fmt.Sprintf("https://github.com/mlOS-foundation/core-releases/releases/download/%s/%s", 
+			version, pattern)
+		archivePathFull := filepath.Join(coreDir, pattern)
+		
+		if curlErr := curlWithRateLimit(pattern, opts, "-L", "-f", "-o", archivePathFull, downloadURL); curlErr != nil {
+			return fmt.Errorf("failed to download Core release for %s/%s (gh: %w, curl: %w)", osName, archName, err, curlErr)
+		}
+		
+		// Verify download succeeded
+		if _, statErr := os.Stat(archivePathFull); statErr != nil {
+			return fmt.Errorf("Core archive not found after curl download: %s", archivePathFull)
+		}
+		
+		fmt.Fprintf(opts.Out(), "✅ Downloaded via curl\n")
 	}
 
 	return nil
 }
 
 // SetupONNXRuntime downloads and sets up ONNX Runtime if needed
-func SetupONNXRuntime(extractDir string) error {
+// If stagingDir already holds a copy fetched by DownloadONNXRuntime, it is moved into place
+// mirror, if set, is used instead of GitHub (see FetchAsset)
+func SetupONNXRuntime(extractDir, stagingDir, mirror string, opts Options) error {
 	buildDir := filepath.Join(extractDir, "build")
-	
-	// Determine target OS (allow override for Docker testing)
-	targetOS := runtime.GOOS
-	targetArch := runtime.GOARCH
-	if forcePlatform := os.Getenv("FORCE_CORE_PLATFORM"); forcePlatform != "" {
-		parts := strings.Split(forcePlatform, "/")
-		if len(parts) == 2 {
-			targetOS = parts[0]
-			targetArch = parts[1]
-			fmt.Printf("🐧 Using forced platform: %s/%s (for Docker testing)\n", targetOS, targetArch)
-		}
-	} else {
-		fmt.Printf("📦 Detected platform: %s/%s (native execution)\n", targetOS, targetArch)
-	}
+	targetOS, targetArch := onnxTargetPlatform(opts)
 
 	// Check if ONNX Runtime is already installed
-	libName := "libonnxruntime.1.18.0.dylib"
-	if targetOS == "linux" {
-		libName = "libonnxruntime.1.18.0.so"
-	}
+	libName := onnxRuntimeLibName(targetOS)
 	onnxLibPath := filepath.Join(buildDir, "onnxruntime", "lib", libName)
 
 	if _, err := os.Stat(onnxLibPath); err == nil {
-		fmt.Printf("✅ ONNX Runtime already installed: %s\n", libName)
+		fmt.Fprintf(opts.Out(), "✅ ONNX Runtime already installed: %s\n", libName)
 		return nil // Already installed
 	}
-	
-	fmt.Printf("📥 ONNX Runtime not found, downloading for %s/%s...\n", targetOS, targetArch)
+
+	// Use the copy downloaded alongside Axon and Core, if any
+	if stagingDir != "" {
+		stagedDir := filepath.Join(stagingDir, "onnxruntime")
+		if _, err := os.Stat(filepath.Join(stagedDir, "lib", libName)); err == nil {
+			if err := os.MkdirAll(buildDir, 0755); err != nil {
+				return fmt.Errorf("failed to create build directory: %w", err)
+			}
+			if err := os.Rename(stagedDir, filepath.Join(buildDir, "onnxruntime")); err != nil {
+				return fmt.Errorf("failed to move ONNX Runtime into place: %w", err)
+			}
+			fmt.Fprintf(opts.Out(), "✅ ONNX Runtime installed from %s\n", stagedDir)
+			return nil
+		}
+	}
+
+	_, err := downloadONNXRuntime(buildDir, targetOS, targetArch, mirror, opts)
+	return err
+}
+
+// DownloadONNXRuntime downloads and extracts ONNX Runtime into destDir/onnxruntime
+// The returned Transfer covers fetching the archive (zero if it was already present)
+func DownloadONNXRuntime(destDir, mirror string, opts Options) (Transfer, error) {
+	targetOS, targetArch := onnxTargetPlatform(opts)
+	return downloadONNXRuntime(destDir, targetOS, targetArch, mirror, opts)
+}
+
+func downloadONNXRuntime(destDir, targetOS, targetArch, mirror string, opts Options) (Transfer, error) {
+	onnxLibPath := filepath.Join(destDir, "onnxruntime", "lib", onnxRuntimeLibName(targetOS))
+	if _, err := os.Stat(onnxLibPath); err == nil {
+		return Transfer{}, nil // Already downloaded
+	}
+
+	fmt.Fprintf(opts.Out(), "📥 ONNX Runtime not found, downloading for %s/%s...\n", targetOS, targetArch)
 
 	// Determine architecture for ONNX Runtime
 	var onnxArch string
@@ -293,37 +389,40 @@ func SetupONNXRuntime(extractDir string) error {
 	case "arm64":
 		onnxArch = "arm64"
 	default:
-		return fmt.Errorf("unsupported architecture for ONNX Runtime: %s", targetArch)
+		return Transfer{}, fmt.Errorf("unsupported architecture for ONNX Runtime: %s", targetArch)
 	}
 
 	// Download ONNX Runtime
-	var onnxURL string
+	var onnxAsset string
 	if targetOS == "darwin" {
-		onnxURL = fmt.Sprintf("https://github.com/microsoft/onnxruntime/releases/download/v1.18.0/onnxruntime-osx-%s-1.18.0.tgz", onnxArch)
+		onnxAsset = fmt.Sprintf("onnxruntime-osx-%s-1.18.0.tgz", onnxArch)
 	} else if targetOS == "linux" {
-		onnxURL = fmt.Sprintf("https://github.com/microsoft/onnxruntime/releases/download/v1.18.0/onnxruntime-linux-%s-1.18.0.tgz", onnxArch)
+		onnxAsset = fmt.Sprintf("onnxruntime-linux-%s-1.18.0.tgz", onnxArch)
 	} else {
-		return fmt.Errorf("unsupported OS for ONNX Runtime: %s", targetOS)
+		return Transfer{}, fmt.Errorf("unsupported OS for ONNX Runtime: %s", targetOS)
 	}
 
-	fmt.Printf("📥 Downloading ONNX Runtime (~8MB)...\n")
+	fmt.Fprintf(opts.Out(), "📥 Downloading ONNX Runtime (~8MB)...\n")
 
-	// Download with progress indicator
-	onnxArchive := filepath.Join(buildDir, "onnxruntime.tgz")
-	cmd := exec.Command("curl", "-L", "-f", "-#", "-o", onnxArchive, onnxURL)
-	cmd.Stderr = os.Stderr // Show curl's progress bar
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to download ONNX Runtime: %w", err)
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return Transfer{}, fmt.Errorf("failed to create ONNX Runtime directory: %w", err)
 	}
 
-	// Extract
-	if err := os.MkdirAll(buildDir, 0755); err != nil {
-		return fmt.Errorf("failed to create build directory: %w", err)
+	// Download with progress indicator
+	tmpDir, err := RunTempDir()
+	if err != nil {
+		return Transfer{}, err
+	}
+	onnxArchive := filepath.Join(tmpDir, "onnxruntime.tgz")
+	start := time.Now()
+	if err := FetchAsset(mirror, "microsoft/onnxruntime", "v1.18.0", onnxAsset, onnxArchive, opts); err != nil {
+		return Transfer{}, fmt.Errorf("failed to download ONNX Runtime: %w", err)
 	}
+	transfer := TransferOf(onnxArchive, start)
 
-	cmd = exec.Command("tar", "-xzf", onnxArchive, "-C", buildDir)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to extract ONNX Runtime: %w", err)
+	// Extract
+	if err := extractTarGz(onnxArchive, destDir); err != nil {
+		return Transfer{}, fmt.Errorf("failed to extract ONNX Runtime: %w", err)
 	}
 
 	// Rename to expected directory structure
@@ -334,29 +433,54 @@ func SetupONNXRuntime(extractDir string) error {
 	} else {
 		extractedDirName = fmt.Sprintf("onnxruntime-linux-%s-1.18.0", onnxArch)
 	}
-	extractedDir := filepath.Join(buildDir, extractedDirName)
-	expectedDir := filepath.Join(buildDir, "onnxruntime")
+	extractedDir := filepath.Join(destDir, extractedDirName)
+	expectedDir := filepath.Join(destDir, "onnxruntime")
 
 	if _, err := os.Stat(extractedDir); err == nil {
 		if err := os.Rename(extractedDir, expectedDir); err != nil {
-			return fmt.Errorf("failed to rename ONNX Runtime directory: %w", err)
+			return Transfer{}, fmt.Errorf("failed to rename ONNX Runtime directory: %w", err)
 		}
 	} else {
 		// Directory might already be named correctly, or extraction failed
-		return fmt.Errorf("ONNX Runtime extraction directory not found: %s", extractedDir)
+		return Transfer{}, fmt.Errorf("ONNX Runtime extraction directory not found: %s", extractedDir)
 	}
 
 	// Clean up archive
 	_ = os.Remove(onnxArchive) // Ignore cleanup errors
 
-	fmt.Printf("✅ ONNX Runtime installed\n")
-	return nil
+	fmt.Fprintf(opts.Out(), "✅ ONNX Runtime installed\n")
+	return transfer, nil
+}
+
+// onnxTargetPlatform returns the OS/arch ONNX Runtime is needed for (allows override for Docker testing)
+func onnxTargetPlatform(opts Options) (string, string) {
+	targetOS := runtime.GOOS
+	targetArch := runtime.GOARCH
+	if forcePlatform := os.Getenv("FORCE_CORE_PLATFORM"); forcePlatform != "" {
+		parts := strings.Split(forcePlatform, "/")
+		if len(parts) == 2 {
+			targetOS = parts[0]
+			targetArch = parts[1]
+			fmt.Fprintf(opts.Out(), "🐧 Using forced platform: %s/%s (for Docker testing)\n", targetOS, targetArch)
+		}
+	} else {
+		fmt.Fprintf(opts.Out(), "📦 Detected platform: %s/%s (native execution)\n", targetOS, targetArch)
+	}
+	return targetOS, targetArch
+}
+
+// onnxRuntimeLibName returns the ONNX Runtime shared library name for the target OS
+func onnxRuntimeLibName(targetOS string) string {
+	if targetOS == "linux" {
+		return "libonnxruntime.1.18.0.so"
+	}
+	return "libonnxruntime.1.18.0.dylib"
 }
 
 // StartCore starts the MLOS Core server on a non-privileged port
 // startCoreInDocker runs Core server in a Linux Docker container
 // This is used to test Linux Core behavior on Mac
-func startCoreInDocker(extractDir string, port int) (*monitor.Process, error) {
+func startCoreInDocker(extractDir, host string, port int, logName string, opts Options) (*monitor.Process, error) {
 	// Find the Core binary
 	binaryPath := ""
 	altPaths := []string{
@@ -376,6 +500,9 @@ func startCoreInDocker(extractDir string, port int) (*monitor.Process, error) {
 	if binaryPath == "" {
 		return nil, fmt.Errorf("Core binary not found in %s", extractDir)
 	}
+	if err := CheckONNXRuntimeVersion(binaryPath, filepath.Join(extractDir, "build", "onnxruntime", "lib"), opts); err != nil {
+		return nil, err
+	}
 	
 	// Get absolute paths for Docker volume mounting
 	absExtractDir, err := filepath.Abs(extractDir)
@@ -383,7 +510,7 @@ func startCoreInDocker(extractDir string, port int) (*monitor.Process, error) {
 		return nil, fmt.Errorf("failed to get absolute path: %w", err)
 	}
 	
-	// Run Core in Ubuntu container with port mapping
+	// Run Core in a Linux container (Ubuntu unless --core-docker-image says otherwise) with port mapping
 	// Mount the entire extract directory so ONNX Runtime is accessible
 	// Note: On Mac, --network host doesn't work (Docker runs in VM), so use -p instead
 	cmd := exec.Command("docker", "run", "--rm",
@@ -391,13 +518,11 @@ func startCoreInDocker(extractDir string, port int) (*monitor.Process, error) {
 		"-p", fmt.Sprintf("%d:%d", port, port),
 		"-v", fmt.Sprintf("%s:/core", absExtractDir),
 		"-w", "/core",
-		"ubuntu:22.04",
+		coreDockerImage(opts),
 		"/bin/bash", "-c",
 		fmt.Sprintf(`
-			# Install minimal dependencies
-			echo "📦 Installing dependencies..."
-			apt-get update -qq && apt-get install -y -qq curl ca-certificates > /dev/null 2>&1
-			
+			# Install minimal dependencies unless the image already has them
+			`+dockerDepsScript+`
 			echo "🔍 Core binary: %s"
 			ls -lh %s
 			
@@ -414,19 +539,27 @@ func startCoreInDocker(extractDir string, port int) (*monitor.Process, error) {
 			%s --http-port %d 2>&1
 		`, filepath.Base(binaryPath), filepath.Base(binaryPath), filepath.Base(binaryPath), filepath.Base(binaryPath), port, filepath.Base(binaryPath), port))
 	
-	// Show output in real-time for debugging
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	// Show output in real-time for debugging, and keep it in the same log files as the native path
+	stdoutFile, stderrFile, err := openCoreLogs(extractDir, logName)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stdout = io.MultiWriter(opts.Out(), stdoutFile)
+	cmd.Stderr = io.MultiWriter(os.Stderr, stderrFile)
 	
 	// Start container
 	if err := cmd.Start(); err != nil {
+		stdoutFile.Close()
+		stderrFile.Close()
 		return nil, fmt.Errorf("failed to start Core Docker container: %w", err)
 	}
 	
 	process := &monitor.Process{
-		PID:    cmd.Process.Pid,
-		Cmd:    cmd,
-		Binary: binaryPath,
+		PID:       cmd.Process.Pid,
+		Cmd:       cmd,
+		Binary:    binaryPath,
+		StdoutLog: stdoutFile.Name(),
+		StderrLog: stderrFile.Name(),
 	}
 	
 	// Give server a moment to start inside Docker
@@ -434,20 +567,56 @@ func startCoreInDocker(extractDir string, port int) (*monitor.Process, error) {
 	time.Sleep(5 * time.Second)
 	
 	// Wait for server to be ready (Docker startup takes longer)
-	fmt.Printf("⏳ Waiting for Core server to be ready (this may take ~30s for Docker setup)...\n")
-	if err := waitForServer(port); err != nil {
-		fmt.Printf("\n❌ Server failed to become ready\n")
+	fmt.Fprintf(opts.Out(), "⏳ Waiting for Core server to be ready (this may take ~30s for Docker setup)...\n")
+	if err := waitForServer(host, port, opts); err != nil {
+		fmt.Fprintf(opts.Out(), "\n❌ Server failed to become ready\n")
 		if stopErr := monitor.StopProcess(process); stopErr != nil {
-			fmt.Printf("WARN: Failed to stop Docker container: %v\n", stopErr)
+			fmt.Fprintf(opts.Out(), "WARN: Failed to stop Docker container: %v\n", stopErr)
 		}
-		return nil, fmt.Errorf("Core server in Docker failed to start: %w", err)
+		return nil, fmt.Errorf("Core server in Docker failed to start: %w%s", err, logTailText(process.StdoutLog, process.StderrLog))
 	}
 	
-	fmt.Printf("✅ Core running in Linux Docker container on port %d\n", port)
+	fmt.Fprintf(opts.Out(), "✅ Core running in Linux Docker container on port %d\n", port)
 	return process, nil
 }
 
-func StartCore(version, outputDir string, port int) (*monitor.Process, error) {
+// host is only used to reach the server; Core itself is told just the port
+// mirror is where ONNX Runtime is fetched from if it wasn't staged beforehand (empty: GitHub)
+func StartCore(version, outputDir, host string, port int, mirror string, opts Options) (*monitor.Process, error) {
+	return StartCoreInstance(version, outputDir, host, port, mirror, "core", opts)
+}
+
+// StartCoreInstance is StartCore with the log file prefix made explicit
+// Additional instances sharing one Core install need distinct prefixes so their
+// <logName>-stdout.log / <logName>-stderr.log don't overwrite each other.
+func StartCoreInstance(version, outputDir, host string, port int, mirror, logName string, opts Options) (*monitor.Process, error) {
+	// An unrelated server on the port would pass waitForServer and be tested in Core's place
+	if err := CheckPortFree(host, port); err != nil {
+		return nil, err
+	}
+	return startCoreInstance(version, outputDir, host, port, mirror, logName, nil, opts)
+}
+
+// StartCoreOnReservation is StartCoreInstance on a port reserved with a PortAllocator
+// The port stays bound through ONNX Runtime setup and is released just before Core is started
+// on it, or when starting fails, so concurrent instances can't take it in the meantime.
+func StartCoreOnReservation(version, outputDir, host string, reservation *PortReservation, mirror, logName string, opts Options) (*monitor.Process, error) {
+	defer reservation.Release() // No-op once handed off
+	return startCoreInstance(version, outputDir, host, reservation.Port, mirror, logName, reservation.Release, opts)
+}
+
+// startCoreInstance starts Core on port; handoff (if not nil) is called right before Core is exec'd
+func startCoreInstance(version, outputDir, host string, port int, mirror, logName string, handoff func() error, opts Options) (*monitor.Process, error) {
+	handOver := func() error {
+		if handoff == nil {
+			return nil
+		}
+		if err := handoff(); err != nil {
+			return fmt.Errorf("failed to release port %d for Core: %w", port, err)
+		}
+		return nil
+	}
+
 	coreDir := filepath.Join(outputDir, "mlos-core")
 
 	// Handle nested directory structure (same logic as DownloadCore)
@@ -469,16 +638,27 @@ func StartCore(version, outputDir string, port int) (*monitor.Process, error) {
 		}
 	}
 
-	// Setup ONNX Runtime if needed
-	if err := SetupONNXRuntime(extractDir); err != nil {
+	// --core-binary replaces the downloaded release
+	if opts.CoreBinary != "" {
+		extractDir = coreBinaryRoot(opts.CoreBinary)
+	}
+
+	// Setup ONNX Runtime if needed (may already be staged in outputDir by DownloadONNXRuntime)
+	if err := SetupONNXRuntime(extractDir, outputDir, mirror, opts); err != nil {
 		return nil, fmt.Errorf("failed to setup ONNX Runtime: %w", err)
 	}
 	
 	// Check if we should run Core in Docker (for testing Linux Core on Mac)
 	// In CI, this will be false, so Core runs directly on the Linux runner
 	if os.Getenv("CORE_IN_DOCKER") == "true" {
-		fmt.Printf("🐳 Running Core in Linux Docker container (local testing mode)\n")
-		return startCoreInDocker(extractDir, port)
+		if opts.CoreBinary != "" {
+			return nil, fmt.Errorf("--core-binary cannot be combined with CORE_IN_DOCKER=true")
+		}
+		fmt.Fprintf(opts.Out(), "🐳 Running Core in Linux Docker container %s (local testing mode)\n", coreDockerImage(opts))
+		if err := handOver(); err != nil {
+			return nil, err
+		}
+		return startCoreInDocker(extractDir, host, port, logName, opts)
 	}
 	
 	// Direct execution path (used in CI and local native runs)
@@ -486,6 +666,9 @@ func StartCore(version, outputDir string, port int) (*monitor.Process, error) {
 
 	// Look for binary - prioritize mlos_core (current release format)
 	binaryPath := filepath.Join(extractDir, "build", "mlos_core")
+	if opts.CoreBinary != "" {
+		binaryPath = opts.CoreBinary
+	}
 
 	// Verify binary exists
 	if _, err := os.Stat(binaryPath); os.IsNotExist(err) {
@@ -522,6 +705,11 @@ func StartCore(version, outputDir string, port int) (*monitor.Process, error) {
 		}
 	}
 
+	// A mismatched ONNX Runtime would only show up as a dlopen failure at startup
+	if err := CheckONNXRuntimeVersion(binaryPath, filepath.Join(extractDir, "build", "onnxruntime", "lib"), opts); err != nil {
+		return nil, err
+	}
+
 	// Ensure we use absolute path for binary
 	absBinaryPath, err := filepath.Abs(binaryPath)
 	if err != nil {
@@ -547,12 +735,15 @@ func StartCore(version, outputDir string, port int) (*monitor.Process, error) {
 
 	// Create log files for Core output (better than buffers for signal handling)
 	coreLogDir := filepath.Join(filepath.Dir(extractDir), "logs")
+	if opts.CoreBinary != "" {
+		coreLogDir = filepath.Join(coreDir, "logs") // Keep the logs in the output directory, not the Core checkout
+	}
 	if err := os.MkdirAll(coreLogDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create log directory: %w", err)
 	}
 	
-	stdoutLog := filepath.Join(coreLogDir, "core-stdout.log")
-	stderrLog := filepath.Join(coreLogDir, "core-stderr.log")
+	stdoutLog := filepath.Join(coreLogDir, logName+"-stdout.log")
+	stderrLog := filepath.Join(coreLogDir, logName+"-stderr.log")
 	
 	stdoutFile, err := os.Create(stdoutLog)
 	if err != nil {
@@ -577,14 +768,21 @@ func StartCore(version, outputDir string, port int) (*monitor.Process, error) {
 	}
 
 	// Start process
+	if err := handOver(); err != nil {
+		stdoutFile.Close()
+		stderrFile.Close()
+		return nil, err
+	}
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("failed to start Core server: %w", err)
 	}
 
 	process := &monitor.Process{
-		PID:    cmd.Process.Pid,
-		Cmd:    cmd,
-		Binary: absBinaryPath,
+		PID:       cmd.Process.Pid,
+		Cmd:       cmd,
+		Binary:    absBinaryPath,
+		StdoutLog: stdoutLog,
+		StderrLog: stderrLog,
 	}
 
 	// Give server a moment to start
@@ -606,37 +804,61 @@ func StartCore(version, outputDir string, port int) (*monitor.Process, error) {
 	}
 
 	// Wait for server to be ready
-	if err := waitForServer(port); err != nil {
+	if err := waitForServer(host, port, opts); err != nil {
 		// Read log files for debugging
 		stdoutContent, stderrContent := readLogs()
 		if stdoutContent != "" {
-			fmt.Printf("Server stdout (from %s):\n%s\n", stdoutLog, stdoutContent)
+			fmt.Fprintf(opts.Out(), "Server stdout (from %s):\n%s\n", stdoutLog, stdoutContent)
 		}
 		if stderrContent != "" {
-			fmt.Printf("Server stderr (from %s):\n%s\n", stderrLog, stderrContent)
+			fmt.Fprintf(opts.Out(), "Server stderr (from %s):\n%s\n", stderrLog, stderrContent)
 		}
 		stdoutFile.Close()
 		stderrFile.Close()
 		if stopErr := monitor.StopProcess(process); stopErr != nil {
-			fmt.Printf("WARN: Failed to stop process: %v\n", stopErr)
+			fmt.Fprintf(opts.Out(), "WARN: Failed to stop process: %v\n", stopErr)
 		}
 		return nil, fmt.Errorf("server failed to start: %w", err)
 	}
 	
 	// Keep files open for the lifetime of the process (they'll be closed when process exits)
 	// Store log paths for later access if needed
-	fmt.Printf("📝 Core logs: stdout=%s, stderr=%s\n", stdoutLog, stderrLog)
+	fmt.Fprintf(opts.Out(), "📝 Core logs: stdout=%s, stderr=%s\n", stdoutLog, stderrLog)
 
 	return process, nil
 }
 
-func waitForServer(port int) error {
-	// Wait for server to be ready by checking HTTP endpoint (use explicit IPv4)
+// CheckCoreHealth verifies a Core at baseURL answers its health endpoint
+// With opts.StrictHealth the response body is checked as well.
+func CheckCoreHealth(baseURL string, opts Options) error {
+	if opts.StrictHealth {
+		return checkHealthStrict(baseURL, opts)
+	}
+	client := NewCoreClient(5*time.Second, opts)
+	url := strings.TrimRight(baseURL, "/") + "/health"
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("Core not reachable at %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Core health check at %s returned HTTP %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+func waitForServer(host string, port int, opts Options) error {
+	// Wait for server to be ready by checking HTTP endpoint
+	// JoinHostPort brackets IPv6 hosts; curl -g keeps it from globbing the brackets
 	maxRetries := 30
-	url := fmt.Sprintf("http://127.0.0.1:%d/health", port)
+	baseURL := "http://" + net.JoinHostPort(host, strconv.Itoa(port)) + opts.BasePath
+	url := baseURL + "/health"
+	if opts.StrictHealth {
+		return waitForStrictHealth(baseURL, maxRetries, opts)
+	}
 	for i := 0; i < maxRetries; i++ {
 		// Try health endpoint - check for any HTTP response (even 404 means server is up)
-		cmd := exec.Command("curl", "-s", "-o", "/dev/null", "-w", "%{http_code}", url)
+		cmd := exec.Command("curl", "-s", "-g", "-o", "/dev/null", "-w", "%{http_code}", url)
 		output, err := cmd.Output()
 		if err == nil {
 			statusCode := strings.TrimSpace(string(output))
@@ -645,9 +867,9 @@ func waitForServer(port int) error {
 				return nil
 			}
 		}
-		// Also try root endpoint as fallback (use explicit IPv4)
-		rootURL := fmt.Sprintf("http://127.0.0.1:%d/", port)
-		cmd2 := exec.Command("curl", "-s", "-o", "/dev/null", "-w", "%{http_code}", rootURL)
+		// Also try root endpoint as fallback
+		rootURL := baseURL + "/"
+		cmd2 := exec.Command("curl", "-s", "-g", "-o", "/dev/null", "-w", "%{http_code}", rootURL)
 		output2, err2 := cmd2.Output()
 		if err2 == nil {
 			statusCode := strings.TrimSpace(string(output2))
@@ -661,12 +883,25 @@ func waitForServer(port int) error {
 	return fmt.Errorf("server did not become ready after %d attempts (checked %s)", maxRetries, url)
 }
 
+// waitForStrictHealth waits for /health to return 200 with the expected body (--strict-health)
+// A Core that answers but stays unhealthy fails with the last check's error.
+func waitForStrictHealth(baseURL string, maxRetries int, opts Options) error {
+	var err error
+	for i := 0; i < maxRetries; i++ {
+		if err = checkHealthStrict(baseURL, opts); err == nil {
+			return nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return fmt.Errorf("server did not pass the strict health check after %d attempts: %w", maxRetries, err)
+}
+
 // downloadViaAPI downloads a release asset using GitHub API
 // Currently unused - using gh CLI directly instead
 // Keeping for potential future use
 //
 //nolint:unused // Kept for potential future use
-func downloadViaAPI(version, assetName, outputPath, token string) error {
+func downloadViaAPI(version, assetName, outputPath, token string, opts Options) error {
 	// Get release info
 	// Use public core-releases repo (GITHUB_TOKEN can access public repos)
 	apiURL := fmt.Sprintf("https://api.github.com/repos/mlOS-foundation/core-releases/releases/tags/%s", version)
@@ -683,7 +918,7 @@ func downloadViaAPI(version, assetName, outputPath, token string) error {
 	req.Header.Set("User-Agent", "mlOS-system-test/1.0")
 
 	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := doWithRateLimit(client, req, opts)
 	if err != nil {
 		return fmt.Errorf("failed to fetch release info: %w", err)
 	}
@@ -739,7 +974,7 @@ func downloadViaAPI(version, assetName, outputPath, token string) error {
 	}
 
 	// Download the asset
-	fmt.Printf("Downloading %s from GitHub API...\n", assetName)
+	fmt.Fprintf(opts.Out(), "Downloading %s from GitHub API...\n", assetName)
 	req, err = http.NewRequest("GET", downloadURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create download request: %w", err)
@@ -748,7 +983,7 @@ func downloadViaAPI(version, assetName, outputPath, token string) error {
 	req.Header.Set("Authorization", fmt.Sprintf("token %s", token))
 	req.Header.Set("Accept", "application/octet-stream")
 
-	resp, err = client.Do(req)
+	resp, err = doWithRateLimit(client, req, opts)
 	if err != nil {
 		return fmt.Errorf("failed to download asset: %w", err)
 	}
@@ -770,6 +1005,6 @@ func downloadViaAPI(version, assetName, outputPath, token string) error {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
-	fmt.Printf("✅ Downloaded %s\n", assetName)
+	fmt.Fprintf(opts.Out(), "✅ Downloaded %s\n", assetName)
 	return nil
 }
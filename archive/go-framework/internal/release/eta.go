@@ -0,0 +1,70 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// downloadETAInterval is how often a running download reports its progress
+const downloadETAInterval = 5 * time.Second
+
+// FormatETA renders a remaining duration for progress lines, e.g. "45s" or "3m10s"
+func FormatETA(d time.Duration) string {
+	if d < time.Second {
+		return "<1s"
+	}
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+	return d.Round(10 * time.Second).String()
+}
+
+// watchDownload reports a curl download's progress every downloadETAInterval until stop is called
+// The total comes from the Content-Length curl dumps to headerPath, which it writes before the body.
+func watchDownload(what, outputPath, headerPath string, opts Options) (stop func()) {
+	done := make(chan struct{})
+	start := time.Now()
+	go func() {
+		ticker := time.NewTicker(downloadETAInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				info, err := os.Stat(outputPath)
+				if err != nil || info.Size() == 0 {
+					continue
+				}
+				_, header := readDumpedHeaders(headerPath)
+				total, _ := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+				fmt.Fprintln(opts.Out(), downloadProgressLine(what, info.Size(), total, time.Since(start)))
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// downloadProgressLine describes a partial download; without a total there is no ETA
+func downloadProgressLine(what string, bytes, total int64, elapsed time.Duration) string {
+	const mb = 1024 * 1024
+	rate := float64(bytes) / elapsed.Seconds()
+	if total <= 0 || bytes > total {
+		return fmt.Sprintf("⏳ %s: %.1f MB (%.1f MB/s)", what, float64(bytes)/mb, rate/mb)
+	}
+	remaining := time.Duration(float64(total-bytes) / rate * float64(time.Second))
+	return fmt.Sprintf("⏳ %s: %.1f/%.1f MB (%.1f MB/s, ETA %s)",
+		what, float64(bytes)/mb, float64(total)/mb, rate/mb, FormatETA(remaining))
+}
+
+// curlOutputPath returns the file a curl invocation writes to (its -o argument), or ""
+func curlOutputPath(args []string) string {
+	for i, arg := range args {
+		if arg == "-o" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package release
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DefaultHealthBody is the /health response --strict-health expects unless told otherwise
+const DefaultHealthBody = `{"status":"ok"}`
+
+// Readiness check modes, as recorded in the results
+const (
+	HealthCheckLenient = "lenient" // Any HTTP response (even 404) means Core is up
+	HealthCheckStrict  = "strict"  // /health must return 200 with the expected body
+)
+
+// maxHealthBodyBytes bounds how much of a /health response is read
+const maxHealthBodyBytes = 64 * 1024
+
+// HealthCheckMode returns the readiness check opts selects (HealthCheckStrict or HealthCheckLenient)
+func HealthCheckMode(opts Options) string {
+	if opts.StrictHealth {
+		return HealthCheckStrict
+	}
+	return HealthCheckLenient
+}
+
+// checkHealthStrict requires /health at baseURL to return 200 with opts.HealthBody
+// A JSON HealthBody matches any JSON response with the same values for its fields; any other
+// HealthBody must equal the trimmed response, and an empty one accepts any body.
+func checkHealthStrict(baseURL string, opts Options) error {
+	client := NewCoreClient(5*time.Second, opts)
+	url := strings.TrimRight(baseURL, "/") + "/health"
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("Core not reachable at %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHealthBodyBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Core health check at %s returned HTTP %d, want 200", url, resp.StatusCode)
+	}
+	expected := strings.TrimSpace(opts.HealthBody)
+	if !healthBodyMatches(body, expected) {
+		return fmt.Errorf("Core health check at %s returned %q, want %s", url, truncateHealthBody(body), expected)
+	}
+	return nil
+}
+
+// healthBodyMatches reports whether a /health response satisfies the expected body
+func healthBodyMatches(body []byte, expected string) bool {
+	if expected == "" {
+		return true
+	}
+	var want map[string]interface{}
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		return string(bytes.TrimSpace(body)) == expected
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(body, &got); err != nil {
+		return false
+	}
+	// Extra fields (version, uptime, ...) are fine
+	for key, value := range want {
+		if !reflect.DeepEqual(got[key], value) {
+			return false
+		}
+	}
+	return true
+}
+
+// truncateHealthBody shortens a response for an error message
+func truncateHealthBody(body []byte) string {
+	const limit = 200
+	s := string(bytes.TrimSpace(body))
+	if len(s) > limit {
+		return s[:limit] + "..."
+	}
+	return s
+}
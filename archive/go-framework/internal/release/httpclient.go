@@ -0,0 +1,17 @@
+package release
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// NewCoreClient returns an HTTP client for talking to Core over http:// or https://
+// With opts.InsecureSkipVerify it accepts any TLS certificate.
+func NewCoreClient(timeout time.Duration, opts Options) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402 -- opt-in for self-signed test certs
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
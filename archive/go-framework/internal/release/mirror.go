@@ -0,0 +1,87 @@
+package release
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FetchAsset fetches a GitHub release asset into destPath
+// If mirror is empty the asset comes from github.com. Otherwise mirror replaces
+// https://github.com and must follow its layout: <mirror>/<owner>/<repo>/releases/download/<tag>/<asset>
+// A mirror that is a local directory (or file:// URL) is copied from without touching the network
+func FetchAsset(mirror, repo, tag, asset, destPath string, opts Options) error {
+	base := mirror
+	if base == "" {
+		base = "https://github.com"
+	}
+
+	if dir, local := localMirrorDir(base); local {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return fmt.Errorf("release mirror %s is not a directory", dir)
+		}
+		src := filepath.Join(dir, filepath.FromSlash(repo), "releases", "download", tag, asset)
+		if err := copyFile(src, destPath); err != nil {
+			return fmt.Errorf("failed to copy %s from local mirror: %w", asset, err)
+		}
+		fmt.Fprintf(opts.Out(), "✅ Copied %s from local mirror %s\n", asset, dir)
+		return nil
+	}
+
+	downloadURL := fmt.Sprintf("%s/%s/releases/download/%s/%s", strings.TrimRight(base, "/"), repo, tag, asset)
+	if err := curlWithRateLimit(asset, opts, "-L", "-f", "-#", "-o", destPath, downloadURL); err != nil {
+		return fmt.Errorf("failed to download %s: %w", downloadURL, err)
+	}
+	return nil
+}
+
+// DownloadGitHubAsset downloads a release asset from github.com into destDir, under its own name
+// gh is tried first, since it can use GITHUB_TOKEN; curl is the fallback for public repos
+// when gh isn't authenticated. Both wait out GitHub rate limits (see ghWithRateLimit).
+func DownloadGitHubAsset(repo, tag, asset, destDir string, opts Options) error {
+	err := ghWithRateLimit(asset, opts, "release", "download", tag,
+		"--repo", repo,
+		"--pattern", asset,
+		"--dir", destDir,
+		"--clobber") // Overwrite if exists
+	if err == nil {
+		return nil
+	}
+
+	fmt.Fprintf(opts.Out(), "   gh download failed, trying curl for public release...\n")
+	downloadURL := fmt.Sprintf("https://github.com/%s/releases/download/%s/%s", repo, tag, asset)
+	if curlErr := curlWithRateLimit(asset, opts, "-L", "-f", "-#", "-o", filepath.Join(destDir, asset), downloadURL); curlErr != nil {
+		return fmt.Errorf("gh: %w, curl: %w", err, curlErr)
+	}
+	fmt.Fprintf(opts.Out(), "   ✅ Downloaded via curl\n")
+	return nil
+}
+
+// localMirrorDir reports whether mirror is a local path (plain or file://) and returns it
+func localMirrorDir(mirror string) (string, bool) {
+	if strings.HasPrefix(mirror, "file://") {
+		return strings.TrimPrefix(mirror, "file://"), true
+	}
+	return mirror, !strings.Contains(mirror, "://")
+}
+
+// copyFile copies src to dst, creating or truncating dst
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
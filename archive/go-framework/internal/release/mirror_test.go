@@ -0,0 +1,56 @@
+package release
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeTool puts an executable shell script named name on PATH
+func fakeTool(t *testing.T, binDir, name, script string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(binDir, name), []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDownloadGitHubAssetFallsBackToCurl(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake gh and curl are shell scripts")
+	}
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("TMPDIR", t.TempDir()) // For the header file curl dumps to
+	calls := filepath.Join(t.TempDir(), "calls")
+	t.Setenv("CALLS", calls)
+
+	// gh isn't authenticated; curl gets the public release, writing to its -o argument
+	fakeTool(t, binDir, "gh", `echo "gh $*" >> "$CALLS"; echo "gh: To get started with GitHub CLI, please run: gh auth login" >&2; exit 4`+"\n")
+	fakeTool(t, binDir, "curl", `echo "curl $*" >> "$CALLS"
+while [ $# -gt 0 ]; do
+	if [ "$1" = "-o" ]; then echo converter > "$2"; fi
+	shift
+done
+`)
+
+	destDir := t.TempDir()
+	const asset = "axon-converter-3.1.9-linux-amd64.tar.gz"
+	if err := DownloadGitHubAsset("mlOS-foundation/axon", "v3.1.9", asset, destDir, Options{Output: io.Discard}); err != nil {
+		t.Fatalf("DownloadGitHubAsset: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(destDir, asset)); err != nil || string(data) != "converter\n" {
+		t.Errorf("asset = %q, %v", data, err)
+	}
+	log, _ := os.ReadFile(calls)
+	for _, want := range []string{
+		"gh release download v3.1.9 --repo mlOS-foundation/axon --pattern " + asset,
+		"https://github.com/mlOS-foundation/axon/releases/download/v3.1.9/" + asset,
+	} {
+		if !strings.Contains(string(log), want) {
+			t.Errorf("calls %q do not include %q", log, want)
+		}
+	}
+}
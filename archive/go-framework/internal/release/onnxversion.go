@@ -0,0 +1,79 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// onnxRuntimeLibPattern matches ONNX Runtime library names and captures their version:
+// libonnxruntime.so.1.18.0, libonnxruntime.so.1, libonnxruntime.1.18.0.so, libonnxruntime.1.18.0.dylib
+var onnxRuntimeLibPattern = regexp.MustCompile(`libonnxruntime(?:\.so)?\.(\d+(?:\.\d+){0,2})(?:\.so|\.dylib)?`)
+
+// CheckONNXRuntimeVersion compares the ONNX Runtime Core was linked against with the one in libDir
+// Core records the versioned library name it needs in its binary; if libDir provides a
+// different version, Core would die at startup with an opaque dlopen error, so this
+// returns a descriptive error instead. If Core's expectation can't be determined
+// (e.g. a statically linked build) it only warns.
+func CheckONNXRuntimeVersion(binaryPath, libDir string, opts Options) error {
+	expected, err := coreONNXRuntimeVersion(binaryPath)
+	if err != nil {
+		return err
+	}
+	if expected == "" {
+		fmt.Fprintf(opts.Out(), "WARN: Could not determine which ONNX Runtime %s was built against; skipping version check\n", binaryPath)
+		return nil
+	}
+
+	installed := installedONNXRuntimeVersion(libDir)
+	if installed == "" {
+		return fmt.Errorf("Core expects ONNX Runtime %s but no ONNX Runtime library was found in %s", expected, libDir)
+	}
+	if !onnxVersionsCompatible(expected, installed) {
+		return fmt.Errorf("ONNX Runtime version mismatch: Core was built against %s but %s provides %s", expected, libDir, installed)
+	}
+
+	fmt.Fprintf(opts.Out(), "✅ ONNX Runtime %s matches Core's expected version (%s)\n", installed, expected)
+	return nil
+}
+
+// coreONNXRuntimeVersion returns the most specific ONNX Runtime version named in the Core binary
+func coreONNXRuntimeVersion(binaryPath string) (string, error) {
+	data, err := os.ReadFile(binaryPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Core binary: %w", err)
+	}
+	var version string
+	for _, match := range onnxRuntimeLibPattern.FindAllSubmatch(data, -1) {
+		if v := string(match[1]); len(v) > len(version) {
+			version = v
+		}
+	}
+	return version, nil
+}
+
+// installedONNXRuntimeVersion returns the most specific ONNX Runtime version found in libDir
+func installedONNXRuntimeVersion(libDir string) string {
+	entries, err := os.ReadDir(libDir)
+	if err != nil {
+		return ""
+	}
+	var version string
+	for _, entry := range entries {
+		match := onnxRuntimeLibPattern.FindStringSubmatch(entry.Name())
+		if match != nil && len(match[1]) > len(version) {
+			version = match[1]
+		}
+	}
+	return version
+}
+
+// onnxVersionsCompatible reports whether two versions agree up to the shorter one's precision
+// A Core linked against the soname libonnxruntime.so.1 accepts any 1.x.y library.
+func onnxVersionsCompatible(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return a == b || strings.HasPrefix(b, a+".")
+}
@@ -0,0 +1,47 @@
+package release
+
+import (
+	"io"
+	"os"
+)
+
+// Options are the run's settings for downloading Axon and Core, and for starting and talking to Core
+// The zero value gives each setting's default. Functions that depend on one take Options as a
+// parameter, the way they take the release mirror, and pass it on to the helpers they call.
+type Options struct {
+	Output             io.Writer // Progress messages and command output (nil: os.Stdout; see Out)
+	EchoCommands       bool      // Print axon, gh and curl command lines before running them (--verbose)
+	InsecureSkipVerify bool      // Accept any TLS certificate from Core; only for self-signed test certs (--insecure-skip-verify)
+	StrictVersions     bool      // Reinstall, then fail, when the installed Axon isn't the requested version (--strict-versions)
+
+	// Print progress with an estimated time remaining while curl downloads run (--progress)
+	// gh downloads are not covered: gh exposes neither the file size nor the partial file.
+	DownloadETA bool
+
+	// Prefix of the routes of Cores started here, for their readiness checks (e.g. "/mlos"; --base-path)
+	// Checks given a base URL, like CheckCoreHealth, expect it to include the prefix already.
+	BasePath string
+
+	// Locally built Core StartCore runs instead of the downloaded release (--core-binary; "": the release)
+	// ONNX Runtime is still set up for it, under build/onnxruntime next to the binary (see coreBinaryRoot).
+	CoreBinary string
+
+	// Image Core runs in when CORE_IN_DOCKER=true (--core-docker-image; "": DefaultCoreDockerImage)
+	// A prebuilt image with curl and CA certificates installed skips the apt step, which also makes
+	// the Docker path work offline.
+	CoreDockerImage string
+
+	// Require Core's /health to return 200 with HealthBody before it counts as ready or healthy (--strict-health)
+	// The lenient default accepts any HTTP response, which suits Cores without a real /health.
+	StrictHealth bool
+	HealthBody   string // Expected /health body; see checkHealthStrict ("": any body)
+}
+
+// Out returns where progress messages and the output of the commands run go: Output, or os.Stdout
+// without one. --output-stdout points it at stderr so stdout carries only the report.
+func (o Options) Out() io.Writer {
+	if o.Output == nil {
+		return os.Stdout
+	}
+	return o.Output
+}
@@ -0,0 +1,21 @@
+package release
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestOptionsOut(t *testing.T) {
+	if out := (Options{}).Out(); out != os.Stdout {
+		t.Errorf("zero Options write to %v, want os.Stdout", out)
+	}
+
+	var out bytes.Buffer
+	LogCommand(exec.Command("curl", "-fsSL", "https://example.com/core.tar.gz"), Options{Output: &out, EchoCommands: true})
+	if !strings.Contains(out.String(), "curl -fsSL https://example.com/core.tar.gz") {
+		t.Errorf("echoed command did not go to Output; it has %q", out.String())
+	}
+}
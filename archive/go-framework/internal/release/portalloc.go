@@ -0,0 +1,67 @@
+package release
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+)
+
+// maxPortScan bounds how far past its first port a PortAllocator looks for a free one
+const maxPortScan = 100
+
+// PortAllocator hands out free TCP ports to Core instances started concurrently
+// Each port is bound when reserved and held until it is handed to Core (see StartCoreOnReservation),
+// so neither another reservation nor another process can take it in between.
+// A port is never handed out twice by the same allocator, even after release.
+type PortAllocator struct {
+	host  string
+	first int
+
+	mu     sync.Mutex
+	next   int
+	issued map[int]bool
+}
+
+// PortReservation is a port bound by this process on behalf of a Core instance
+type PortReservation struct {
+	Port     int
+	listener net.Listener
+}
+
+// NewPortAllocator returns an allocator for ports on host, starting at first
+func NewPortAllocator(host string, first int) *PortAllocator {
+	return &PortAllocator{host: host, first: first, next: first, issued: make(map[int]bool)}
+}
+
+// Reserve binds the next free port and returns it still bound; pass it to StartCoreOnReservation,
+// which releases it just before starting Core on it
+// Safe for concurrent use.
+func (a *PortAllocator) Reserve() (*PortReservation, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for port := a.next; port < a.first+maxPortScan && port <= 65535; port++ {
+		if a.issued[port] {
+			continue
+		}
+		listener, err := net.Listen("tcp", net.JoinHostPort(a.host, strconv.Itoa(port)))
+		if err != nil {
+			continue // In use by something else
+		}
+		a.issued[port] = true
+		a.next = port + 1
+		return &PortReservation{Port: port, listener: listener}, nil
+	}
+	return nil, fmt.Errorf("no free port on %s in %d-%d", a.host, a.first, a.first+maxPortScan-1)
+}
+
+// Release unbinds the port so Core can listen on it (idempotent)
+func (p *PortReservation) Release() error {
+	if p.listener == nil {
+		return nil
+	}
+	err := p.listener.Close()
+	p.listener = nil
+	return err
+}
@@ -0,0 +1,70 @@
+package release
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestPortAllocatorConcurrentReservations(t *testing.T) {
+	const host, instances = "127.0.0.1", 8
+
+	// Stands in for the main Core's port; extra instances are allocated after it, as startExtraCores does
+	core, err := net.Listen("tcp", net.JoinHostPort(host, "0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer core.Close()
+	corePort := core.Addr().(*net.TCPAddr).Port
+	if corePort+maxPortScan > 65535 {
+		t.Skipf("port %d leaves no room to allocate after it", corePort)
+	}
+
+	ports := NewPortAllocator(host, corePort+1)
+	reservations := make([]*PortReservation, instances)
+	var wg sync.WaitGroup
+	for i := range reservations {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			reservation, err := ports.Reserve()
+			if err != nil {
+				t.Errorf("Reserve: %v", err)
+				return
+			}
+			reservations[i] = reservation
+		}(i)
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	seen := make(map[int]bool)
+	for _, reservation := range reservations {
+		port := reservation.Port
+		if port == corePort {
+			t.Errorf("reserved Core's port %d", port)
+		}
+		if seen[port] {
+			t.Errorf("port %d reserved twice", port)
+		}
+		seen[port] = true
+
+		address := net.JoinHostPort(host, strconv.Itoa(port))
+		if l, err := net.Listen("tcp", address); err == nil {
+			l.Close()
+			t.Errorf("port %d is not held before Release", port)
+		}
+		if err := reservation.Release(); err != nil {
+			t.Errorf("Release(%d): %v", port, err)
+		}
+		l, err := net.Listen("tcp", address)
+		if err != nil {
+			t.Errorf("port %d still bound after Release: %v", port, err)
+			continue
+		}
+		l.Close()
+	}
+}
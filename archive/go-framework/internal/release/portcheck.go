@@ -0,0 +1,138 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrPortInUse is wrapped by CheckPortFree when something else is listening on the port
+var ErrPortInUse = errors.New("port conflict")
+
+// ssUserPattern pulls the process name and PID out of an `ss -p` users:(("name",pid=N,fd=M)) column
+var ssUserPattern = regexp.MustCompile(`\(\("([^"]*)",pid=(\d+)`)
+
+// portDialTimeout bounds the connect that looks for a listener on a local port
+const portDialTimeout = 500 * time.Millisecond
+
+// CheckPortFree fails if something already listens on port on this machine, naming the process
+// holding it when it can be found. Core would fail to bind, and the readiness check would then
+// pass against whatever else answers there. host is where the harness reaches Core; a host that
+// isn't this machine isn't checked. Core binds the port on every interface, so that is what is
+// probed, along with a connect to host: with SO_REUSEADDR, macOS lets the bind succeed even
+// while another process listens on one address.
+func CheckPortFree(host string, port int) error {
+	if !isLocalHost(host) {
+		return nil
+	}
+	if portAnswers(host, port) || (host != "127.0.0.1" && portAnswers("127.0.0.1", port)) {
+		return portInUse(port)
+	}
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err == nil {
+		return listener.Close()
+	}
+	if !errors.Is(err, syscall.EADDRINUSE) {
+		return fmt.Errorf("cannot bind port %d: %w", port, err)
+	}
+	return portInUse(port)
+}
+
+// portAnswers reports whether a TCP connect to host:port succeeds, i.e. something listens there
+func portAnswers(host string, port int) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), portDialTimeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+// isLocalHost reports whether host names this machine: localhost, a loopback or unspecified
+// address, or an address of one of its interfaces
+func isLocalHost(host string) bool {
+	if host == "" || strings.EqualFold(host, "localhost") {
+		return true
+	}
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else if resolved, err := net.LookupIP(host); err == nil {
+		ips = resolved
+	}
+	addrs, _ := net.InterfaceAddrs()
+	for _, ip := range ips {
+		if ip.IsLoopback() || ip.IsUnspecified() {
+			return true
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// portInUse is CheckPortFree's error for a port something else holds
+func portInUse(port int) error {
+	if holder := portHolder(port); holder != "" {
+		return fmt.Errorf("%w: port %d already in use by %s", ErrPortInUse, port, holder)
+	}
+	return fmt.Errorf("%w: port %d already in use (holder unknown)", ErrPortInUse, port)
+}
+
+// portHolder describes the process listening on a TCP port ("PID 1234 (python3)"), or "" if unknown
+// Uses ss on Linux and falls back to lsof, which is also what macOS has.
+func portHolder(port int) string {
+	if runtime.GOOS == "linux" {
+		if holder := portHolderSS(port); holder != "" {
+			return holder
+		}
+	}
+	return portHolderLsof(port)
+}
+
+// portHolderSS asks ss for the listener on port; it only sees PIDs of processes we may inspect
+func portHolderSS(port int) string {
+	output, err := exec.Command("ss", "-Hltnp", fmt.Sprintf("sport = :%d", port)).Output()
+	if err != nil {
+		return ""
+	}
+	match := ssUserPattern.FindStringSubmatch(string(output))
+	if match == nil {
+		return ""
+	}
+	return fmt.Sprintf("PID %s (%s)", match[2], match[1])
+}
+
+// portHolderLsof asks lsof for the listener on port, using its field output (p<pid>, c<command>)
+func portHolderLsof(port int) string {
+	output, err := exec.Command("lsof", "-nP", fmt.Sprintf("-iTCP:%d", port), "-sTCP:LISTEN", "-Fpc").Output()
+	if err != nil {
+		return ""
+	}
+	var pid, command string
+	for _, line := range strings.Split(string(output), "\n") {
+		switch {
+		case strings.HasPrefix(line, "p") && pid == "":
+			pid = line[1:]
+		case strings.HasPrefix(line, "c") && command == "":
+			command = line[1:]
+		}
+	}
+	if pid == "" {
+		return ""
+	}
+	if command == "" {
+		return "PID " + pid
+	}
+	return fmt.Sprintf("PID %s (%s)", pid, command)
+}
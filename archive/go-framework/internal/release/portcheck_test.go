@@ -0,0 +1,62 @@
+package release
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestCheckPortFree(t *testing.T) {
+	held, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer held.Close()
+	heldPort := held.Addr().(*net.TCPAddr).Port
+
+	free, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	freePort := free.Addr().(*net.TCPAddr).Port
+	free.Close()
+
+	tests := []struct {
+		name    string
+		host    string
+		port    int
+		inUse   bool
+		wantErr bool
+	}{
+		{"held on loopback", "127.0.0.1", heldPort, true, true},
+		{"held, reached as localhost", "localhost", heldPort, true, true},
+		{"held, Core on every interface", "0.0.0.0", heldPort, true, true},
+		{"free", "127.0.0.1", freePort, false, false},
+		{"remote host is not probed", "192.0.2.1", heldPort, false, false}, // TEST-NET-1, never local
+	}
+	for _, tt := range tests {
+		err := CheckPortFree(tt.host, tt.port)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: CheckPortFree(%s, %d) = %v", tt.name, tt.host, tt.port, err)
+		}
+		if tt.inUse && !errors.Is(err, ErrPortInUse) {
+			t.Errorf("%s: error %v does not wrap ErrPortInUse", tt.name, err)
+		}
+	}
+}
+
+func TestIsLocalHost(t *testing.T) {
+	for host, want := range map[string]bool{
+		"":            true,
+		"localhost":   true,
+		"127.0.0.1":   true,
+		"::1":         true,
+		"0.0.0.0":     true,
+		"192.0.2.1":   false,
+		"203.0.113.7": false,
+	} {
+		if got := isLocalHost(host); got != want {
+			t.Errorf("isLocalHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package release
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrPprofUnavailable is returned by FetchProfile when Core doesn't serve Go's pprof endpoints
+var ErrPprofUnavailable = errors.New("Core does not expose /debug/pprof")
+
+// FetchProfile downloads a profile from Core's pprof endpoint path (e.g. "/debug/pprof/heap") to dest
+// pprof profiles are gzipped protobuf, so a 404, or a 200 with anything else (a Core answering every
+// path), is ErrPprofUnavailable. timeout must cover the profile's own duration for CPU profiles.
+func FetchProfile(coreURL, path string, timeout time.Duration, dest string, opts Options) error {
+	resp, err := NewCoreClient(timeout, opts).Get(coreURL + path)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrPprofUnavailable
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	body := bufio.NewReader(resp.Body)
+	if magic, err := body.Peek(2); err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+		return ErrPprofUnavailable
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close()
+		os.Remove(dest)
+		return fmt.Errorf("failed to download %s: %w", path, err)
+	}
+	return f.Close()
+}
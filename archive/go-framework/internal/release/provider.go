@@ -0,0 +1,115 @@
+package release
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Where DetectExecutionProvider found the execution provider, as recorded in the results
+const (
+	ProviderSourceCore = "core" // Core reported it (in its /health response)
+	ProviderSourceLog  = "log"  // ONNX Runtime named it in Core's output
+)
+
+// executionProviderNames maps lowercased ONNX Runtime provider names to how the results spell them
+var executionProviderNames = map[string]string{
+	"cpu":      "CPU",
+	"cuda":     "CUDA",
+	"tensorrt": "TensorRT",
+	"rocm":     "ROCm",
+	"migraphx": "MIGraphX",
+	"coreml":   "CoreML",
+	"directml": "DirectML",
+	"dml":      "DirectML",
+	"openvino": "OpenVINO",
+	"qnn":      "QNN",
+	"nnapi":    "NNAPI",
+	"xnnpack":  "XNNPACK",
+}
+
+// executionProviderPattern matches ONNX Runtime's provider class names (CUDAExecutionProvider) and
+// "execution provider: cuda"-style log fields
+var executionProviderPattern = regexp.MustCompile(`(?i)\b([a-z]+)ExecutionProvider\b|execution[ _-]?provider["']?\s*[=:]\s*["']?([a-z]+)`)
+
+// executionProviderFields are the /health JSON fields a Core reporting its provider might use
+var executionProviderFields = []string{"execution_provider", "executionProvider", "provider", "providers"}
+
+// DetectExecutionProvider returns the ONNX Runtime execution provider Core runs inference on, and where
+// that was found (ProviderSourceCore or ProviderSourceLog); both are empty if it can't be told.
+// Core's /health response is asked first. Otherwise Core's logs are searched: ONNX Runtime always
+// registers the CPU provider as a fallback, so any accelerated provider they mention wins over it.
+// Call it after inference, since ONNX Runtime only logs providers once a session has been created.
+func DetectExecutionProvider(coreURL, stdoutLog, stderrLog string, opts Options) (string, string) {
+	if provider := healthExecutionProvider(coreURL, opts); provider != "" {
+		return provider, ProviderSourceCore
+	}
+	if provider := logExecutionProvider(stdoutLog, stderrLog); provider != "" {
+		return provider, ProviderSourceLog
+	}
+	return "", ""
+}
+
+// healthExecutionProvider looks for an execution provider field in Core's /health JSON
+func healthExecutionProvider(coreURL string, opts Options) string {
+	resp, err := NewCoreClient(5*time.Second, opts).Get(coreURL + "/health")
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+	var health map[string]interface{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&health); err != nil {
+		return ""
+	}
+	for _, field := range executionProviderFields {
+		switch value := health[field].(type) {
+		case string:
+			return executionProviderName(value)
+		case []interface{}:
+			// A provider list is in priority order, as ONNX Runtime's session options take it
+			if len(value) > 0 {
+				if name, ok := value[0].(string); ok {
+					return executionProviderName(name)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// logExecutionProvider returns the last accelerated provider Core's logs name, else CPU if they name it
+func logExecutionProvider(logs ...string) string {
+	var accelerated, cpu string
+	for _, path := range logs {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, match := range executionProviderPattern.FindAllStringSubmatch(string(data), -1) {
+			name := executionProviderName(match[1] + match[2])
+			switch name {
+			case "":
+			case "CPU":
+				cpu = name
+			default:
+				accelerated = name
+			}
+		}
+	}
+	if accelerated != "" {
+		return accelerated
+	}
+	return cpu
+}
+
+// executionProviderName canonicalizes a provider name ("CUDAExecutionProvider", "cuda"), or returns "" for unknown ones
+func executionProviderName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), "executionprovider")
+	return executionProviderNames[name]
+}
@@ -0,0 +1,175 @@
+package release
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitHub rate-limit handling for release downloads
+const (
+	maxRateLimitRetries  = 3                // Retries per download after a rate-limit response
+	maxRateLimitWait     = 5 * time.Minute  // Cap on a single wait, whatever the headers ask for
+	defaultRateLimitWait = 60 * time.Second // Wait when GitHub gives no Retry-After/X-RateLimit-Reset
+)
+
+// rateLimitDelay reports whether a response is a GitHub rate limit and how long to wait before retrying
+// GitHub answers 429, or 403 with X-RateLimit-Remaining: 0 (primary limit) or a Retry-After header (secondary limit).
+func rateLimitDelay(status int, header http.Header) (time.Duration, bool) {
+	limited := status == http.StatusTooManyRequests ||
+		(status == http.StatusForbidden && (header.Get("X-RateLimit-Remaining") == "0" || header.Get("Retry-After") != ""))
+	if !limited {
+		return 0, false
+	}
+
+	wait := defaultRateLimitWait
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if secs, err := strconv.Atoi(retryAfter); err == nil {
+			wait = time.Duration(secs) * time.Second
+		} else if at, err := http.ParseTime(retryAfter); err == nil {
+			wait = time.Until(at)
+		}
+	} else if reset := header.Get("X-RateLimit-Reset"); reset != "" {
+		if epoch, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			wait = time.Until(time.Unix(epoch, 0)) + time.Second // The window reopens just after the reset second
+		}
+	}
+
+	if wait < time.Second {
+		wait = time.Second
+	}
+	if wait > maxRateLimitWait {
+		wait = maxRateLimitWait
+	}
+	return wait, true
+}
+
+// waitForRateLimit logs and sleeps through one rate-limit backoff
+func waitForRateLimit(what string, status int, wait time.Duration, retry int, opts Options) {
+	fmt.Fprintf(opts.Out(), "⏳ GitHub rate limit hit while downloading %s (HTTP %d); waiting %s before retry %d/%d\n",
+		what, status, wait.Round(time.Second), retry, maxRateLimitRetries)
+	time.Sleep(wait)
+}
+
+// doWithRateLimit sends a body-less request, retrying while GitHub rate-limits it
+func doWithRateLimit(client *http.Client, req *http.Request, opts Options) (*http.Response, error) {
+	for retry := 1; ; retry++ {
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		wait, limited := rateLimitDelay(resp.StatusCode, resp.Header)
+		if !limited || retry > maxRateLimitRetries {
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		waitForRateLimit(req.URL.String(), resp.StatusCode, wait, retry, opts)
+	}
+}
+
+// curlWithRateLimit runs curl with args, retrying while GitHub rate-limits the download
+// args should include -f so HTTP errors fail the command; the response headers are
+// dumped to a temporary file (-D) so the rate-limit headers can be read.
+func curlWithRateLimit(what string, opts Options, args ...string) error {
+	tmpDir, err := RunTempDir()
+	if err != nil {
+		return err
+	}
+	headerFile, err := os.CreateTemp(tmpDir, "curl-headers-*")
+	if err != nil {
+		return fmt.Errorf("failed to create header file: %w", err)
+	}
+	headerFile.Close()
+	defer os.Remove(headerFile.Name())
+
+	for retry := 1; ; retry++ {
+		cmd := exec.Command("curl", append([]string{"-D", headerFile.Name()}, args...)...)
+		cmd.Stderr = os.Stderr // Show curl's progress bar
+		LogCommand(cmd, opts)
+		var runErr error
+		if output := curlOutputPath(args); opts.DownloadETA && output != "" {
+			stop := watchDownload(what, output, headerFile.Name(), opts)
+			runErr = cmd.Run()
+			stop()
+		} else {
+			runErr = cmd.Run()
+		}
+		if runErr == nil {
+			return nil
+		}
+		status, header := readDumpedHeaders(headerFile.Name())
+		wait, limited := rateLimitDelay(status, header)
+		if !limited || retry > maxRateLimitRetries {
+			return runErr
+		}
+		waitForRateLimit(what, status, wait, retry, opts)
+	}
+}
+
+// readDumpedHeaders parses the last response in a curl -D header dump (earlier ones are redirects)
+func readDumpedHeaders(path string) (int, http.Header) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, nil
+	}
+	status := 0
+	header := http.Header{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "HTTP/") {
+			// Status line of the next response: "HTTP/2 429" or "HTTP/1.1 403 Forbidden"
+			status = 0
+			header = http.Header{}
+			if fields := strings.Fields(line); len(fields) >= 2 {
+				status, _ = strconv.Atoi(fields[1])
+			}
+			continue
+		}
+		if name, value, ok := strings.Cut(line, ":"); ok {
+			header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+	}
+	return status, header
+}
+
+// ghWithRateLimit runs a gh command, retrying while GitHub rate-limits it
+// gh does not expose the response headers, so the wait is defaultRateLimitWait.
+func ghWithRateLimit(what string, opts Options, args ...string) error {
+	for retry := 1; ; retry++ {
+		var stderr bytes.Buffer
+		cmd := exec.Command("gh", args...)
+		cmd.Stdout = opts.Out()
+		cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+		LogCommand(cmd, opts)
+		runErr := cmd.Run()
+		if runErr == nil {
+			return nil
+		}
+		status, limited := ghRateLimitStatus(stderr.String())
+		if !limited || retry > maxRateLimitRetries {
+			return runErr
+		}
+		waitForRateLimit(what, status, defaultRateLimitWait, retry, opts)
+	}
+}
+
+// ghRateLimitStatus recognizes gh's rate-limit errors, e.g. "HTTP 403: API rate limit exceeded"
+func ghRateLimitStatus(output string) (int, bool) {
+	lower := strings.ToLower(output)
+	switch {
+	case strings.Contains(lower, "http 429"):
+		return http.StatusTooManyRequests, true
+	case strings.Contains(lower, "rate limit"):
+		return http.StatusForbidden, true
+	}
+	return 0, false
+}
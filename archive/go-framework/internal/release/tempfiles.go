@@ -0,0 +1,63 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// RunID tags the temporary files this run creates, so the post-run audit only touches its own
+var RunID = fmt.Sprintf("%s-%d", time.Now().Format("20060102-150405"), os.Getpid())
+
+// tempDirPrefix names the per-run directories in the system temp directory
+const tempDirPrefix = "mlos-system-test-"
+
+// TempLeftover is a temporary file or directory a run left behind
+type TempLeftover struct {
+	Path  string
+	Bytes int64
+}
+
+// RunTempDir returns this run's temporary directory, creating it if needed
+// Downloads that are deleted once used (the converter image, the ONNX Runtime archive) are staged
+// here rather than loose in /tmp, so whatever a failure leaves behind is found by AuditTempFiles.
+func RunTempDir() (string, error) {
+	dir := runTempDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	return dir, nil
+}
+
+func runTempDir() string {
+	return filepath.Join(os.TempDir(), tempDirPrefix+RunID)
+}
+
+// AuditTempFiles lists what is left in this run's temporary directory, largest first
+// With remove set the directory is deleted as well. An empty directory is removed either way.
+func AuditTempFiles(remove bool) ([]TempLeftover, error) {
+	dir := runTempDir()
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read temporary directory: %w", err)
+	}
+
+	leftovers := make([]TempLeftover, 0, len(entries))
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		leftovers = append(leftovers, TempLeftover{Path: path, Bytes: diskUsage(path)})
+	}
+	sort.Slice(leftovers, func(i, j int) bool { return leftovers[i].Bytes > leftovers[j].Bytes })
+
+	if remove || len(leftovers) == 0 {
+		if err := os.RemoveAll(dir); err != nil {
+			return leftovers, fmt.Errorf("failed to remove temporary directory: %w", err)
+		}
+	}
+	return leftovers, nil
+}
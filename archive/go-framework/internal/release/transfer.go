@@ -0,0 +1,30 @@
+package release
+
+import (
+	"os"
+	"time"
+)
+
+// Transfer describes one artifact download, for bandwidth reporting
+// A zero Transfer means nothing was downloaded (e.g. the artifact was already present)
+type Transfer struct {
+	Bytes    int64
+	Duration time.Duration
+}
+
+// MBps returns the effective throughput in MB/s (0 if nothing was timed)
+func (t Transfer) MBps() float64 {
+	if t.Bytes == 0 || t.Duration <= 0 {
+		return 0
+	}
+	return float64(t.Bytes) / (1024 * 1024) / t.Duration.Seconds()
+}
+
+// TransferOf builds a Transfer for a file fetched since start (size 0 if the file is missing)
+func TransferOf(path string, start time.Time) Transfer {
+	t := Transfer{Duration: time.Since(start)}
+	if info, err := os.Stat(path); err == nil {
+		t.Bytes = info.Size()
+	}
+	return t
+}
@@ -2,56 +2,192 @@ package report
 
 import (
 	"encoding/json"
+	"fmt"
 	"html/template"
+	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/mlOS-foundation/system-test/internal/config"
+	"github.com/mlOS-foundation/system-test/internal/model"
+	"github.com/mlOS-foundation/system-test/internal/release"
 	"github.com/mlOS-foundation/system-test/internal/test"
 )
 
 // ReportData holds all data needed for the report template
+//
+// Its exported fields are the stable inputs for custom templates passed via
+// --report-template. Templates use "[[" and "]]" as delimiters and may call
+// htmlSafe, hasMetrics and json (see Generator.Generate). Fields are only
+// ever added, never renamed or removed, so existing templates keep working.
 type ReportData struct {
 	// Summary metrics
-	SuccessRate          float64
+	SuccessRate float64
+
+	// Quality gate (thresholds are zero in metrics from runs before the gate existed)
+	MinSuccessRate       float64
+	MinPassingModels     int
+	PassingModels        int
+	TotalModels          int
+	SuccessCriteria      string // --success-criteria expression (replaces the thresholds when set)
+	GateMet              bool
 	SummaryCardClass     string
 	TotalDuration        float64
 	SuccessfulInferences int
 	TotalInferences      int
+	SkippedInferences    int
 	ModelsInstalled      int
 
 	// Versions
 	AxonVersion string
 	CoreVersion string
+	CoreBinary  string // Local Core build tested instead of the release (empty for a release)
 
 	// Installation times
-	AxonDownloadTime int64
-	CoreDownloadTime int64
-	CoreStartupTime  int64
+	AxonDownloadTime        int64
+	CoreDownloadTime        int64
+	ONNXRuntimeDownloadTime int64
+	DownloadWallTime        int64
+	CoreStartupTime         int64
+	HealthCheckMode         string // "strict" or "lenient" (empty in metrics from older runs)
+	APIStyle                string // "native" or "openai" (empty in metrics from older runs)
+	InferencePath           string // Native inference route template (empty for openai and in metrics from older runs)
+
+	// Machine speed relative to the timing baseline (--normalize-timings; 0 if not measured)
+	PerformanceIndex float64
+
+	// ONNX Runtime execution provider Core ran inference on (empty if unknown); also in HardwareSpecs
+	ExecutionProvider string
+
+	// Download sizes and bandwidth, in fixed component order
+	DownloadStats []DownloadStat
+
+	// Skipped models (display name -> reason)
+	SkipReasons map[string]string
+
+	// Models installed but deliberately not inferred (--install-only-categories)
+	InstallOnlyModels []string
+
+	// Registrations run concurrently (--register-concurrency; timings include contention when > 1)
+	RegistrationConcurrency int
 
 	// Model metrics
-	RegistrationMetrics []ModelMetric
-	InferenceMetrics    []ModelMetric
+	RegistrationMetrics  []ModelMetric
+	UnregisterMetrics    []ModelMetric // --test-unregister teardown
+	ReregisterMetrics    []ModelMetric // --test-reregister idempotency check
+	InferenceMetrics     []ModelMetric
+	FamilyMetrics        []FamilyMetric // Only families of two or more models; lone models are their own rows
+	InferenceParallelism int            // Inference tests run concurrently (latencies include contention when > 1)
+	BatchMetrics         []BatchMetric
+	BatchSweep           []BatchSweepMetric
+	StreamingMetrics     []StreamingMetric
+	TimeseriesMetrics    []TimeseriesMetric // --record-timeseries
+
+	// Gzipped inference requests (display name -> count; threshold 0 if compression was off)
+	GzipThresholdBytes int
+	CompressedRequests map[string]int
+	FuzzMetrics        []FuzzMetric
+	FuzzSeed           int64
+	ColdStartMetrics   []ColdStartMetric
+	BatchSize          int
+
+	// Determinism check (--check-determinism; nil when off)
+	DeterminismMetrics   []DeterminismMetric
+	DeterminismTolerance float64
 
 	// Chart data
 	InferenceLabelsJSON template.JS
 	InferenceDataJSON   template.JS
 	InferenceColorsJSON template.JS
 
+	// Watchdog restarts of Core during the run
+	CoreRestarts []CoreRestartEvent
+
+	// Pipeline attempts that failed on infrastructure before this run's (--pipeline-retries)
+	PipelineRetries []PipelineRetryEvent
+
+	// Per-model flakiness across the repetitions of --repeat, highest score first (nil without it)
+	Repetitions        int
+	AbortedRepetitions int // Repetitions that failed before producing results; they count against no model
+	Flakiness          []FlakinessMetric
+
+	// Core pprof profiles captured under load, CPU first (--profile)
+	CoreProfiles []ProfileFile
+
+	// End of Core's stdout/stderr logs (nil if Core wasn't started by the run)
+	CoreStdoutTail []string
+	CoreStderrTail []string
+
+	// Every failure the run recorded, shown first in the report (empty on a clean run)
+	Errors []RunErrorMetric
+
+	// Failure injection outcomes (--inject-failures)
+	InjectedFailures []InjectedFailureMetric
+
+	// Recorded session replayed against this Core (--replay; nil when off)
+	Replay *ReplayReport
+
+	// Side-by-side small inference results per Core instance (empty unless several ran)
+	CoreInstances []InstanceReport
+
+	// Pipeline timeline (one bar per stage)
+	Timeline []TimelineSpan
+
 	// Totals
 	TotalInferenceTime int64
 	TotalRegisterTime  int64
 
+	// Input schemas reported by Core (display name -> inputs)
+	InputSchemas map[string][]model.InputSpec
+
+	// Models limited to one inference size (display name -> sizes that ran)
+	InferenceSizes map[string][]string
+
+	// Prompt the inputs were tokenized from (display name -> small-input token IDs)
+	Prompt       string
+	PromptTokens map[string][]int
+
+	// Installed model files (display name -> path, SHA256, size)
+	ModelManifest map[string]model.ManifestEntry
+
+	// Format each model was installed in (display name -> onnx, pytorch, safetensors)
+	ModelFormats map[string]string
+
+	// ONNX metadata checks against --model-expectations, in matrix order (empty if none were set)
+	MetadataChecks []MetadataCheckMetric
+
+	// Per-model memory footprint (empty unless measured)
+	ModelMemory          []ModelMemoryMetric
+	CoreBaselineMemoryMB float64
+
+	// Memory stability
+	MemoryStability *MemoryStability
+
 	// Hardware
 	HardwareSpecs map[string]string
 
+	// Recognized environment variables that were set, in config.RecognizedEnvVars order
+	Environment []EnvironmentVar
+
 	// Resources
 	ResourceUsage map[string]interface{}
 
 	// Categories
 	CategoryStatuses map[string]interface{}
+	UnmetCategories  []string // Required categories with no tested model
+
+	// Thermal throttling during the timed phases
+	Throttled       bool
+	ThermalDetails  []string
+	MaxTemperatureC float64 // -1 if unknown
 
 	// Timestamp
 	Timestamp string
+
+	// Harness build that produced the report
+	HarnessVersion string
+	HarnessCommit  string
+	HarnessDate    string
 }
 
 // ModelMetric represents a single model metric
@@ -60,38 +196,468 @@ type ModelMetric struct {
 	Value      int64  `json:"value"`
 	Status     string `json:"status"` // "success", "failed", "ready"
 	StatusText string `json:"statusText"`
-	Type       string `json:"type"` // "registration", "inference-small", "inference-large"
+	Type       string `json:"type"` // "registration", "reregistration", "unregistration", "inference-small", "inference-large"
+	Error      string `json:"error,omitempty"`
+	Budget     int64  `json:"budget,omitempty"` // Latency budget in ms (inference only; 0 means ungated)
+	Bytes      int64  `json:"bytes,omitempty"`  // Response body size (inference only; 0 if none was read)
+
+	// Latency scaled to the reference machine (passing inference only, with --normalize-timings)
+	NormalizedMs float64 `json:"normalizedMs,omitempty"`
+
+	// HTTP status a negative test had to get and got (0 otherwise; an actual 0 means no response)
+	ExpectedStatus int `json:"expectedStatus,omitempty"`
+	ActualStatus   int `json:"actualStatus,omitempty"`
+}
+
+// FamilyMetric aggregates the inference results of the models sharing a ModelSpec.Family
+type FamilyMetric struct {
+	Family   string   `json:"family"`
+	Models   []string `json:"models"`
+	Tested   int      `json:"tested"`
+	Passed   int      `json:"passed"`   // Every inference test of the model passed
+	PassRate float64  `json:"passRate"` // Percent of the tested models (0 if none were)
+	MeanMs   float64  `json:"meanMs"`   // Over the passing small inferences (0 if none passed)
+	MedianMs float64  `json:"medianMs"`
+}
+
+// MetadataCheckMetric is the outcome of one model's metadata check
+type MetadataCheckMetric struct {
+	Name       string   `json:"name"`
+	Mismatches []string `json:"mismatches"` // Empty if the graph matched
+}
+
+// MemoryStability summarizes Core RSS before and after repeated inference
+type MemoryStability struct {
+	Iterations    int     `json:"iterations"`
+	BeforeMB      float64 `json:"beforeMB"`
+	AfterMB       float64 `json:"afterMB"`
+	GrowthMB      float64 `json:"growthMB"`
+	LeakSuspected bool    `json:"leakSuspected"`
+}
+
+// EnvironmentVar is one environment variable that affected the run
+type EnvironmentVar struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"` // Secrets are already redacted
+	Effect string `json:"effect"`
+}
+
+// ModelMemoryMetric is the Core RSS growth attributed to registering one model
+type ModelMemoryMetric struct {
+	Name     string  `json:"name"`
+	MemoryMB float64 `json:"memoryMB"`
+}
+
+// ColdStartMetric compares a model's first inference after registration with warm ones
+type ColdStartMetric struct {
+	Name      string `json:"name"`
+	ColdMs    int64  `json:"coldMs"`
+	WarmMs    int64  `json:"warmMs"`
+	PenaltyMs int64  `json:"penaltyMs"`
+}
+
+// CoreRestartEvent is a Core restart performed by the watchdog
+type CoreRestartEvent struct {
+	Time        string `json:"time"`
+	BeforeModel string `json:"beforeModel"`
+	Reason      string `json:"reason"`
+	Succeeded   bool   `json:"succeeded"`
+	Error       string `json:"error,omitempty"`
+}
+
+// PipelineRetryEvent is an earlier attempt of the run that failed downloading releases or starting Core
+type PipelineRetryEvent struct {
+	Time  string `json:"time"`
+	Stage string `json:"stage"`
+	Error string `json:"error"`
+}
+
+// FlakinessMetric is one model's outcomes across the repetitions of --repeat
+type FlakinessMetric struct {
+	Name         string   `json:"name"`
+	Runs         int      `json:"runs"`
+	Failures     int      `json:"failures"`
+	Score        float64  `json:"score"`                  // Fraction of runs failed
+	Verdict      string   `json:"verdict"`                // "stable", "flaky" or "broken"
+	FailureModes []string `json:"failureModes,omitempty"` // "<failure> (n×)", most frequent first
+}
+
+// ProfileFile is a Core pprof profile saved by the run
+type ProfileFile struct {
+	Kind string `json:"kind"` // test.ProfileCPU or test.ProfileHeap
+	Path string `json:"path"`
+	File string `json:"file"` // Base name; the profiles are saved next to the report
+}
+
+// RunErrorMetric is one failure in the report's Failures section
+type RunErrorMetric struct {
+	Stage   string `json:"stage"`
+	Model   string `json:"model,omitempty"`
+	Message string `json:"message"`
+}
+
+// InjectedFailureMetric is the outcome of one deliberately injected failure
+
+type InjectedFailureMetric struct {
+	Scenario string `json:"scenario"`
+	Detail   string `json:"detail,omitempty"`
+	Expected string `json:"expected"`
+	Observed string `json:"observed"`
+	Status   string `json:"status"` // success, failed or skipped
+}
+
+// ReplayReport compares this Core's responses with a recorded session's
+type ReplayReport struct {
+	SessionPath         string                   `json:"sessionPath"`
+	RecordedCoreVersion string                   `json:"recordedCoreVersion"`
+	Requests            int                      `json:"requests"`
+	Matched             int                      `json:"matched"`
+	Divergences         []ReplayDivergenceMetric `json:"divergences"`
+}
+
+// ReplayDivergenceMetric is one replayed request whose response differed from the recording
+type ReplayDivergenceMetric struct {
+	Model   string   `json:"model"`
+	Request int      `json:"request"`
+	Path    string   `json:"path"`
+	Details []string `json:"details"`
+}
+
+// DownloadStat is the size and effective bandwidth of one component download
+type DownloadStat struct {
+	Component string  `json:"component"`
+	Bytes     int64   `json:"bytes"`
+	MBps      float64 `json:"mbps"`
+}
+
+// InstanceReport is one Core instance's column in the isolation results
+type InstanceReport struct {
+	Label   string           `json:"label"`
+	URL     string           `json:"url"`
+	Error   string           `json:"error,omitempty"`
+	Results []InstanceResult `json:"results"`
+}
+
+// InstanceResult is a model's small inference result on one Core instance
+type InstanceResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	TimeMs int64  `json:"timeMs"`
+	Error  string `json:"error,omitempty"`
+}
+
+// TimelineSpan is a pipeline stage positioned on the run's timeline
+type TimelineSpan struct {
+	Name       string `json:"name"`
+	StartMs    int64  `json:"startMs"`
+	DurationMs int64  `json:"durationMs"`
+	Failed     bool   `json:"failed"`
+}
+
+// FuzzMetric summarizes randomized-input inference for a single model
+type FuzzMetric struct {
+	Name       string `json:"name"`
+	Requests   int    `json:"requests"`
+	Failures   int    `json:"failures"`
+	FirstError string `json:"firstError,omitempty"`
+}
+
+// DeterminismMetric is one model's determinism check: its small input sent twice, outputs compared
+type DeterminismMetric struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`            // "deterministic", "nondeterministic" or "failed"
+	Details []string `json:"details,omitempty"` // Where the outputs differed, or why the check failed
+}
+
+// TimeseriesMetric is one model's rolling inference: every request's latency, in order (--record-timeseries)
+type TimeseriesMetric struct {
+	Name        string    `json:"name"`
+	LatenciesMs []float64 `json:"latenciesMs"` // -1 for a failed request
+	Failures    int       `json:"failures"`
+	FirstMs     float64   `json:"firstMs"`  // First successful request, which pays any remaining warmup
+	SteadyMs    float64   `json:"steadyMs"` // Median of the second half
+	DriftPct    float64   `json:"driftPct"` // Mean of the last quarter against the second quarter's (0 with under 4 requests)
+	Drifting    bool      `json:"drifting"` // DriftPct is over timeseriesDriftThresholdPct
+}
+
+// timeseriesDriftThresholdPct is the latency drift over a rolling inference run that the report flags
+const timeseriesDriftThresholdPct = 10
+
+// BatchSweepMetric is one model's throughput across the swept batch sizes
+type BatchSweepMetric struct {
+	Name       string            `json:"name"`
+	Points     []BatchSweepPoint `json:"points"`
+	Efficiency float64           `json:"efficiency"` // 1.0 = linear scaling, 0 = unknown
+	SubLinear  bool              `json:"subLinear"`
+}
+
+// BatchSweepPoint is the throughput at one batch size (0 if the request failed)
+type BatchSweepPoint struct {
+	BatchSize  int     `json:"batchSize"`
+	Throughput float64 `json:"throughput"`
+}
+
+// StreamingMetric is one model's streamed inference token timing
+type StreamingMetric struct {
+	Name               string  `json:"name"`
+	Status             string  `json:"status"` // "success", "failed" or "unsupported"
+	Error              string  `json:"error,omitempty"`
+	Tokens             int     `json:"tokens"`
+	TimeToFirstTokenMs float64 `json:"timeToFirstTokenMs"`
+	MeanInterTokenMs   float64 `json:"meanInterTokenMs"`
+	MaxInterTokenMs    float64 `json:"maxInterTokenMs"`
+	TotalMs            float64 `json:"totalMs"`
+}
+
+// BatchMetric represents a batched inference result for a single model
+type BatchMetric struct {
+	Name       string  `json:"name"`
+	Value      int64   `json:"value"`      // time_ms for the whole batch
+	Throughput float64 `json:"throughput"` // examples/sec
+	Status     string  `json:"status"`
+	StatusText string  `json:"statusText"`
 }
 
 // PrepareData creates a ReportData structure from test results
 func PrepareData(results *test.Results, cfg *config.Config) *ReportData {
 	data := &ReportData{
-		SuccessRate:          results.SuccessRate,
-		TotalDuration:        results.Duration.Seconds(),
-		SuccessfulInferences: results.Metrics.SuccessfulInferences,
-		TotalInferences:      results.Metrics.TotalInferences,
-		ModelsInstalled:      results.Metrics.ModelsInstalled,
-		AxonVersion:          results.AxonVersion,
-		CoreVersion:          results.CoreVersion,
-		AxonDownloadTime:     results.Metrics.AxonDownloadTimeMs,
-		CoreDownloadTime:     results.Metrics.CoreDownloadTimeMs,
-		CoreStartupTime:      results.Metrics.CoreStartupTimeMs,
-		HardwareSpecs:        formatHardwareSpecs(results.HardwareSpecs),
-		ResourceUsage:        formatResourceUsage(results.ResourceUsage),
-		Timestamp:            time.Now().Format("2006-01-02 15:04:05"),
+		SuccessRate:             results.SuccessRate,
+		TotalDuration:           results.Duration.Seconds(),
+		SuccessfulInferences:    results.Metrics.SuccessfulInferences,
+		TotalInferences:         results.Metrics.TotalInferences,
+		SkippedInferences:       results.Metrics.SkippedInferences,
+		ModelsInstalled:         results.Metrics.ModelsInstalled,
+		AxonVersion:             results.AxonVersion,
+		CoreVersion:             results.CoreVersion,
+		CoreBinary:              results.CoreBinary,
+		AxonDownloadTime:        results.Metrics.AxonDownloadTimeMs,
+		CoreDownloadTime:        results.Metrics.CoreDownloadTimeMs,
+		ONNXRuntimeDownloadTime: results.Metrics.ONNXRuntimeDownloadTimeMs,
+		DownloadWallTime:        results.Metrics.DownloadWallTimeMs,
+		CoreStartupTime:         results.Metrics.CoreStartupTimeMs,
+		HealthCheckMode:         results.HealthCheckMode,
+		APIStyle:                results.APIStyle,
+		InferencePath:           results.InferencePath,
+		PerformanceIndex:        results.PerformanceIndex,
+		HardwareSpecs:           formatHardwareSpecs(results.HardwareSpecs),
+		ResourceUsage:           formatResourceUsage(results.ResourceUsage),
+		Timestamp:               time.Now().Format("2006-01-02 15:04:05"),
+		HarnessVersion:          results.Harness.Version,
+		HarnessCommit:           results.Harness.Commit,
+		HarnessDate:             results.Harness.Date,
+	}
+
+	// The execution provider belongs with the hardware it ran on, so it is listed there too
+	if results.ExecutionProvider != "" {
+		data.ExecutionProvider = results.ExecutionProvider
+		if data.HardwareSpecs == nil {
+			data.HardwareSpecs = make(map[string]string)
+		}
+		source := "reported by Core"
+		if results.ExecutionProviderSource != release.ProviderSourceCore {
+			source = "from Core's log"
+		}
+		data.HardwareSpecs["Execution Provider"] = fmt.Sprintf("%s (%s)", results.ExecutionProvider, source)
+	}
+
+	// Older results have no gate recorded; they were held to 100%
+	data.MinSuccessRate = results.MinSuccessRate
+	data.MinPassingModels = results.MinPassingModels
+	data.SuccessCriteria = results.SuccessCriteria
+	if results.MinSuccessRate > 0 || results.MinPassingModels > 0 || results.SuccessCriteria != "" {
+		data.GateMet = results.GateError() == nil
+	} else {
+		data.GateMet = results.SuccessRate >= 100.0
 	}
 
 	// Determine summary card class
-	if data.SuccessRate < 100.0 {
+	if !data.GateMet {
 		data.SummaryCardClass = "warning"
 	} else {
 		data.SummaryCardClass = "success"
 	}
 
 	// Build model metrics
-	testModels := getTestModels(cfg.TestAllModels)
+	// Prefer the matrix recorded with the results; older metrics.json files fall back to cfg.
+	// The run already failed on an invalid matrix, so an error here just means no rows
+	testModels := results.Models
+	if len(testModels) == 0 {
+		testModels, _ = test.ResolveModels(cfg)
+	}
+	data.PassingModels = test.PassingModels(results, testModels)
+	data.TotalModels = len(testModels)
 	data.RegistrationMetrics = buildRegistrationMetrics(results, testModels)
+	data.RegistrationConcurrency = results.Metrics.RegistrationConcurrency
+	data.UnregisterMetrics = buildUnregisterMetrics(results, testModels)
+	data.ReregisterMetrics = buildReregisterMetrics(results, testModels)
 	data.InferenceMetrics = buildInferenceMetrics(results, testModels)
+	data.FamilyMetrics = buildFamilyMetrics(results, testModels)
+	data.InferenceParallelism = results.Metrics.InferenceParallelism
+	data.BatchMetrics = buildBatchMetrics(results, testModels)
+	data.BatchSweep = buildBatchSweep(results, testModels)
+	data.TimeseriesMetrics = buildTimeseriesMetrics(results, testModels)
+	data.StreamingMetrics = buildStreamingMetrics(results, testModels)
+	data.GzipThresholdBytes = results.Metrics.GzipThresholdBytes
+	data.CompressedRequests = make(map[string]int)
+	for name, n := range results.Metrics.ModelCompressedRequests {
+		data.CompressedRequests[getDisplayName(name)] = n
+	}
+	data.FuzzMetrics = buildFuzzMetrics(results, testModels)
+	data.FuzzSeed = results.Metrics.FuzzSeed
+	data.DeterminismMetrics = buildDeterminismMetrics(results, testModels)
+	data.DeterminismTolerance = results.Metrics.DeterminismTolerance
+	data.ColdStartMetrics = buildColdStartMetrics(results, testModels)
+	data.BatchSize = results.Metrics.BatchSize
+
+	for _, restart := range results.CoreRestarts {
+		data.CoreRestarts = append(data.CoreRestarts, CoreRestartEvent{
+			Time:        restart.Time.Format("15:04:05"),
+			BeforeModel: getDisplayName(restart.BeforeModel),
+			Reason:      restart.Reason,
+			Succeeded:   restart.Succeeded,
+			Error:       restart.Error,
+		})
+	}
+
+	for _, retry := range results.PipelineRetries {
+		data.PipelineRetries = append(data.PipelineRetries, PipelineRetryEvent{
+			Time:  retry.Time.Format("15:04:05"),
+			Stage: retry.Stage,
+			Error: retry.Error,
+		})
+	}
+
+	data.Repetitions = len(results.Repetitions)
+	for _, repetition := range results.Repetitions {
+		if repetition.Error != "" {
+			data.AbortedRepetitions++
+		}
+	}
+	data.Flakiness = buildFlakinessMetrics(results)
+
+	for _, kind := range []string{test.ProfileCPU, test.ProfileHeap} {
+		if path, ok := results.CoreProfiles[kind]; ok {
+			data.CoreProfiles = append(data.CoreProfiles, ProfileFile{Kind: kind, Path: path, File: filepath.Base(path)})
+		}
+	}
+
+	data.CoreStdoutTail = results.CoreStdoutTail
+	data.CoreStderrTail = results.CoreStderrTail
+
+	for _, e := range results.Errors {
+		data.Errors = append(data.Errors, RunErrorMetric{
+			Stage:   e.Stage,
+			Model:   getDisplayName(e.Model),
+			Message: e.Message,
+		})
+	}
+
+	for _, f := range results.InjectedFailures {
+		data.InjectedFailures = append(data.InjectedFailures, InjectedFailureMetric{
+			Scenario: f.Scenario,
+			Detail:   f.Detail,
+			Expected: f.Expected,
+			Observed: f.Observed,
+			Status:   f.Status,
+		})
+	}
+
+	if replay := results.Replay; replay != nil {
+		data.Replay = &ReplayReport{
+			SessionPath:         replay.SessionPath,
+			RecordedCoreVersion: replay.RecordedCoreVersion,
+			Requests:            replay.Requests,
+			Matched:             replay.Matched,
+			Divergences:         []ReplayDivergenceMetric{},
+		}
+		for _, d := range replay.Divergences {
+			data.Replay.Divergences = append(data.Replay.Divergences, ReplayDivergenceMetric{
+				Model:   getDisplayName(d.Model),
+				Request: d.Request,
+				Path:    d.Path,
+				Details: d.Details,
+			})
+		}
+	}
+
+	for name, sizes := range results.Metrics.ModelInferenceSizes {
+		if len(sizes) < 2 {
+			if data.InferenceSizes == nil {
+				data.InferenceSizes = make(map[string][]string)
+			}
+			data.InferenceSizes[getDisplayName(name)] = sizes
+		}
+	}
+
+	data.DownloadStats = buildDownloadStats(results)
+	data.CoreInstances = buildInstanceReports(results, testModels, cfg)
+
+	for _, span := range results.Timeline {
+		data.Timeline = append(data.Timeline, TimelineSpan{
+			Name:       span.Name,
+			StartMs:    span.StartMs,
+			DurationMs: span.DurationMs,
+			Failed:     span.Failed,
+		})
+	}
+
+	for _, name := range results.InstallOnlyModels {
+		data.InstallOnlyModels = append(data.InstallOnlyModels, getDisplayName(name))
+	}
+
+	data.SkipReasons = make(map[string]string)
+	for name, reason := range results.Metrics.InferenceSkipReasons {
+		data.SkipReasons[getDisplayName(name)] = reason
+	}
+
+	data.ModelFormats = make(map[string]string)
+	for name, format := range results.Metrics.ModelFormat {
+		data.ModelFormats[getDisplayName(name)] = format
+	}
+
+	for _, spec := range testModels {
+		if mismatches, ok := results.Metrics.ModelMetadataMismatches[spec.Name]; ok {
+			if mismatches == nil {
+				mismatches = []string{}
+			}
+			data.MetadataChecks = append(data.MetadataChecks, MetadataCheckMetric{Name: getDisplayName(spec.Name), Mismatches: mismatches})
+		}
+	}
+
+	data.ModelManifest = make(map[string]model.ManifestEntry)
+	for name, entry := range results.Metrics.ModelManifest {
+		data.ModelManifest[getDisplayName(name)] = entry
+	}
+
+	data.InputSchemas = make(map[string][]model.InputSpec)
+	for name, schema := range results.ModelInputSchemas {
+		data.InputSchemas[getDisplayName(name)] = schema
+	}
+
+	for _, v := range config.RecognizedEnvVars {
+		if value, ok := results.Environment[v.Name]; ok {
+			data.Environment = append(data.Environment, EnvironmentVar{Name: v.Name, Value: value, Effect: v.Effect})
+		}
+	}
+
+	data.Prompt = results.Prompt
+	data.PromptTokens = make(map[string][]int)
+	for name, ids := range results.PromptTokens {
+		data.PromptTokens[getDisplayName(name)] = ids
+	}
+
+	data.ModelMemory = buildModelMemoryMetrics(results, testModels)
+	data.CoreBaselineMemoryMB = results.Metrics.CoreBaselineMemoryMB
+
+	if results.Metrics.LeakCheckIterations > 0 {
+		data.MemoryStability = &MemoryStability{
+			Iterations:    results.Metrics.LeakCheckIterations,
+			BeforeMB:      results.Metrics.MemoryBeforeMB,
+			AfterMB:       results.Metrics.MemoryAfterMB,
+			GrowthMB:      results.Metrics.MemoryGrowthMB,
+			LeakSuspected: results.Metrics.MemoryLeakSuspected,
+		}
+	}
 
 	// Calculate totals
 	for _, m := range data.RegistrationMetrics {
@@ -106,10 +672,72 @@ func PrepareData(results *test.Results, cfg *config.Config) *ReportData {
 
 	// Calculate category statuses
 	data.CategoryStatuses = calculateCategoryStatuses(results, testModels)
+	data.UnmetCategories = results.UnmetCategories
+	if len(data.UnmetCategories) > 0 {
+		data.SummaryCardClass = "warning"
+	}
+
+	data.Throttled = results.Throttled
+	data.ThermalDetails = results.ThermalDetails
+	data.MaxTemperatureC = results.MaxTemperatureC
 
 	return data
 }
 
+// buildInstanceReports lines up instance 0 (the main run) with each additional instance
+func buildInstanceReports(results *test.Results, models []test.ModelSpec, cfg *config.Config) []InstanceReport {
+	if len(results.CoreInstances) == 0 {
+		return nil
+	}
+
+	column := func(label, url, instanceErr string, times map[string]int64, statuses, errs map[string]string) InstanceReport {
+		report := InstanceReport{Label: label, URL: url, Error: instanceErr}
+		for _, spec := range models {
+			status, ok := statuses[spec.Name]
+			if !ok {
+				continue
+			}
+			report.Results = append(report.Results, InstanceResult{
+				Name:   getDisplayName(spec.Name),
+				Status: status,
+				TimeMs: times[spec.Name],
+				Error:  errs[spec.Name],
+			})
+		}
+		return report
+	}
+
+	reports := []InstanceReport{column("Instance 0 (main)", cfg.CoreURL(), "",
+		results.Metrics.ModelInferenceTimes, results.Metrics.ModelInferenceStatus, results.Metrics.ModelInferenceErrors)}
+	for _, instance := range results.CoreInstances {
+		reports = append(reports, column(fmt.Sprintf("Instance %d", instance.Index), instance.URL, instance.Error,
+			instance.ModelInferenceTimes, instance.ModelInferenceStatus, instance.ModelInferenceErrors))
+	}
+	return reports
+}
+
+func buildDownloadStats(results *test.Results) []DownloadStat {
+	labels := []struct{ component, label string }{
+		{test.ComponentAxon, "Axon"},
+		{test.ComponentCore, "Core"},
+		{test.ComponentONNXRuntime, "ONNX Runtime"},
+		{test.ComponentConverter, "Converter Image"},
+	}
+	var stats []DownloadStat
+	for _, l := range labels {
+		bytes, ok := results.Metrics.DownloadBytes[l.component]
+		if !ok {
+			continue // Cached or not needed in this run
+		}
+		stats = append(stats, DownloadStat{
+			Component: l.label,
+			Bytes:     bytes,
+			MBps:      results.Metrics.DownloadThroughputMBs[l.component],
+		})
+	}
+	return stats
+}
+
 func buildRegistrationMetrics(results *test.Results, models []test.ModelSpec) []ModelMetric {
 	var metrics []ModelMetric
 	for _, spec := range models {
@@ -124,7 +752,62 @@ func buildRegistrationMetrics(results *test.Results, models []test.ModelSpec) []
 				StatusText: "✅ Success",
 				Type:       "registration",
 			})
+		} else if errMsg, failed := results.Metrics.ModelRegistrationErrors[spec.Name]; failed {
+			metrics = append(metrics, ModelMetric{
+				Name:       getDisplayName(spec.Name),
+				Status:     "failed",
+				StatusText: "❌ Failed",
+				Type:       "registration",
+				Error:      errMsg,
+			})
+		}
+	}
+	return metrics
+}
+
+func buildUnregisterMetrics(results *test.Results, models []test.ModelSpec) []ModelMetric {
+	var metrics []ModelMetric
+	for _, spec := range models {
+		status, ok := results.Metrics.ModelUnregisterStatus[spec.Name]
+		if !ok {
+			continue
+		}
+		statusText := "✅ Removed"
+		if status != "success" {
+			statusText = "❌ Failed"
+		}
+		metrics = append(metrics, ModelMetric{
+			Name:       getDisplayName(spec.Name),
+			Status:     status,
+			StatusText: statusText,
+			Type:       "unregistration",
+			Error:      results.Metrics.ModelUnregisterErrors[spec.Name],
+		})
+	}
+	return metrics
+}
+
+func buildReregisterMetrics(results *test.Results, models []test.ModelSpec) []ModelMetric {
+	var metrics []ModelMetric
+	for _, spec := range models {
+		status, ok := results.Metrics.ModelReregisterStatus[spec.Name]
+		if !ok {
+			continue
+		}
+		statusText := "✅ No-op"
+		switch {
+		case status != "success":
+			statusText = "❌ Failed"
+		case results.Metrics.ModelReregisterBehavior[spec.Name] == test.ReregisterAlreadyRegistered:
+			statusText = "✅ Already registered"
 		}
+		metrics = append(metrics, ModelMetric{
+			Name:       getDisplayName(spec.Name),
+			Status:     status,
+			StatusText: statusText,
+			Type:       "reregistration",
+			Error:      results.Metrics.ModelReregisterErrors[spec.Name],
+		})
 	}
 	return metrics
 }
@@ -136,37 +819,317 @@ func buildInferenceMetrics(results *test.Results, models []test.ModelSpec) []Mod
 			continue
 		}
 
-		// Small inference
-		if time, ok := results.Metrics.ModelInferenceTimes[spec.Name]; ok && time > 0 {
-			status := results.Metrics.ModelInferenceStatus[spec.Name]
-			statusText := "✅ Success"
-			if status != "success" {
-				statusText = "❌ Failed"
-			}
-			metrics = append(metrics, ModelMetric{
-				Name:       getDisplayName(spec.Name),
-				Value:      time,
-				Status:     status,
-				StatusText: statusText,
-				Type:       "inference-small",
-			})
+		// Small inference (failed runs have no time but carry the error from Core)
+		if status, ok := results.Metrics.ModelInferenceStatus[spec.Name]; ok {
+			metric := newInferenceMetric(spec.Name, "inference-small", status,
+				results.Metrics.ModelInferenceTimes[spec.Name], results.Metrics.ModelInferenceErrors[spec.Name])
+			metric.Budget = results.Metrics.ModelInferenceBudgetMs[spec.Name]
+			metric.Bytes = results.Metrics.ModelResponseBytes[spec.Name]
+			metric.NormalizedMs = normalizedMs(results, metric)
+			metric.ExpectedStatus = results.Metrics.ModelExpectedStatus[spec.Name]
+			metric.ActualStatus = results.Metrics.ModelResponseStatus[spec.Name]
+			metrics = append(metrics, metric)
 		}
 
 		// Large inference
-		if time, ok := results.Metrics.ModelLargeInferenceTimes[spec.Name]; ok && time > 0 {
-			status := results.Metrics.ModelLargeInferenceStatus[spec.Name]
-			statusText := "✅ Success"
-			if status != "success" {
-				statusText = "❌ Failed"
+		if status, ok := results.Metrics.ModelLargeInferenceStatus[spec.Name]; ok {
+			metric := newInferenceMetric(spec.Name, "inference-large", status,
+				results.Metrics.ModelLargeInferenceTimes[spec.Name], results.Metrics.ModelLargeInferenceErrors[spec.Name])
+			metric.Budget = results.Metrics.ModelLargeInferenceBudgetMs[spec.Name]
+			metric.Bytes = results.Metrics.ModelLargeResponseBytes[spec.Name]
+			metric.NormalizedMs = normalizedMs(results, metric)
+			metric.ExpectedStatus = results.Metrics.ModelExpectedStatus[spec.Name]
+			metric.ActualStatus = results.Metrics.ModelLargeResponseStatus[spec.Name]
+			metrics = append(metrics, metric)
+		}
+	}
+	return metrics
+}
+
+// normalizedMs returns what a passing inference's latency would be on the reference machine
+// A machine twice as fast (index 2.0) would have taken twice as long there. 0 without a performance index.
+func normalizedMs(results *test.Results, metric ModelMetric) float64 {
+	if results.PerformanceIndex <= 0 || metric.Status != "success" {
+		return 0
+	}
+	return float64(metric.Value) * results.PerformanceIndex
+}
+
+func newInferenceMetric(name, metricType, status string, timeMs int64, errMsg string) ModelMetric {
+	statusText := "✅ Success"
+	if status != "success" {
+		statusText = "❌ Failed"
+	}
+	return ModelMetric{
+		Name:       getDisplayName(name),
+		Value:      timeMs,
+		Status:     status,
+		StatusText: statusText,
+		Type:       metricType,
+		Error:      errMsg,
+	}
+}
+
+func buildFuzzMetrics(results *test.Results, models []test.ModelSpec) []FuzzMetric {
+	var metrics []FuzzMetric
+	for _, spec := range models {
+		requests, ok := results.Metrics.ModelFuzzRequests[spec.Name]
+		if !ok {
+			continue
+		}
+		metrics = append(metrics, FuzzMetric{
+			Name:       getDisplayName(spec.Name),
+			Requests:   requests,
+			Failures:   results.Metrics.ModelFuzzFailures[spec.Name],
+			FirstError: results.Metrics.ModelFuzzErrors[spec.Name],
+		})
+	}
+	return metrics
+}
+
+func buildDeterminismMetrics(results *test.Results, models []test.ModelSpec) []DeterminismMetric {
+	var metrics []DeterminismMetric
+	for _, spec := range models {
+		status, ok := results.Metrics.ModelDeterminismStatus[spec.Name]
+		if !ok {
+			continue
+		}
+		metrics = append(metrics, DeterminismMetric{
+			Name:    getDisplayName(spec.Name),
+			Status:  status,
+			Details: results.Metrics.ModelDeterminismDetails[spec.Name],
+		})
+	}
+	return metrics
+}
+
+func buildBatchMetrics(results *test.Results, models []test.ModelSpec) []BatchMetric {
+	var metrics []BatchMetric
+	for _, spec := range models {
+		status, ok := results.Metrics.ModelBatchInferenceStatus[spec.Name]
+		if !ok {
+			continue
+		}
+		statusText := "✅ Success"
+		if status != "success" {
+			statusText = "❌ Failed"
+		}
+		metrics = append(metrics, BatchMetric{
+			Name:       getDisplayName(spec.Name),
+			Value:      results.Metrics.ModelBatchInferenceTimes[spec.Name],
+			Throughput: results.Metrics.ModelBatchThroughput[spec.Name],
+			Status:     status,
+			StatusText: statusText,
+		})
+	}
+	return metrics
+}
+
+func buildBatchSweep(results *test.Results, models []test.ModelSpec) []BatchSweepMetric {
+	var metrics []BatchSweepMetric
+	for _, spec := range models {
+		points, ok := results.Metrics.ModelBatchSweep[spec.Name]
+		if !ok {
+			continue
+		}
+		efficiency := results.Metrics.ModelBatchScaling[spec.Name]
+		metric := BatchSweepMetric{
+			Name:       getDisplayName(spec.Name),
+			Efficiency: efficiency,
+			SubLinear:  efficiency > 0 && efficiency < test.SubLinearScalingThreshold,
+		}
+		for _, p := range points {
+			metric.Points = append(metric.Points, BatchSweepPoint{BatchSize: p.BatchSize, Throughput: p.Throughput})
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+func buildTimeseriesMetrics(results *test.Results, models []test.ModelSpec) []TimeseriesMetric {
+	var metrics []TimeseriesMetric
+	for _, spec := range models {
+		latencies, ok := results.Metrics.ModelLatencyTimeseries[spec.Name]
+		if !ok {
+			continue
+		}
+		metric := TimeseriesMetric{Name: getDisplayName(spec.Name), LatenciesMs: latencies}
+		var succeeded []float64
+		for _, ms := range latencies {
+			if ms < 0 {
+				metric.Failures++
+				continue
+			}
+			succeeded = append(succeeded, ms)
+		}
+		if len(succeeded) > 0 {
+			metric.FirstMs = succeeded[0]
+			_, metric.SteadyMs = meanAndMedian(succeeded[len(succeeded)/2:])
+		}
+		// The first quarter is left out of the drift as warmup; a steady climb after it suggests a leak
+		if quarter := len(succeeded) / 4; quarter > 0 {
+			before, _ := meanAndMedian(succeeded[quarter : 2*quarter])
+			after, _ := meanAndMedian(succeeded[len(succeeded)-quarter:])
+			if before > 0 {
+				metric.DriftPct = (after - before) / before * 100
+				metric.Drifting = metric.DriftPct > timeseriesDriftThresholdPct
 			}
-			metrics = append(metrics, ModelMetric{
-				Name:       getDisplayName(spec.Name),
-				Value:      time,
-				Status:     status,
-				StatusText: statusText,
-				Type:       "inference-large",
-			})
 		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+func buildStreamingMetrics(results *test.Results, models []test.ModelSpec) []StreamingMetric {
+	var metrics []StreamingMetric
+	for _, spec := range models {
+		result, ok := results.Metrics.ModelStreaming[spec.Name]
+		if !ok {
+			continue
+		}
+		metrics = append(metrics, StreamingMetric{
+			Name:               getDisplayName(spec.Name),
+			Status:             result.Status,
+			Error:              result.Error,
+			Tokens:             result.Tokens,
+			TimeToFirstTokenMs: result.TimeToFirstTokenMs,
+			MeanInterTokenMs:   result.MeanInterTokenMs,
+			MaxInterTokenMs:    result.MaxInterTokenMs,
+			TotalMs:            result.TotalMs,
+		})
+	}
+	return metrics
+}
+
+func buildModelMemoryMetrics(results *test.Results, models []test.ModelSpec) []ModelMemoryMetric {
+	var metrics []ModelMemoryMetric
+	for _, spec := range models {
+		memoryMB, ok := results.Metrics.ModelMemoryMB[spec.Name]
+		if !ok {
+			continue
+		}
+		metrics = append(metrics, ModelMemoryMetric{
+			Name:     getDisplayName(spec.Name),
+			MemoryMB: memoryMB,
+		})
+	}
+	return metrics
+}
+
+// buildFamilyMetrics aggregates models by family, in matrix order of each family's first model
+// Small latencies are compared because every size-unrestricted model runs that test.
+func buildFamilyMetrics(results *test.Results, models []test.ModelSpec) []FamilyMetric {
+	var order []string
+	members := make(map[string][]test.ModelSpec)
+	for _, spec := range models {
+		if spec.Family == "" {
+			continue
+		}
+		if _, seen := members[spec.Family]; !seen {
+			order = append(order, spec.Family)
+		}
+		members[spec.Family] = append(members[spec.Family], spec)
+	}
+
+	m := results.Metrics
+	var metrics []FamilyMetric
+	for _, family := range order {
+		specs := members[family]
+		if len(specs) < 2 {
+			continue
+		}
+		metric := FamilyMetric{Family: family}
+		var latencies []float64
+		for _, spec := range specs {
+			metric.Models = append(metric.Models, getDisplayName(spec.Name))
+			_, small := m.ModelInferenceStatus[spec.Name]
+			_, large := m.ModelLargeInferenceStatus[spec.Name]
+			if small || large {
+				metric.Tested++
+			}
+			if m.ModelInferenceStatus[spec.Name] == "success" {
+				latencies = append(latencies, float64(m.ModelInferenceTimes[spec.Name]))
+			}
+		}
+		metric.Passed = test.PassingModels(results, specs)
+		if metric.Tested > 0 {
+			metric.PassRate = float64(metric.Passed) / float64(metric.Tested) * 100
+		}
+		metric.MeanMs, metric.MedianMs = meanAndMedian(latencies)
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+// buildFlakinessMetrics converts the run's flakiness scores, keeping their order (highest score first)
+func buildFlakinessMetrics(results *test.Results) []FlakinessMetric {
+	var metrics []FlakinessMetric
+	for _, f := range results.Flakiness {
+		metric := FlakinessMetric{
+			Name:     getDisplayName(f.Model),
+			Runs:     f.Runs,
+			Failures: f.Failures,
+			Score:    f.Score,
+			Verdict:  "flaky",
+		}
+		switch f.Failures {
+		case 0:
+			metric.Verdict = "stable"
+		case f.Runs:
+			metric.Verdict = "broken"
+		}
+
+		modes := make([]string, 0, len(f.FailureModes))
+		for mode := range f.FailureModes {
+			modes = append(modes, mode)
+		}
+		sort.Slice(modes, func(i, j int) bool {
+			if f.FailureModes[modes[i]] != f.FailureModes[modes[j]] {
+				return f.FailureModes[modes[i]] > f.FailureModes[modes[j]]
+			}
+			return modes[i] < modes[j]
+		})
+		for _, mode := range modes {
+			metric.FailureModes = append(metric.FailureModes, fmt.Sprintf("%s (%d×)", mode, f.FailureModes[mode]))
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+// meanAndMedian returns 0, 0 for no values
+func meanAndMedian(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mid := len(sorted) / 2
+	median := sorted[mid]
+	if len(sorted)%2 == 0 {
+		median = (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sum / float64(len(sorted)), median
+}
+
+func buildColdStartMetrics(results *test.Results, models []test.ModelSpec) []ColdStartMetric {
+	var metrics []ColdStartMetric
+	for _, spec := range models {
+		cold, ok := results.Metrics.ModelColdInferenceTimes[spec.Name]
+		if !ok {
+			continue
+		}
+		warm := results.Metrics.ModelWarmInferenceTimes[spec.Name]
+		metrics = append(metrics, ColdStartMetric{
+			Name:      getDisplayName(spec.Name),
+			ColdMs:    cold,
+			WarmMs:    warm,
+			PenaltyMs: cold - warm,
+		})
 	}
 	return metrics
 }
@@ -177,6 +1140,10 @@ func buildChartData(metrics []ModelMetric) (template.JS, template.JS, template.J
 	colors := []string{}
 
 	for _, m := range metrics {
+		// Failed inferences have no meaningful time to chart
+		if m.Status != "success" {
+			continue
+		}
 		if m.Type == "inference-small" {
 			labels = append(labels, m.Name+" (small)")
 			data = append(data, m.Value)
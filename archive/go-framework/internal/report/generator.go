@@ -4,10 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/mlOS-foundation/system-test/internal/config"
+	"github.com/mlOS-foundation/system-test/internal/monitor"
 	"github.com/mlOS-foundation/system-test/internal/test"
 )
 
@@ -21,26 +24,52 @@ func NewGenerator(cfg *config.Config) *Generator {
 	return &Generator{cfg: cfg}
 }
 
-// Generate generates an HTML report from test results
+// Generate generates the report from test results in cfg.OutputFormat
+// It returns the report's path, or "stdout" with --output-stdout.
 func (g *Generator) Generate(results *test.Results) (string, error) {
-	// Load HTML template with custom delimiters to avoid JSX conflicts
-	tmpl, err := template.New("report").Delims("[[", "]]").Funcs(template.FuncMap{
-		"htmlSafe": func(s string) template.HTML {
-			return template.HTML(s)
-		},
-		"hasMetrics": func(metrics []ModelMetric) bool {
-			return len(metrics) > 0
-		},
-		"json": func(v interface{}) (template.JS, error) {
-			b, err := json.Marshal(v)
-			if err != nil {
-				return "", err
-			}
-			return template.JS(b), nil
-		},
-	}).Parse(reportTemplate)
+	switch g.cfg.OutputFormat {
+	case config.ReportFormatJSON:
+		return g.write(func(w io.Writer) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(results)
+		})
+	case config.ReportFormatMarkdown:
+		return g.write(func(w io.Writer) error {
+			return writeMarkdown(w, PrepareData(results, g.cfg))
+		})
+	}
+	return g.generateHTML(results)
+}
+
+// write writes a single-file report to cfg.ReportPath, or to stdout with --output-stdout
+func (g *Generator) write(render func(io.Writer) error) (string, error) {
+	if g.cfg.OutputStdout {
+		if err := render(g.cfg.ReportStdout); err != nil {
+			return "", fmt.Errorf("failed to write report to stdout: %w", err)
+		}
+		return "stdout", nil
+	}
+
+	file, err := os.Create(g.cfg.ReportPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create report file: %w", err)
+	}
+	if err := render(file); err != nil {
+		_ = file.Close()
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+	return g.cfg.ReportPath, nil
+}
+
+// generateHTML renders the report template next to its JavaScript
+func (g *Generator) generateHTML(results *test.Results) (string, error) {
+	tmpl, err := g.loadTemplate()
 	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
+		return "", err
 	}
 
 	// Prepare structured data
@@ -48,10 +77,9 @@ func (g *Generator) Generate(results *test.Results) (string, error) {
 
 	// Generate report
 	reportPath := g.cfg.ReportPath
-	reportDir := reportPath[:len(reportPath)-len("release-validation-report.html")]
 
 	// Copy JavaScript file to report directory
-	jsPath := reportDir + "report_app.js"
+	jsPath := filepath.Join(filepath.Dir(reportPath), "report_app.js")
 	if err := os.WriteFile(jsPath, reportAppJS, 0644); err != nil {
 		return "", fmt.Errorf("failed to write JavaScript file: %w", err)
 	}
@@ -72,6 +100,59 @@ func (g *Generator) Generate(results *test.Results) (string, error) {
 	return reportPath, nil
 }
 
+// GenerateFromMetrics regenerates the report from a metrics.json written by an earlier run
+// Nothing is rerun; the report is written to cfg.ReportPath (report --output in the CLI).
+func (g *Generator) GenerateFromMetrics(metricsPath string) (string, error) {
+	results, err := test.ReadMetrics(metricsPath)
+	if err != nil {
+		return "", err
+	}
+	return g.Generate(results)
+}
+
+// loadTemplate parses the user-provided report template if one is configured,
+// falling back to the embedded template otherwise
+func (g *Generator) loadTemplate() (*template.Template, error) {
+	source := reportTemplate
+	if g.cfg.ReportTemplatePath != "" {
+		custom, err := os.ReadFile(g.cfg.ReportTemplatePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report template: %w", err)
+		}
+		source = string(custom)
+	}
+
+	// Load HTML template with custom delimiters to avoid JSX conflicts
+	tmpl, err := template.New("report").Delims("[[", "]]").Funcs(template.FuncMap{
+		"htmlSafe": func(s string) template.HTML {
+			return template.HTML(s)
+		},
+		"hasMetrics": func(metrics []ModelMetric) bool {
+			return len(metrics) > 0
+		},
+		"json": func(v interface{}) (template.JS, error) {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			return template.JS(b), nil
+		},
+	}).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	// Validate a custom template against the ReportData shape up front, so a
+	// typo in a field name fails clearly instead of producing a half-written report
+	if g.cfg.ReportTemplatePath != "" {
+		if err := tmpl.Execute(io.Discard, &ReportData{}); err != nil {
+			return nil, fmt.Errorf("report template %s does not match ReportData: %w", g.cfg.ReportTemplatePath, err)
+		}
+	}
+
+	return tmpl, nil
+}
+
 func formatHardwareSpecs(specs map[string]string) map[string]string {
 	if specs == nil {
 		return nil
@@ -128,10 +209,12 @@ func formatResourceUsage(usage map[string]interface{}) map[string]interface{} {
 		if idleMap, ok := idleRaw.(map[string]interface{}); ok {
 			cpu, _ := idleMap["CPUPercent"].(float64)
 			mem, _ := idleMap["MemoryMB"].(float64)
-			formatted["Idle"] = map[string]float64{
+			entry := map[string]float64{
 				"CPU":    cpu,
 				"Memory": mem,
 			}
+			addGPUMemory(entry, idleMap)
+			formatted["Idle"] = entry
 		}
 	}
 
@@ -140,16 +223,29 @@ func formatResourceUsage(usage map[string]interface{}) map[string]interface{} {
 		if loadMap, ok := loadRaw.(map[string]interface{}); ok {
 			cpu, _ := loadMap["CPUPercent"].(float64)
 			mem, _ := loadMap["MemoryMB"].(float64)
-			formatted["UnderLoad"] = map[string]float64{
+			entry := map[string]float64{
 				"CPU":    cpu,
 				"Memory": mem,
 			}
+			addGPUMemory(entry, loadMap)
+			formatted["UnderLoad"] = entry
 		}
 	}
 
 	return formatted
 }
 
+// addGPUMemory adds Core's GPU memory to a formatted usage entry when a GPU was present
+// Without a GPU the entry has no GPU line at all; 0 MB means Core held none of it.
+func addGPUMemory(entry map[string]float64, usage map[string]interface{}) {
+	status, _ := usage["GPUStatus"].(string)
+	if status == "" || status == monitor.GPUNone {
+		return
+	}
+	gpu, _ := usage["GPUMemoryMB"].(float64)
+	entry["GPUMemory"] = gpu
+}
+
 func getDisplayName(modelName string) string {
 	names := map[string]string{
 		"gpt2":    "GPT-2",
@@ -167,32 +263,19 @@ func getDisplayName(modelName string) string {
 }
 
 func calculateCategoryStatuses(results *test.Results, models []test.ModelSpec) map[string]interface{} {
-	categories := map[string]int{"nlp": 0, "vision": 0, "multimodal": 0}
-	categoryPassed := map[string]int{"nlp": 0, "vision": 0, "multimodal": 0}
-	categoryTested := map[string]int{"nlp": 0, "vision": 0, "multimodal": 0}
-
-	// Count models that were actually tested (have inference results)
-	for _, spec := range models {
-		categories[spec.Category]++
-		// Check if model was tested (has inference status)
-		if _, hasStatus := results.Metrics.ModelInferenceStatus[spec.Name]; hasStatus {
-			categoryTested[spec.Category]++
-			status := results.Metrics.ModelInferenceStatus[spec.Name]
-			if status == "success" {
-				categoryPassed[spec.Category]++
-			}
-		}
-	}
-
 	statuses := make(map[string]interface{})
 
-	for cat := range categories {
+	for cat, count := range test.CountCategories(results, models) {
 		var status, statusClass string
-		total := categories[cat]
-		tested := categoryTested[cat]
-		passed := categoryPassed[cat]
+		total := count.Total
+		tested := count.Tested
+		passed := count.Passed
 
-		if tested == 0 {
+		if tested == 0 && count.InstallOnly > 0 {
+			// Installed on purpose without inference (--install-only-categories)
+			status = fmt.Sprintf("📦 Install Only (%d/%d)", count.InstallOnly, total)
+			statusClass = "ready"
+		} else if tested == 0 {
 			// No models in this category were tested
 			status = "⏸️ Not Tested"
 			statusClass = "ready"
@@ -225,22 +308,3 @@ func calculateCategoryStatuses(results *test.Results, models []test.ModelSpec) m
 
 	return statuses
 }
-
-func getTestModels(testAllModels bool) []test.ModelSpec {
-	models := []test.ModelSpec{
-		{ID: "hf/distilgpt2@latest", Name: "gpt2", Type: "single", Category: "nlp"},
-		{ID: "hf/bert-base-uncased@latest", Name: "bert", Type: "multi", Category: "nlp"},
-	}
-
-	if testAllModels {
-		models = append(models,
-			test.ModelSpec{ID: "hf/roberta-base@latest", Name: "roberta", Type: "multi", Category: "nlp"},
-			test.ModelSpec{ID: "hf/t5-small@latest", Name: "t5", Type: "multi", Category: "nlp"},
-			test.ModelSpec{ID: "hf/microsoft/resnet-50@latest", Name: "resnet", Type: "single", Category: "vision"},
-			test.ModelSpec{ID: "hf/timm/vgg16@latest", Name: "vgg", Type: "single", Category: "vision"},
-			test.ModelSpec{ID: "hf/openai/clip-vit-base-patch32@latest", Name: "clip", Type: "multi", Category: "multimodal"},
-		)
-	}
-
-	return models
-}
@@ -0,0 +1,166 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mlOS-foundation/system-test/internal/model"
+)
+
+// writeMarkdown renders the summary, model results and failures of the report as Markdown
+// It covers what a CI job summary or PR comment needs; charts and diagnostics stay in the HTML report.
+func writeMarkdown(w io.Writer, data *ReportData) error {
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, "# MLOS Release Validation Report\n\n")
+	fmt.Fprintf(out, "Axon %s · Core %s · %s\n\n", data.AxonVersion, data.CoreVersion, data.Timestamp)
+	if data.CoreBinary != "" {
+		fmt.Fprintf(out, "Core is a local build: `%s`\n\n", data.CoreBinary)
+	}
+
+	gate := "✅ Passed"
+	if !data.GateMet {
+		gate = "❌ Failed"
+	}
+	fmt.Fprintf(out, "## Summary\n\n")
+	fmt.Fprintf(out, "| Metric | Value |\n|---|---|\n")
+	fmt.Fprintf(out, "| Quality gate | %s |\n", gate)
+	if data.SuccessCriteria != "" {
+		fmt.Fprintf(out, "| Success criteria | `%s` |\n", markdownCell(data.SuccessCriteria))
+	}
+	fmt.Fprintf(out, "| Success rate | %.1f%% |\n", data.SuccessRate)
+	fmt.Fprintf(out, "| Inferences | %d of %d passed (%d skipped) |\n", data.SuccessfulInferences, data.TotalInferences, data.SkippedInferences)
+	fmt.Fprintf(out, "| Passing models | %d of %d |\n", data.PassingModels, data.TotalModels)
+	fmt.Fprintf(out, "| Models installed | %d |\n", data.ModelsInstalled)
+	fmt.Fprintf(out, "| Core startup | %d ms |\n", data.CoreStartupTime)
+	if data.ExecutionProvider != "" {
+		fmt.Fprintf(out, "| Execution provider | %s |\n", data.ExecutionProvider)
+	}
+	fmt.Fprintf(out, "| Duration | %.1f s |\n\n", data.TotalDuration)
+
+	if len(data.InferenceMetrics) > 0 {
+		fmt.Fprintf(out, "## Inference\n\n")
+		if data.InferencePath != "" && data.InferencePath != model.DefaultInferencePathTemplate {
+			fmt.Fprintf(out, "Requests went to `%s`.\n\n", data.InferencePath)
+		}
+		if data.PerformanceIndex > 0 {
+			fmt.Fprintf(out, "Performance index %.2f; normalized latencies are scaled to a machine scoring 1.0.\n\n", data.PerformanceIndex)
+		}
+		fmt.Fprintf(out, "| Model | Test | Status | Latency (ms) | Normalized (ms) | Response (bytes) | Error |\n|---|---|---|---:|---:|---:|---|\n")
+		for _, m := range data.InferenceMetrics {
+			normalized := "-"
+			if m.NormalizedMs > 0 {
+				normalized = fmt.Sprintf("%.0f", m.NormalizedMs)
+			}
+			status := m.Status
+			if m.ExpectedStatus > 0 {
+				status += fmt.Sprintf(" (expected HTTP %d, got %d)", m.ExpectedStatus, m.ActualStatus)
+			}
+			fmt.Fprintf(out, "| %s | %s | %s | %d | %s | %d | %s |\n",
+				markdownCell(m.Name), strings.TrimPrefix(m.Type, "inference-"), status, m.Value, normalized, m.Bytes, markdownCell(m.Error))
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(data.FamilyMetrics) > 0 {
+		fmt.Fprintf(out, "## Model Families\n\n")
+		fmt.Fprintf(out, "| Family | Models | Passed | Mean small (ms) | Median small (ms) |\n|---|---|---|---:|---:|\n")
+		for _, f := range data.FamilyMetrics {
+			fmt.Fprintf(out, "| %s | %s | %d of %d (%.0f%%) | %.0f | %.0f |\n",
+				markdownCell(f.Family), markdownCell(strings.Join(f.Models, ", ")), f.Passed, f.Tested, f.PassRate, f.MeanMs, f.MedianMs)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(data.Flakiness) > 0 {
+		fmt.Fprintf(out, "## Flakiness\n\n")
+		fmt.Fprintf(out, "Across %d repetitions", data.Repetitions)
+		if data.AbortedRepetitions > 0 {
+			fmt.Fprintf(out, " (%d aborted before inference and counted against no model)", data.AbortedRepetitions)
+		}
+		fmt.Fprintf(out, ".\n\n")
+		fmt.Fprintf(out, "| Model | Score | Verdict | Failed | Failure modes |\n|---|---:|---|---|---|\n")
+		for _, f := range data.Flakiness {
+			fmt.Fprintf(out, "| %s | %.2f | %s | %d of %d | %s |\n",
+				markdownCell(f.Name), f.Score, f.Verdict, f.Failures, f.Runs, markdownCell(strings.Join(f.FailureModes, "; ")))
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(data.TimeseriesMetrics) > 0 {
+		fmt.Fprintf(out, "## Latency Over Time\n\n")
+		fmt.Fprintf(out, "Drift compares the mean of the last quarter of requests with the second quarter's.\n\n")
+		fmt.Fprintf(out, "| Model | Requests | Failed | First (ms) | Steady (ms) | Drift |\n|---|---:|---:|---:|---:|---:|\n")
+		for _, t := range data.TimeseriesMetrics {
+			drift := fmt.Sprintf("%+.1f%%", t.DriftPct)
+			if t.Drifting {
+				drift += " ⚠️"
+			}
+			fmt.Fprintf(out, "| %s | %d | %d | %.1f | %.1f | %s |\n", markdownCell(t.Name), len(t.LatenciesMs), t.Failures, t.FirstMs, t.SteadyMs, drift)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(data.DeterminismMetrics) > 0 {
+		fmt.Fprintf(out, "## Determinism\n\n")
+		fmt.Fprintf(out, "Each model's small input was sent twice; numbers in the outputs may differ by %g.\n\n", data.DeterminismTolerance)
+		fmt.Fprintf(out, "| Model | Status | Details |\n|---|---|---|\n")
+		for _, d := range data.DeterminismMetrics {
+			fmt.Fprintf(out, "| %s | %s | %s |\n", markdownCell(d.Name), d.Status, markdownCell(strings.Join(d.Details, "; ")))
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(data.RegistrationMetrics) > 0 {
+		fmt.Fprintf(out, "## Registration\n\n")
+		if data.RegistrationConcurrency > 1 {
+			fmt.Fprintf(out, "Up to %d models registered concurrently; times include contention between them.\n\n", data.RegistrationConcurrency)
+		}
+		fmt.Fprintf(out, "| Model | Status | Time (ms) | Error |\n|---|---|---:|---|\n")
+		for _, m := range data.RegistrationMetrics {
+			fmt.Fprintf(out, "| %s | %s | %d | %s |\n", markdownCell(m.Name), m.Status, m.Value, markdownCell(m.Error))
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(data.SkipReasons) > 0 {
+		names := make([]string, 0, len(data.SkipReasons))
+		for name := range data.SkipReasons {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(out, "## Skipped Models\n\n")
+		for _, name := range names {
+			fmt.Fprintf(out, "- **%s**: %s\n", name, data.SkipReasons[name])
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(data.CoreProfiles) > 0 {
+		fmt.Fprintf(out, "## Core Profiles\n\n")
+		for _, p := range data.CoreProfiles {
+			fmt.Fprintf(out, "- %s: `%s`\n", p.Kind, p.Path)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(data.Errors) > 0 {
+		fmt.Fprintf(out, "## Failures\n\n")
+		fmt.Fprintf(out, "| Stage | Model | Message |\n|---|---|---|\n")
+		for _, e := range data.Errors {
+			fmt.Fprintf(out, "| %s | %s | %s |\n", e.Stage, markdownCell(e.Model), markdownCell(e.Message))
+		}
+		fmt.Fprintln(out)
+	}
+
+	return out.Flush()
+}
+
+// markdownCell keeps a value on one table row and out of the table's column syntax
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
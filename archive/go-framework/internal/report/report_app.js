@@ -170,21 +170,24 @@ function App() {
     // Ensure we have valid data
     const axonTime = Math.max(reportData.axonDownloadTime || 0, 1);
     const coreDownloadTime = Math.max(reportData.coreDownloadTime || 0, 1);
+    const onnxRuntimeDownloadTime = Math.max(reportData.onnxRuntimeDownloadTime || 0, 1);
     const coreStartupTime = Math.max(reportData.coreStartupTime || 0, 1);
     
     const installationChartData = {
-        labels: ['Axon Download', 'Core Download', 'Core Startup'],
+        labels: ['Axon Download', 'Core Download', 'ONNX Runtime Download', 'Core Startup'],
         datasets: [{
             label: 'Time (ms)',
-            data: [axonTime, coreDownloadTime, coreStartupTime],
+            data: [axonTime, coreDownloadTime, onnxRuntimeDownloadTime, coreStartupTime],
             backgroundColor: [
                 'rgba(102, 126, 234, 0.8)',
                 'rgba(118, 75, 162, 0.8)',
+                'rgba(240, 147, 251, 0.8)',
                 'rgba(17, 153, 142, 0.8)'
             ],
             borderColor: [
                 'rgb(102, 126, 234)',
                 'rgb(118, 75, 162)',
+                'rgb(240, 147, 251)',
                 'rgb(17, 153, 142)'
             ],
             borderWidth: 2
@@ -253,7 +256,50 @@ function App() {
     
     console.log('Breakdown chart data:', breakdownChartData);
     
-    const cardClass = 'summary-card ' + (reportData.successRate === 100 ? 'success' : 'warning');
+    // Floating bars [start, end] give a Gantt-style view of where wall-clock went
+    const timeline = reportData.timeline || [];
+    const timelineChartData = {
+        labels: timeline.map(span => span.name),
+        datasets: [{
+            label: 'Stage (ms since start)',
+            data: timeline.map(span => [span.startMs, span.startMs + Math.max(span.durationMs, 1)]),
+            backgroundColor: timeline.map(span => span.failed ? 'rgba(239, 68, 68, 0.8)' : 'rgba(102, 126, 234, 0.8)'),
+            borderWidth: 1
+        }]
+    };
+    
+    // One line per model; failed batch sizes leave a gap
+    const batchSweep = reportData.batchSweep || [];
+    const sweepColors = ['rgb(102, 126, 234)', 'rgb(118, 75, 162)', 'rgb(240, 147, 251)', 'rgb(17, 153, 142)', 'rgb(245, 158, 11)'];
+    const batchSweepChartData = {
+        labels: batchSweep.length > 0 ? batchSweep[0].points.map(p => 'x' + p.batchSize) : [],
+        datasets: batchSweep.map((metric, idx) => ({
+            label: metric.name,
+            data: metric.points.map(p => p.throughput > 0 ? p.throughput : null),
+            borderColor: sweepColors[idx % sweepColors.length],
+            backgroundColor: sweepColors[idx % sweepColors.length],
+            spanGaps: false,
+            tension: 0.2
+        }))
+    };
+    
+    // One line per model across its rolling inference requests; failed requests leave a gap
+    const timeseries = reportData.timeseriesMetrics || [];
+    const timeseriesLength = Math.max(0, ...timeseries.map(m => m.latenciesMs.length));
+    const timeseriesChartData = {
+        labels: Array.from({ length: timeseriesLength }, (_, i) => i + 1),
+        datasets: timeseries.map((metric, idx) => ({
+            label: metric.name,
+            data: metric.latenciesMs.map(ms => ms >= 0 ? ms : null),
+            borderColor: sweepColors[idx % sweepColors.length],
+            backgroundColor: sweepColors[idx % sweepColors.length],
+            spanGaps: false,
+            pointRadius: 0,
+            tension: 0.1
+        }))
+    };
+    
+    const cardClass = 'summary-card ' + (reportData.gateMet ? 'success' : 'warning');
     
     return React.createElement('div', { className: 'container' },
         React.createElement('div', { className: 'header' },
@@ -263,10 +309,41 @@ function App() {
                 'Generated: ', reportData.timestamp
             )
         ),
+        reportData.unmetCategories && reportData.unmetCategories.length > 0 ? (
+            React.createElement('div', {
+                style: { background: '#fee2e2', color: '#991b1b', padding: '20px 30px', fontWeight: 600, borderBottom: '1px solid #fca5a5' }
+            },
+                '❌ Coverage requirement not met: no models were tested in required categories: ',
+                reportData.unmetCategories.join(', ')
+            )
+        ) : null,
+        reportData.throttled ? (
+            React.createElement('div', {
+                style: { background: '#fef3c7', color: '#92400e', padding: '20px 30px', fontWeight: 600, borderBottom: '1px solid #fcd34d' }
+            },
+                '🌡️ Thermal throttling detected during the run; timings may not be comparable: ',
+                (reportData.thermalDetails || []).join('; '),
+                reportData.maxTemperatureC >= 0 ? ' (max ' + reportData.maxTemperatureC.toFixed(0) + ' °C)' : ''
+            )
+        ) : null,
         React.createElement('div', { className: 'summary' },
             React.createElement('div', { className: cardClass },
                 React.createElement('h3', null, 'Success Rate'),
-                React.createElement('div', { className: 'value' }, reportData.successRate.toFixed(1) + '%')
+                React.createElement('div', { className: 'value' }, reportData.successRate.toFixed(1) + '%'),
+                reportData.minSuccessRate > 0 && reportData.minSuccessRate < 100 ? (
+                    React.createElement('div', { style: { fontSize: '0.85em', marginTop: '6px' } }, 'gate: ≥ ' + reportData.minSuccessRate + '%')
+                ) : null,
+                reportData.successCriteria ? (
+                    React.createElement('div', { style: { fontSize: '0.85em', marginTop: '6px', wordBreak: 'break-word' } },
+                        'gate: ' + reportData.successCriteria + (reportData.gateMet ? ' ✓' : ' ✗'))
+                ) : null
+            ),
+            React.createElement('div', { className: 'summary-card' + (reportData.minPassingModels > 0 ? (reportData.passingModels >= reportData.minPassingModels ? ' success' : ' warning') : '') },
+                React.createElement('h3', null, 'Passing Models'),
+                React.createElement('div', { className: 'value' }, reportData.passingModels + '/' + reportData.totalModels),
+                reportData.minPassingModels > 0 ? (
+                    React.createElement('div', { style: { fontSize: '0.85em', marginTop: '6px' } }, 'gate: ≥ ' + reportData.minPassingModels)
+                ) : null
             ),
             React.createElement('div', { className: 'summary-card' },
                 React.createElement('h3', null, 'Total Duration'),
@@ -276,6 +353,10 @@ function App() {
                 React.createElement('h3', null, 'Inferences'),
                 React.createElement('div', { className: 'value' }, reportData.successfulInferences + '/' + reportData.totalInferences)
             ),
+            React.createElement('div', { className: 'summary-card' + (reportData.skippedInferences > 0 ? ' warning' : '') },
+                React.createElement('h3', null, 'Skipped'),
+                React.createElement('div', { className: 'value' }, reportData.skippedInferences || 0)
+            ),
             React.createElement('div', { className: 'summary-card' },
                 React.createElement('h3', null, 'Models Installed'),
                 React.createElement('div', { className: 'value' }, reportData.modelsInstalled)
@@ -286,9 +367,35 @@ function App() {
             ),
             React.createElement('div', { className: 'summary-card' },
                 React.createElement('h3', null, 'Core Version'),
-                React.createElement('div', { className: 'value', style: { fontSize: '1.2em' } }, reportData.coreVersion)
+                React.createElement('div', { className: 'value', style: { fontSize: '1.2em' } }, reportData.coreVersion),
+                reportData.coreBinary ? (
+                    React.createElement('div', { style: { fontSize: '0.8em', color: '#666', wordBreak: 'break-all' } },
+                        'Local build: ' + reportData.coreBinary)
+                ) : null
             )
         ),
+        reportData.errors && reportData.errors.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '❌ Failures'),
+                React.createElement(MetricFolder, {
+                    title: reportData.errors.length + ' failure' + (reportData.errors.length === 1 ? '' : 's') + ' during the run',
+                    icon: '🚨',
+                    defaultExpanded: true
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.errors.map((e, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item failed' },
+                                React.createElement('div', { className: 'metric-item-label' }, e.model ? e.model : 'Run'),
+                                React.createElement('div', { className: 'metric-item-value' },
+                                    React.createElement('span', { className: 'badge failed' }, e.stage)
+                                ),
+                                React.createElement('pre', { style: { marginTop: '8px', fontSize: '0.8em', color: '#991b1b', whiteSpace: 'pre-wrap', wordBreak: 'break-word' } }, e.message)
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
         React.createElement('div', { className: 'section' },
             React.createElement('h2', null, '📊 Installation & Setup Times'),
             React.createElement(MetricFolder, { title: 'Installation Metrics', icon: '⏱️', defaultExpanded: true },
@@ -322,15 +429,90 @@ function App() {
                         React.createElement('div', { className: 'metric-item-label' }, 'Core Download'),
                         React.createElement('div', { className: 'metric-item-value' }, reportData.coreDownloadTime + ' ms')
                     ),
+                    React.createElement('div', { className: 'metric-item' },
+                        React.createElement('div', { className: 'metric-item-label' }, 'ONNX Runtime Download'),
+                        React.createElement('div', { className: 'metric-item-value' }, reportData.onnxRuntimeDownloadTime + ' ms')
+                    ),
+                    React.createElement('div', { className: 'metric-item' },
+                        React.createElement('div', { className: 'metric-item-label' }, 'Download Phase (wall-clock)'),
+                        React.createElement('div', { className: 'metric-item-value' }, reportData.downloadWallTime + ' ms')
+                    ),
                     React.createElement('div', { className: 'metric-item' },
                         React.createElement('div', { className: 'metric-item-label' }, 'Core Startup'),
                         React.createElement('div', { className: 'metric-item-value' }, reportData.coreStartupTime + ' ms')
+                    ),
+                    reportData.healthCheckMode && React.createElement('div', { className: 'metric-item' },
+                        React.createElement('div', { className: 'metric-item-label' }, 'Core Health Check'),
+                        React.createElement('div', { className: 'metric-item-value' }, reportData.healthCheckMode),
+                        React.createElement('div', { className: 'metric-item-status' },
+                            reportData.healthCheckMode === 'strict' ? '/health returned 200 with the expected body' : 'any HTTP response counted as ready'
+                        )
+                    )
+                ),
+                reportData.downloadStats && reportData.downloadStats.length > 0 && React.createElement('div', { className: 'metric-grid', style: { marginTop: '20px' } },
+                    reportData.downloadStats.map((stat, idx) =>
+                        React.createElement('div', { key: idx, className: 'metric-item' },
+                            React.createElement('div', { className: 'metric-item-label' }, stat.component + ' Bandwidth'),
+                            React.createElement('div', { className: 'metric-item-value' }, stat.mbps.toFixed(2) + ' MB/s'),
+                            React.createElement('div', { className: 'metric-item-status' },
+                                (stat.bytes / (1024 * 1024)).toFixed(1) + ' MB downloaded'
+                            )
+                        )
                     )
                 )
-            )
+            ),
+            reportData.modelFormats && Object.keys(reportData.modelFormats).length > 0 ? (
+                React.createElement(MetricFolder, {
+                    title: 'Installed Model Formats',
+                    icon: Object.values(reportData.modelFormats).some(f => f !== 'onnx') ? '⚠️' : '📦',
+                    defaultExpanded: Object.values(reportData.modelFormats).some(f => f !== 'onnx')
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        Object.entries(reportData.modelFormats).map(([name, format]) =>
+                            React.createElement('div', { key: name, className: 'metric-item ' + (format === 'onnx' ? 'success' : 'failed') },
+                                React.createElement('div', { className: 'metric-item-label' }, name),
+                                React.createElement('div', { className: 'metric-item-value' },
+                                    React.createElement('span', { className: 'badge ' + (format === 'onnx' ? 'success' : 'failed') }, format)
+                                ),
+                                format !== 'onnx' ? (
+                                    React.createElement('div', { className: 'metric-item-status' }, 'ONNX conversion fell back; Core requires ONNX')
+                                ) : null
+                            )
+                        )
+                    )
+                )
+            ) : null,
+            reportData.metadataChecks && reportData.metadataChecks.length > 0 ? (
+                React.createElement(MetricFolder, {
+                    title: 'Model Metadata (' + reportData.metadataChecks.filter(c => c.mismatches.length === 0).length + '/' + reportData.metadataChecks.length + ' as expected)',
+                    icon: reportData.metadataChecks.some(c => c.mismatches.length > 0) ? '⚠️' : '🏷️',
+                    defaultExpanded: reportData.metadataChecks.some(c => c.mismatches.length > 0)
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.metadataChecks.map(check =>
+                            React.createElement('div', { key: check.name, className: 'metric-item ' + (check.mismatches.length === 0 ? 'success' : 'failed') },
+                                React.createElement('div', { className: 'metric-item-label' }, check.name),
+                                React.createElement('div', { className: 'metric-item-value' },
+                                    React.createElement('span', { className: 'badge ' + (check.mismatches.length === 0 ? 'success' : 'failed') },
+                                        check.mismatches.length === 0 ? '✅ Matches' : '❌ ' + check.mismatches.length + ' mismatch(es)')
+                                ),
+                                check.mismatches.length > 0 ? (
+                                    React.createElement('pre', {
+                                        style: { marginTop: '8px', fontSize: '0.8em', color: '#991b1b', whiteSpace: 'pre-wrap', wordBreak: 'break-word' }
+                                    }, check.mismatches.join('\n'))
+                                ) : null
+                            )
+                        )
+                    )
+                )
+            ) : null
         ),
         React.createElement('div', { className: 'section' },
             React.createElement('h2', null, '📝 Model Registration'),
+            reportData.registrationConcurrency > 1 ? (
+                React.createElement('p', { style: { color: '#666', fontStyle: 'italic' } },
+                    'Up to ' + reportData.registrationConcurrency + ' models registered concurrently; times include contention between them.')
+            ) : null,
             reportData.registrationMetrics && reportData.registrationMetrics.length > 0 ? (
                 React.createElement(MetricFolder, {
                     title: 'Registered Models (' + reportData.registrationMetrics.length + ')',
@@ -340,10 +522,15 @@ function App() {
                         reportData.registrationMetrics.map((metric, idx) =>
                             React.createElement('div', { key: idx, className: 'metric-item ' + metric.status },
                                 React.createElement('div', { className: 'metric-item-label' }, metric.name + ' Registration'),
-                                React.createElement('div', { className: 'metric-item-value' }, metric.value + ' ms'),
+                                React.createElement('div', { className: 'metric-item-value' }, metric.status === 'success' ? metric.value + ' ms' : 'N/A'),
                                 React.createElement('div', { className: 'metric-item-status' },
                                     React.createElement('span', { className: 'badge ' + metric.status }, metric.statusText)
-                                )
+                                ),
+                                metric.error ? (
+                                    React.createElement('pre', {
+                                        style: { marginTop: '8px', fontSize: '0.8em', color: '#991b1b', whiteSpace: 'pre-wrap', wordBreak: 'break-word' }
+                                    }, metric.error)
+                                ) : null
                             )
                         )
                     )
@@ -352,8 +539,76 @@ function App() {
                 React.createElement('p', { style: { color: '#666', fontStyle: 'italic' } }, 'No registration metrics available')
             )
         ),
+        reportData.reregisterMetrics && reportData.reregisterMetrics.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🔁 Registration Idempotency'),
+                React.createElement(MetricFolder, {
+                    title: 'Re-registered Models (' + reportData.reregisterMetrics.length + ')',
+                    icon: reportData.reregisterMetrics.some(m => m.status !== 'success') ? '⚠️' : '✅',
+                    defaultExpanded: reportData.reregisterMetrics.some(m => m.status !== 'success')
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.reregisterMetrics.map((metric, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item ' + metric.status },
+                                React.createElement('div', { className: 'metric-item-label' }, metric.name + ' Re-registration'),
+                                React.createElement('div', { className: 'metric-item-status' },
+                                    React.createElement('span', { className: 'badge ' + metric.status }, metric.statusText)
+                                ),
+                                metric.error ? (
+                                    React.createElement('pre', {
+                                        style: { marginTop: '8px', fontSize: '0.8em', color: '#991b1b', whiteSpace: 'pre-wrap', wordBreak: 'break-word' }
+                                    }, metric.error)
+                                ) : null
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        reportData.unregisterMetrics && reportData.unregisterMetrics.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🧹 Model Unregistration'),
+                React.createElement(MetricFolder, {
+                    title: 'Unregistered Models (' + reportData.unregisterMetrics.length + ')',
+                    icon: reportData.unregisterMetrics.some(m => m.status !== 'success') ? '⚠️' : '✅',
+                    defaultExpanded: reportData.unregisterMetrics.some(m => m.status !== 'success')
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.unregisterMetrics.map((metric, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item ' + metric.status },
+                                React.createElement('div', { className: 'metric-item-label' }, metric.name + ' Unregistration'),
+                                React.createElement('div', { className: 'metric-item-status' },
+                                    React.createElement('span', { className: 'badge ' + metric.status }, metric.statusText)
+                                ),
+                                metric.error ? (
+                                    React.createElement('pre', {
+                                        style: { marginTop: '8px', fontSize: '0.8em', color: '#991b1b', whiteSpace: 'pre-wrap', wordBreak: 'break-word' }
+                                    }, metric.error)
+                                ) : null
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
         React.createElement('div', { className: 'section' },
             React.createElement('h2', null, '🧪 Inference Performance'),
+            reportData.apiStyle === 'openai' ? (
+                React.createElement('p', { style: { color: '#666', fontStyle: 'italic' } },
+                    'Small and large tests went through the OpenAI-compatible /v1/completions and /v1/chat/completions routes; each latency covers both requests.')
+            ) : null,
+            reportData.inferencePath && reportData.inferencePath !== '/models/{model}/inference' ? (
+                React.createElement('p', { style: { color: '#666', fontStyle: 'italic' } },
+                    'Inference requests went to ' + reportData.inferencePath + ' instead of Core\'s default /models/{model}/inference route.')
+            ) : null,
+            reportData.performanceIndex > 0 ? (
+                React.createElement('p', { style: { color: '#666', fontStyle: 'italic' } },
+                    'This machine scored ' + reportData.performanceIndex.toFixed(2) + ' on the matrix-multiply baseline; normalized timings are what passing tests would take on a machine scoring 1.0, so they compare across runner hardware.')
+            ) : null,
+            reportData.inferenceParallelism > 1 ? (
+                React.createElement('p', { style: { color: '#666', fontStyle: 'italic' } },
+                    'Up to ' + reportData.inferenceParallelism + ' inference tests ran concurrently; latencies include contention between them.')
+            ) : null,
             reportData.inferenceMetrics && reportData.inferenceMetrics.length > 0 ? (
                 React.createElement(React.Fragment, null,
                     React.createElement(MetricFolder, { title: 'Inference Chart', icon: '📈', defaultExpanded: true },
@@ -389,19 +644,580 @@ function App() {
                                     React.createElement('div', { className: 'metric-item-label' },
                                         metric.name + ' (' + (metric.type === 'inference-small' ? 'Small' : 'Large') + ')'
                                     ),
-                                    React.createElement('div', { className: 'metric-item-value' }, metric.value + ' ms'),
+                                    React.createElement('div', { className: 'metric-item-value' }, metric.value > 0 ? metric.value + ' ms' : '-'),
                                     React.createElement('div', { className: 'metric-item-status' },
-                                        React.createElement('span', { className: 'badge ' + metric.status }, metric.statusText)
-                                    )
+                                        React.createElement('span', { className: 'badge ' + metric.status }, metric.statusText),
+                                        metric.budget ? (
+                                            metric.value > metric.budget ?
+                                                React.createElement('span', { className: 'badge failed', style: { marginLeft: '6px' } },
+                                                    '⏱️ Over budget (' + metric.budget + ' ms)') :
+                                                React.createElement('span', { style: { marginLeft: '6px', color: '#666' } },
+                                                    'budget ' + metric.budget + ' ms')
+                                        ) : null,
+                                        metric.bytes ? (
+                                            React.createElement('span', { style: { marginLeft: '6px', color: '#666' } },
+                                                (metric.bytes < 1024 ? metric.bytes + ' B' : (metric.bytes / 1024).toFixed(1) + ' KiB') + ' response')
+                                        ) : null,
+                                        metric.normalizedMs ? (
+                                            React.createElement('span', { style: { marginLeft: '6px', color: '#666' } },
+                                                metric.normalizedMs.toFixed(0) + ' ms normalized')
+                                        ) : null,
+                                        metric.expectedStatus ? (
+                                            React.createElement('span', { style: { marginLeft: '6px', color: '#666' } },
+                                                'expected HTTP ' + metric.expectedStatus + ', got ' + (metric.actualStatus ? metric.actualStatus : 'no response'))
+                                        ) : null
+                                    ),
+                                    metric.error ? (
+                                        React.createElement('pre', {
+                                            style: { marginTop: '8px', fontSize: '0.8em', color: '#991b1b', whiteSpace: 'pre-wrap', wordBreak: 'break-word' }
+                                        }, metric.error)
+                                    ) : null
                                 )
                             )
                         )
-                    )
+                    ),
+                    reportData.familyMetrics && reportData.familyMetrics.length > 0 ? (
+                        React.createElement(MetricFolder, {
+                            title: 'Model Families (' + reportData.familyMetrics.length + ')',
+                            icon: '👪',
+                            defaultExpanded: true
+                        },
+                            React.createElement('div', { className: 'metric-grid' },
+                                reportData.familyMetrics.map((family, idx) => {
+                                    const status = family.tested === 0 ? 'ready' : (family.passed === family.tested ? 'success' : 'failed');
+                                    return React.createElement('div', { key: idx, className: 'metric-item ' + status },
+                                        React.createElement('div', { className: 'metric-item-label' },
+                                            family.family + ' (' + family.models.join(', ') + ')'
+                                        ),
+                                        React.createElement('div', { className: 'metric-item-value' },
+                                            family.meanMs > 0 ? family.meanMs.toFixed(0) + ' ms mean' : '-'
+                                        ),
+                                        React.createElement('div', { className: 'metric-item-status' },
+                                            family.meanMs > 0 ? 'median ' + family.medianMs.toFixed(0) + ' ms (small) ' : null,
+                                            React.createElement('span', { className: 'badge ' + status },
+                                                family.passed + '/' + family.tested + ' passed (' + family.passRate.toFixed(0) + '%)')
+                                        )
+                                    );
+                                })
+                            )
+                        )
+                    ) : null
                 )
             ) : (
                 React.createElement('p', { style: { color: '#666', fontStyle: 'italic' } }, 'No inference metrics available')
-            )
+            ),
+            reportData.inferenceSizes && Object.keys(reportData.inferenceSizes).length > 0 ? (
+                React.createElement('p', { style: { color: '#666', fontStyle: 'italic' } },
+                    'Size-limited models: ' + Object.entries(reportData.inferenceSizes)
+                        .map(([name, sizes]) => name + ' (' + sizes.join(', ') + ' only)').join(', '))
+            ) : null,
+            reportData.skipReasons && Object.keys(reportData.skipReasons).length > 0 ? (
+                React.createElement(MetricFolder, {
+                    title: 'Skipped Models (' + Object.keys(reportData.skipReasons).length + ')',
+                    icon: '⏭️',
+                    defaultExpanded: true
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        Object.entries(reportData.skipReasons).map(([name, reason]) =>
+                            React.createElement('div', { key: name, className: 'metric-item ready' },
+                                React.createElement('div', { className: 'metric-item-label' }, name),
+                                React.createElement('div', { className: 'metric-item-status' },
+                                    React.createElement('span', { className: 'badge ready' }, '⏸️ Skipped'), ' ', reason
+                                )
+                            )
+                        )
+                    )
+                )
+            ) : null,
+            reportData.installOnlyModels && reportData.installOnlyModels.length > 0 ? (
+                React.createElement(MetricFolder, {
+                    title: 'Install-Only Models (' + reportData.installOnlyModels.length + ')',
+                    icon: '📦',
+                    defaultExpanded: false
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.installOnlyModels.map(name =>
+                            React.createElement('div', { key: name, className: 'metric-item ready' },
+                                React.createElement('div', { className: 'metric-item-label' }, name),
+                                React.createElement('div', { className: 'metric-item-status' },
+                                    React.createElement('span', { className: 'badge ready' }, '📦 Install only'), ' ', 'installed and registered; inference not run by request'
+                                )
+                            )
+                        )
+                    )
+                )
+            ) : null
         ),
+        reportData.coldStartMetrics && reportData.coldStartMetrics.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🧊 Cold Start vs Warm'),
+                React.createElement(MetricFolder, {
+                    title: 'First Inference After Registration (' + reportData.coldStartMetrics.length + ' models)',
+                    icon: '⏱️',
+                    defaultExpanded: true
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.coldStartMetrics.map((metric, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item' },
+                                React.createElement('div', { className: 'metric-item-label' }, metric.name + ' cold-start penalty'),
+                                React.createElement('div', { className: 'metric-item-value' }, metric.penaltyMs + ' ms'),
+                                React.createElement('div', { className: 'metric-item-status' },
+                                    'Cold ' + metric.coldMs + ' ms / Warm ' + metric.warmMs + ' ms'
+                                )
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        reportData.batchMetrics && reportData.batchMetrics.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '📦 Batched Inference'),
+                React.createElement(MetricFolder, {
+                    title: 'Batch Size ' + reportData.batchSize + ' (' + reportData.batchMetrics.length + ' models)',
+                    icon: '🚚',
+                    defaultExpanded: true
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.batchMetrics.map((metric, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item ' + metric.status },
+                                React.createElement('div', { className: 'metric-item-label' }, metric.name + ' (x' + reportData.batchSize + ')'),
+                                React.createElement('div', { className: 'metric-item-value' },
+                                    metric.status === 'success' ? metric.throughput.toFixed(1) + ' ex/s' : '-'
+                                ),
+                                React.createElement('div', { className: 'metric-item-status' },
+                                    metric.status === 'success' ? metric.value + ' ms per batch ' : null,
+                                    React.createElement('span', { className: 'badge ' + metric.status }, metric.statusText)
+                                )
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        batchSweep.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '📈 Batch Size Scaling'),
+                React.createElement(MetricFolder, {
+                    title: 'Throughput across batch sizes (' + batchSweep.length + ' models)',
+                    icon: '📦',
+                    defaultExpanded: batchSweep.some(m => m.subLinear)
+                },
+                    React.createElement(ChartComponent, {
+                        type: 'line',
+                        data: batchSweepChartData,
+                        options: {
+                            plugins: {
+                                title: {
+                                    display: true,
+                                    text: 'Throughput vs Batch Size',
+                                    font: { size: 16, weight: 'bold' }
+                                }
+                            },
+                            scales: {
+                                y: {
+                                    beginAtZero: true,
+                                    title: { display: true, text: 'Examples / second' }
+                                }
+                            }
+                        },
+                        height: 400
+                    }),
+                    React.createElement('div', { className: 'metric-grid', style: { marginTop: '20px' } },
+                        batchSweep.map((metric, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item ' + (metric.subLinear ? 'failed' : 'success') },
+                                React.createElement('div', { className: 'metric-item-label' }, metric.name + ' scaling efficiency'),
+                                React.createElement('div', { className: 'metric-item-value' },
+                                    metric.efficiency > 0 ? (metric.efficiency * 100).toFixed(0) + '% of linear' : 'N/A'
+                                ),
+                                React.createElement('div', { className: 'metric-item-status' },
+                                    React.createElement('span', { className: 'badge ' + (metric.subLinear ? 'failed' : 'success') },
+                                        metric.efficiency === 0 ? 'Not enough data' : (metric.subLinear ? '⚠️ Sub-linear' : '✅ Near-linear')
+                                    )
+                                )
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        timeseries.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '📉 Latency Over Time'),
+                React.createElement(MetricFolder, {
+                    title: 'Rolling inference (' + timeseriesLength + ' requests per model)',
+                    icon: '⏱️',
+                    defaultExpanded: true
+                },
+                    React.createElement(ChartComponent, {
+                        type: 'line',
+                        data: timeseriesChartData,
+                        options: {
+                            plugins: {
+                                title: {
+                                    display: true,
+                                    text: 'Small Inference Latency per Request',
+                                    font: { size: 16, weight: 'bold' }
+                                }
+                            },
+                            scales: {
+                                x: { title: { display: true, text: 'Request' } },
+                                y: {
+                                    beginAtZero: true,
+                                    title: { display: true, text: 'Latency (ms)' }
+                                }
+                            }
+                        },
+                        height: 400
+                    }),
+                    React.createElement('div', { className: 'metric-grid', style: { marginTop: '20px' } },
+                        timeseries.map((metric, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item ' + (metric.failures > 0 || metric.drifting ? 'failed' : 'success') },
+                                React.createElement('div', { className: 'metric-item-label' }, metric.name),
+                                React.createElement('div', { className: 'metric-item-value' },
+                                    metric.steadyMs.toFixed(1) + ' ms steady (first ' + metric.firstMs.toFixed(1) + ' ms)'
+                                ),
+                                React.createElement('div', { className: 'metric-item-status' },
+                                    React.createElement('span', { className: 'badge ' + (metric.drifting ? 'failed' : 'success') },
+                                        (metric.driftPct >= 0 ? '+' : '') + metric.driftPct.toFixed(1) + '% drift'
+                                    ),
+                                    metric.failures > 0 ? (
+                                        React.createElement('span', { style: { marginLeft: '6px', color: '#666' } },
+                                            metric.failures + ' failed')
+                                    ) : null
+                                )
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        reportData.streamingMetrics && reportData.streamingMetrics.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🌊 Streaming Inference'),
+                React.createElement(MetricFolder, {
+                    title: 'Token timing of streamed responses (' + reportData.streamingMetrics.length + ' models)',
+                    icon: '⏱️',
+                    defaultExpanded: reportData.streamingMetrics.some(m => m.status === 'failed')
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.streamingMetrics.map((metric, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item ' + (metric.status === 'unsupported' ? '' : metric.status) },
+                                React.createElement('div', { className: 'metric-item-label' }, metric.name + ' time to first token'),
+                                React.createElement('div', { className: 'metric-item-value' },
+                                    metric.status === 'success' ? metric.timeToFirstTokenMs.toFixed(1) + ' ms' : '-'
+                                ),
+                                React.createElement('div', { className: 'metric-item-status' },
+                                    metric.status === 'success'
+                                        ? metric.tokens + ' tokens, ' + metric.meanInterTokenMs.toFixed(1) + ' ms between (max ' + metric.maxInterTokenMs.toFixed(1) + ' ms) '
+                                        : null,
+                                    React.createElement('span', { className: 'badge ' + metric.status },
+                                        metric.status === 'success' ? '✅ Streamed' : (metric.status === 'failed' ? '❌ Failed' : 'Not streamed'))
+                                ),
+                                metric.error ? (
+                                    React.createElement('pre', {
+                                        style: { marginTop: '8px', fontSize: '0.8em', color: '#991b1b', whiteSpace: 'pre-wrap', wordBreak: 'break-word' }
+                                    }, metric.error)
+                                ) : null
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        reportData.gzipThresholdBytes > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🗜️ Request Compression'),
+                React.createElement(MetricFolder, {
+                    title: 'Inference bodies of ' + (reportData.gzipThresholdBytes / 1024).toFixed(0) + ' KiB or more sent with Content-Encoding: gzip',
+                    icon: '📦'
+                },
+                    Object.keys(reportData.compressedRequests || {}).length > 0 ? (
+                        React.createElement('div', { className: 'metric-grid' },
+                            Object.entries(reportData.compressedRequests).map(([name, count]) =>
+                                React.createElement('div', { key: name, className: 'metric-item' },
+                                    React.createElement('div', { className: 'metric-item-label' }, name),
+                                    React.createElement('div', { className: 'metric-item-value' }, count + ' gzipped')
+                                )
+                            )
+                        )
+                    ) : (
+                        React.createElement('p', { style: { color: '#666', fontStyle: 'italic' } }, 'No request reached the threshold')
+                    )
+                )
+            )
+        ) : null,
+        reportData.fuzzMetrics && reportData.fuzzMetrics.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🎲 Fuzz Inference'),
+                React.createElement(MetricFolder, {
+                    title: 'Randomized Inputs (seed ' + reportData.fuzzSeed + ')',
+                    icon: '🧪',
+                    defaultExpanded: reportData.fuzzMetrics.some(m => m.failures > 0)
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.fuzzMetrics.map((metric, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item ' + (metric.failures > 0 ? 'failed' : 'success') },
+                                React.createElement('div', { className: 'metric-item-label' }, metric.name),
+                                React.createElement('div', { className: 'metric-item-value' }, metric.failures + ' / ' + metric.requests + ' failed'),
+                                React.createElement('div', { className: 'metric-item-status' },
+                                    React.createElement('span', { className: 'badge ' + (metric.failures > 0 ? 'failed' : 'success') },
+                                        metric.failures > 0 ? '❌ Crashes, 5xx or timeouts' : '✅ Robust')
+                                ),
+                                metric.firstError ? (
+                                    React.createElement('pre', {
+                                        style: { marginTop: '8px', fontSize: '0.8em', color: '#991b1b', whiteSpace: 'pre-wrap', wordBreak: 'break-word' }
+                                    }, metric.firstError)
+                                ) : null
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        reportData.determinismMetrics && reportData.determinismMetrics.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🔂 Output Determinism'),
+                React.createElement(MetricFolder, {
+                    title: 'Identical Input Sent Twice (' +
+                        (reportData.determinismTolerance > 0 ? 'tolerance ' + reportData.determinismTolerance : 'outputs must be identical') + ')',
+                    icon: '🔂',
+                    defaultExpanded: reportData.determinismMetrics.some(m => m.status !== 'deterministic')
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.determinismMetrics.map((metric, idx) => {
+                            const status = metric.status === 'deterministic' ? 'success' : 'failed';
+                            return React.createElement('div', { key: idx, className: 'metric-item ' + status },
+                                React.createElement('div', { className: 'metric-item-label' }, metric.name),
+                                React.createElement('div', { className: 'metric-item-status' },
+                                    React.createElement('span', { className: 'badge ' + status },
+                                        metric.status === 'deterministic' ? '✅ Deterministic' :
+                                            (metric.status === 'nondeterministic' ? '❌ Outputs differ' : '❌ Check failed'))
+                                ),
+                                metric.details && metric.details.length > 0 ? (
+                                    React.createElement('pre', {
+                                        style: { marginTop: '8px', fontSize: '0.8em', color: '#991b1b', whiteSpace: 'pre-wrap', wordBreak: 'break-word' }
+                                    }, metric.details.join('\n'))
+                                ) : null
+                            );
+                        })
+                    )
+                )
+            )
+        ) : null,
+        reportData.flakiness && reportData.flakiness.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🎲 Flakiness'),
+                React.createElement('p', { style: { color: '#666', fontStyle: 'italic' } },
+                    'Across ' + reportData.repetitions + ' repetitions' +
+                    (reportData.abortedRepetitions > 0 ? ' (' + reportData.abortedRepetitions + ' aborted before inference and counted against no model)' : '') +
+                    '. The score is the fraction of runs a model failed: flaky models fail some of them, broken ones all.'),
+                React.createElement(MetricFolder, {
+                    title: reportData.flakiness.filter(f => f.verdict === 'flaky').length + ' flaky, ' +
+                        reportData.flakiness.filter(f => f.verdict === 'broken').length + ' broken of ' + reportData.flakiness.length + ' models',
+                    icon: '🎲',
+                    defaultExpanded: true
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.flakiness.map((f, idx) => {
+                            const status = f.verdict === 'stable' ? 'success' : (f.verdict === 'broken' ? 'failed' : 'ready');
+                            return React.createElement('div', { key: idx, className: 'metric-item ' + status },
+                                React.createElement('div', { className: 'metric-item-label' }, f.name),
+                                React.createElement('div', { className: 'metric-item-value' }, f.score.toFixed(2)),
+                                React.createElement('div', { className: 'metric-item-status' },
+                                    React.createElement('span', { className: 'badge ' + status }, f.verdict),
+                                    ' failed ' + f.failures + ' of ' + f.runs
+                                ),
+                                f.failureModes && f.failureModes.length > 0 ? (
+                                    React.createElement('div', {
+                                        style: { marginTop: '8px', fontSize: '0.8em', color: '#991b1b', whiteSpace: 'pre-wrap', wordBreak: 'break-word' }
+                                    }, f.failureModes.join('\n'))
+                                ) : null
+                            );
+                        })
+                    )
+                )
+            )
+        ) : null,
+        reportData.coreRestarts && reportData.coreRestarts.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🔁 Core Restarts'),
+                React.createElement(MetricFolder, {
+                    title: reportData.coreRestarts.length + ' restart(s) by the watchdog',
+                    icon: '⚠️',
+                    defaultExpanded: true
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.coreRestarts.map((restart, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item ' + (restart.succeeded ? 'success' : 'failed') },
+                                React.createElement('div', { className: 'metric-item-label' }, restart.time + (restart.beforeModel ? ' before ' + restart.beforeModel : '')),
+                                React.createElement('div', { className: 'metric-item-value' }, restart.succeeded ? 'Restarted' : 'Restart failed'),
+                                React.createElement('div', { className: 'metric-item-status' }, restart.error || restart.reason)
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        reportData.pipelineRetries && reportData.pipelineRetries.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🔂 Pipeline Retries'),
+                React.createElement(MetricFolder, {
+                    title: reportData.pipelineRetries.length + ' earlier attempt(s) failed before inference',
+                    icon: '⚠️',
+                    defaultExpanded: false
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.pipelineRetries.map((retry, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item failed' },
+                                React.createElement('div', { className: 'metric-item-label' }, retry.time + ' in ' + retry.stage),
+                                React.createElement('div', { className: 'metric-item-status' }, retry.error)
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        reportData.coreProfiles && reportData.coreProfiles.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🔬 Core Profiles'),
+                React.createElement('p', { style: { color: '#666', fontStyle: 'italic' } },
+                    'Captured from /debug/pprof while Core was under sustained inference load. Open with go tool pprof -http=: <file>.'),
+                React.createElement('div', { className: 'metric-grid' },
+                    reportData.coreProfiles.map((profile, idx) =>
+                        React.createElement('div', { key: idx, className: 'metric-item success' },
+                            React.createElement('div', { className: 'metric-item-label' }, profile.kind === 'cpu' ? 'CPU profile' : 'Heap profile'),
+                            React.createElement('div', { className: 'metric-item-value' },
+                                React.createElement('a', { href: profile.file }, profile.file)),
+                            React.createElement('div', { className: 'metric-item-status', style: { wordBreak: 'break-all' } }, profile.path)
+                        )
+                    )
+                )
+            )
+        ) : null,
+        (reportData.coreStdoutTail && reportData.coreStdoutTail.length > 0) || (reportData.coreStderrTail && reportData.coreStderrTail.length > 0) ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '📋 Core Output'),
+                [['stdout', reportData.coreStdoutTail], ['stderr', reportData.coreStderrTail]]
+                    .filter(([, lines]) => lines && lines.length > 0)
+                    .map(([stream, lines]) =>
+                        React.createElement(MetricFolder, { key: stream, title: 'Core ' + stream + ' (last ' + lines.length + ' lines)', icon: '📄', defaultExpanded: false },
+                            React.createElement('pre', { style: { marginTop: '8px', fontSize: '0.8em', whiteSpace: 'pre-wrap', wordBreak: 'break-word' } }, lines.join('\n'))
+                        )
+                    )
+            )
+        ) : null,
+        reportData.injectedFailures && reportData.injectedFailures.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '💥 Failure Injection'),
+                React.createElement(MetricFolder, {
+                    title: reportData.injectedFailures.filter(f => f.status === 'success').length + '/' + reportData.injectedFailures.length + ' injected failures handled as expected',
+                    icon: '🧯',
+                    defaultExpanded: true
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.injectedFailures.map((f, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item ' + (f.status === 'skipped' ? '' : f.status) },
+                                React.createElement('div', { className: 'metric-item-label' }, f.scenario + (f.detail ? ' (' + f.detail + ')' : '')),
+                                React.createElement('div', { className: 'metric-item-value' },
+                                    React.createElement('span', { className: 'badge ' + (f.status === 'skipped' ? '' : f.status) }, f.status)
+                                ),
+                                React.createElement('div', { className: 'metric-item-status' }, 'Expected ' + f.expected),
+                                React.createElement('pre', { style: { marginTop: '8px', fontSize: '0.8em', color: f.status === 'failed' ? '#991b1b' : '#666', whiteSpace: 'pre-wrap', wordBreak: 'break-word' } }, f.observed)
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        reportData.replay ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '⏪ Replay Comparison'),
+                React.createElement(MetricFolder, {
+                    title: reportData.replay.matched + '/' + reportData.replay.requests + ' replayed responses match the recording (Core ' + reportData.replay.recordedCoreVersion + ')',
+                    icon: '📼',
+                    defaultExpanded: reportData.replay.divergences.length > 0
+                },
+                    React.createElement('div', { className: 'metric-item-status' }, 'Session: ' + reportData.replay.sessionPath),
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.replay.divergences.map((d, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item failed' },
+                                React.createElement('div', { className: 'metric-item-label' }, d.model + ' (request ' + d.request + ')'),
+                                React.createElement('div', { className: 'metric-item-status' }, d.path),
+                                React.createElement('pre', { style: { marginTop: '8px', fontSize: '0.8em', color: '#991b1b', whiteSpace: 'pre-wrap', wordBreak: 'break-word' } }, d.details.join('\n'))
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        reportData.coreInstances && reportData.coreInstances.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🧪 Core Instance Isolation'),
+                React.createElement(MetricFolder, {
+                    title: reportData.coreInstances.length + ' Core instances, side by side',
+                    icon: '🔀',
+                    defaultExpanded: true
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.coreInstances.map((instance, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item' + (instance.error ? ' failed' : '') },
+                                React.createElement('div', { className: 'metric-item-label' }, instance.label),
+                                React.createElement('div', { className: 'metric-item-status' }, instance.url),
+                                instance.error ? React.createElement('pre', {
+                                    style: { marginTop: '8px', fontSize: '0.8em', color: '#991b1b', whiteSpace: 'pre-wrap', wordBreak: 'break-word' }
+                                }, instance.error) : null,
+                                (instance.results || []).map((result, ridx) =>
+                                    React.createElement('div', { key: ridx, className: 'metric-item-status', title: result.error || '' },
+                                        React.createElement('span', { className: 'badge ' + result.status },
+                                            result.name + ': ' + (result.status === 'success' ? result.timeMs + ' ms' : 'failed')
+                                        )
+                                    )
+                                )
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        timeline.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🗓️ Pipeline Timeline'),
+                React.createElement(MetricFolder, { title: 'Stage Timeline', icon: '📏', defaultExpanded: true },
+                    React.createElement(ChartComponent, {
+                        type: 'bar',
+                        data: timelineChartData,
+                        options: {
+                            indexAxis: 'y',
+                            plugins: {
+                                legend: { display: false },
+                                title: {
+                                    display: true,
+                                    text: 'Wall-clock by Pipeline Stage',
+                                    font: { size: 16, weight: 'bold' }
+                                }
+                            },
+                            scales: {
+                                x: {
+                                    beginAtZero: true,
+                                    title: { display: true, text: 'Time since start (milliseconds)' }
+                                }
+                            }
+                        },
+                        height: Math.max(200, timeline.length * 40)
+                    }),
+                    React.createElement('div', { className: 'metric-grid', style: { marginTop: '20px' } },
+                        timeline.map((span, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item ' + (span.failed ? 'failed' : '') },
+                                React.createElement('div', { className: 'metric-item-label' }, span.name),
+                                React.createElement('div', { className: 'metric-item-value' }, span.durationMs + ' ms'),
+                                React.createElement('div', { className: 'metric-item-status' }, 'starts at ' + span.startMs + ' ms')
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
         React.createElement('div', { className: 'section' },
             React.createElement('h2', null, '⏱️ Performance Breakdown'),
             React.createElement(MetricFolder, { title: 'Time Distribution', icon: '🥧', defaultExpanded: true },
@@ -437,6 +1253,22 @@ function App() {
                 )
             )
         ) : null,
+        reportData.environment && reportData.environment.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🌐 Environment'),
+                React.createElement(MetricFolder, { title: 'Environment variables that affected this run (' + reportData.environment.length + ')', icon: '⚙️' },
+                    React.createElement('div', { className: 'hardware-grid' },
+                        reportData.environment.map(v =>
+                            React.createElement('div', { key: v.name, className: 'hardware-item', title: v.effect },
+                                React.createElement('div', { className: 'hardware-item-label' }, v.name),
+                                React.createElement('div', { className: 'hardware-item-value', style: { wordBreak: 'break-all' } }, v.value || '(empty)'),
+                                React.createElement('div', { style: { fontSize: '0.8em', color: '#666', marginTop: '4px' } }, v.effect)
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
         reportData.resourceUsage && Object.keys(reportData.resourceUsage).length > 0 ? (
             React.createElement('div', { className: 'section' },
                 React.createElement('h2', null, '📊 Resource Usage'),
@@ -455,6 +1287,11 @@ function App() {
                                         React.createElement('div', { style: { marginTop: '5px' } },
                                             React.createElement('strong', null, 'Memory: '), value.Memory.toFixed(2) + ' MB'
                                         )
+                                    ) : null,
+                                    value.GPUMemory !== undefined ? (
+                                        React.createElement('div', { style: { marginTop: '5px' } },
+                                            React.createElement('strong', null, 'GPU Memory (Core): '), value.GPUMemory.toFixed(0) + ' MB'
+                                        )
                                     ) : null
                                 );
                             }
@@ -464,11 +1301,127 @@ function App() {
                 )
             )
         ) : null,
+        reportData.prompt ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '💬 Prompt'),
+                React.createElement(MetricFolder, { title: '"' + reportData.prompt + '"', icon: '🔤' },
+                    Object.keys(reportData.promptTokens || {}).length > 0 ? (
+                        React.createElement('div', { className: 'metric-grid' },
+                            Object.entries(reportData.promptTokens).map(([name, ids]) =>
+                                React.createElement('div', { key: name, className: 'metric-item' },
+                                    React.createElement('div', { className: 'metric-item-label' }, name + ' (' + ids.length + ' tokens)'),
+                                    React.createElement('pre', { style: { marginTop: '8px', fontSize: '0.8em', whiteSpace: 'pre-wrap', wordBreak: 'break-word' } }, ids.join(' '))
+                                )
+                            )
+                        )
+                    ) : (
+                        React.createElement('p', { style: { color: '#666', fontStyle: 'italic' } }, 'No tokenizer was available; built-in token IDs were used')
+                    )
+                )
+            )
+        ) : null,
+        reportData.inputSchemas && Object.keys(reportData.inputSchemas).length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🧩 Model Input Schemas'),
+                React.createElement(MetricFolder, { title: 'Inputs Reported by Core', icon: '📐' },
+                    React.createElement('div', { className: 'hardware-grid' },
+                        Object.entries(reportData.inputSchemas).map(([name, inputs]) =>
+                            React.createElement('div', { key: name, className: 'hardware-item' },
+                                React.createElement('div', { className: 'hardware-item-label' }, name),
+                                inputs.map((input, idx) =>
+                                    React.createElement('div', { key: idx, style: { marginTop: '5px' } },
+                                        React.createElement('strong', null, input.name),
+                                        input.shape ? ' [' + input.shape.join(', ') + ']' : '',
+                                        input.dtype ? ' ' + input.dtype : ''
+                                    )
+                                )
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        reportData.modelManifest && Object.keys(reportData.modelManifest).length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🔏 Model Manifest'),
+                React.createElement(MetricFolder, { title: 'Installed Model Files (SHA256)', icon: '📄' },
+                    React.createElement('div', { className: 'hardware-grid' },
+                        Object.entries(reportData.modelManifest).map(([name, entry]) =>
+                            React.createElement('div', { key: name, className: 'hardware-item' },
+                                React.createElement('div', { className: 'hardware-item-label' }, name),
+                                React.createElement('div', { className: 'hardware-item-value' },
+                                    (entry.variant ? entry.variant + ' · ' : '') + (entry.sizeBytes / (1024 * 1024)).toFixed(1) + ' MB' +
+                                        (entry.externalData && entry.externalData.length > 0 ? ' · +' + entry.externalData.length + ' external data file(s)' : '')
+                                ),
+                                React.createElement('div', {
+                                    style: { marginTop: '5px', fontFamily: 'monospace', fontSize: '0.8em', wordBreak: 'break-all' },
+                                    title: entry.path
+                                }, entry.sha256)
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        reportData.modelMemory && reportData.modelMemory.length > 0 ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🧮 Memory per Model'),
+                React.createElement(MetricFolder, {
+                    title: 'Core RSS added by each registration (baseline ' + reportData.coreBaselineMemoryMB.toFixed(1) + ' MB)',
+                    icon: '🧠',
+                    defaultExpanded: true
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        reportData.modelMemory.map((metric, idx) =>
+                            React.createElement('div', { key: idx, className: 'metric-item' },
+                                React.createElement('div', { className: 'metric-item-label' }, metric.name),
+                                React.createElement('div', { className: 'metric-item-value' }, metric.memoryMB.toFixed(1) + ' MB')
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
+        reportData.memoryStability ? (
+            React.createElement('div', { className: 'section' },
+                React.createElement('h2', null, '🧠 Memory Stability'),
+                React.createElement(MetricFolder, {
+                    title: 'Core RSS across ' + reportData.memoryStability.iterations + ' inference iterations',
+                    icon: reportData.memoryStability.leakSuspected ? '⚠️' : '✅',
+                    defaultExpanded: reportData.memoryStability.leakSuspected
+                },
+                    React.createElement('div', { className: 'metric-grid' },
+                        React.createElement('div', { className: 'metric-item' },
+                            React.createElement('div', { className: 'metric-item-label' }, 'Before (steady state)'),
+                            React.createElement('div', { className: 'metric-item-value' }, reportData.memoryStability.beforeMB.toFixed(1) + ' MB')
+                        ),
+                        React.createElement('div', { className: 'metric-item' },
+                            React.createElement('div', { className: 'metric-item-label' }, 'After'),
+                            React.createElement('div', { className: 'metric-item-value' }, reportData.memoryStability.afterMB.toFixed(1) + ' MB')
+                        ),
+                        React.createElement('div', { className: 'metric-item ' + (reportData.memoryStability.leakSuspected ? 'failed' : 'success') },
+                            React.createElement('div', { className: 'metric-item-label' }, 'Growth'),
+                            React.createElement('div', { className: 'metric-item-value' }, reportData.memoryStability.growthMB.toFixed(1) + ' MB'),
+                            React.createElement('div', { className: 'metric-item-status' },
+                                React.createElement('span', { className: 'badge ' + (reportData.memoryStability.leakSuspected ? 'failed' : 'success') },
+                                    reportData.memoryStability.leakSuspected ? '⚠️ Potential leak' : '✅ Stable'
+                                )
+                            )
+                        )
+                    )
+                )
+            )
+        ) : null,
         React.createElement('div', { className: 'footer' },
             React.createElement('p', null,
                 React.createElement('strong', null, 'MLOS Foundation'), ' - Signal. Propagate. Myelinate. 🧠'
             ),
-            React.createElement('p', null, 'Generated: ', reportData.timestamp)
+            React.createElement('p', null, 'Generated: ', reportData.timestamp),
+            React.createElement('p', null, 'Test harness: ',
+                (reportData.harness && reportData.harness.version) || 'dev',
+                reportData.harness && reportData.harness.commit ? ' @ ' + reportData.harness.commit : '',
+                reportData.harness && reportData.harness.date ? ' (built ' + reportData.harness.date + ')' : ''
+            )
         )
     );
 }
@@ -0,0 +1,137 @@
+package report
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/test"
+
+	_ "modernc.org/sqlite" // Pure-Go driver, so the binary stays cgo-free
+)
+
+// sqliteSchema creates the history tables on first use
+// One runs row per E2E run; model_metrics holds that run's per-model numbers.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id                    INTEGER PRIMARY KEY AUTOINCREMENT,
+	started_at            TEXT NOT NULL,
+	axon_version          TEXT NOT NULL,
+	core_version          TEXT NOT NULL,
+	duration_ms           INTEGER NOT NULL,
+	success_rate          REAL NOT NULL,
+	total_inferences      INTEGER NOT NULL,
+	successful_inferences INTEGER NOT NULL,
+	failed_inferences     INTEGER NOT NULL,
+	skipped_inferences    INTEGER NOT NULL,
+	models_installed      INTEGER NOT NULL,
+	core_startup_ms       INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS runs_started_at ON runs (started_at);
+CREATE INDEX IF NOT EXISTS runs_versions ON runs (core_version, axon_version);
+
+CREATE TABLE IF NOT EXISTS model_metrics (
+	run_id                INTEGER NOT NULL REFERENCES runs (id),
+	model                 TEXT NOT NULL,
+	registration_ms       INTEGER,
+	small_status          TEXT,
+	small_ms              INTEGER,
+	large_status          TEXT,
+	large_ms              INTEGER,
+	batch_throughput      REAL,
+	PRIMARY KEY (run_id, model)
+);
+`
+
+// WriteSQLite appends a run's summary and per-model metrics to the SQLite database at path
+// The schema is created if needed, so the file can start out missing. Rows are keyed by
+// start time and versions for trend queries such as GPT-2 latency across nightly runs.
+// Callers treat a failure here as a warning; the run's own results are already written.
+func WriteSQLite(results *test.Results, path string) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("failed to open SQLite database %s: %w", path, err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return fmt.Errorf("failed to create SQLite schema: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start SQLite transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // No-op after a successful commit
+	}()
+
+	m := results.Metrics
+	res, err := tx.Exec(`INSERT INTO runs (started_at, axon_version, core_version, duration_ms, success_rate,
+		total_inferences, successful_inferences, failed_inferences, skipped_inferences, models_installed, core_startup_ms)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		results.StartTime.UTC().Format(time.RFC3339), results.AxonVersion, results.CoreVersion,
+		results.Duration.Milliseconds(), results.SuccessRate,
+		m.TotalInferences, m.SuccessfulInferences, m.FailedInferences, m.SkippedInferences,
+		m.ModelsInstalled, m.CoreStartupTimeMs)
+	if err != nil {
+		return fmt.Errorf("failed to insert run: %w", err)
+	}
+	runID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to read run id: %w", err)
+	}
+
+	for _, name := range sqliteModelNames(results) {
+		_, err := tx.Exec(`INSERT INTO model_metrics (run_id, model, registration_ms, small_status, small_ms,
+			large_status, large_ms, batch_throughput) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			runID, name,
+			nullable(m.ModelRegistrationTimes, name),
+			nullable(m.ModelInferenceStatus, name), nullable(m.ModelInferenceTimes, name),
+			nullable(m.ModelLargeInferenceStatus, name), nullable(m.ModelLargeInferenceTimes, name),
+			nullable(m.ModelBatchThroughput, name))
+		if err != nil {
+			return fmt.Errorf("failed to insert metrics for %s: %w", name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit SQLite transaction: %w", err)
+	}
+	return nil
+}
+
+// sqliteModelNames returns the models to write rows for: the recorded matrix, or
+// (for results from before it was recorded) every model with a registration or inference
+func sqliteModelNames(results *test.Results) []string {
+	if len(results.Models) > 0 {
+		names := make([]string, 0, len(results.Models))
+		for _, spec := range results.Models {
+			names = append(names, spec.Name)
+		}
+		return names
+	}
+
+	seen := make(map[string]bool)
+	for name := range results.Metrics.ModelRegistrationTimes {
+		seen[name] = true
+	}
+	for name := range results.Metrics.ModelInferenceStatus {
+		seen[name] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// nullable returns m[key], or nil (SQL NULL) if the model has no value
+func nullable[V any](m map[string]V, key string) interface{} {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mlOS-foundation/system-test/internal/criteria"
+)
+
+// ExitCodeRequirementsUnmet is the process exit code for a run that completed
+// but did not meet its coverage requirements (e.g. --require-categories)
+const ExitCodeRequirementsUnmet = 3
+
+// ErrRequirementsUnmet is wrapped by Results.RequirementsError
+var ErrRequirementsUnmet = errors.New("coverage requirements not met")
+
+// CategoryCount tallies the models of one category
+type CategoryCount struct {
+	Total       int // Models in the matrix
+	Tested      int // Models with an inference result
+	Passed      int // Models whose inference succeeded
+	InstallOnly int // Models installed but deliberately not inferred (--install-only-categories)
+}
+
+// CountCategories counts total/tested/passed models per category
+// nlp, vision and multimodal are always present, even if empty
+func CountCategories(results *Results, models []ModelSpec) map[string]*CategoryCount {
+	counts := map[string]*CategoryCount{
+		"nlp":        {},
+		"vision":     {},
+		"multimodal": {},
+	}
+
+	// Count models that were actually tested (have inference results)
+	for _, spec := range models {
+		count, ok := counts[spec.Category]
+		if !ok {
+			count = &CategoryCount{}
+			counts[spec.Category] = count
+		}
+		count.Total++
+		for _, name := range results.InstallOnlyModels {
+			if name == spec.Name {
+				count.InstallOnly++
+			}
+		}
+		if status, hasStatus := results.Metrics.ModelInferenceStatus[spec.Name]; hasStatus {
+			count.Tested++
+			if status == "success" {
+				count.Passed++
+			}
+		}
+	}
+
+	return counts
+}
+
+// RequirementsError reports unmet coverage requirements, or nil if there are none
+func (r *Results) RequirementsError() error {
+	if len(r.UnmetCategories) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: no models tested in required categories: %s",
+		ErrRequirementsUnmet, strings.Join(r.UnmetCategories, ", "))
+}
+
+// ErrGateNotMet is wrapped by Results.GateError
+var ErrGateNotMet = errors.New("quality gate not met")
+
+// PassingModels counts the models every one of whose inference tests succeeded
+// Models that were skipped or never tested don't count.
+func PassingModels(results *Results, models []ModelSpec) int {
+	m := results.Metrics
+	passing := 0
+	for _, spec := range models {
+		tested, failed := false, false
+		for _, statuses := range []map[string]string{m.ModelInferenceStatus, m.ModelLargeInferenceStatus, m.ModelBatchInferenceStatus} {
+			if status, ok := statuses[spec.Name]; ok {
+				tested = true
+				failed = failed || status != "success"
+			}
+		}
+		if tested && !failed {
+			passing++
+		}
+	}
+	return passing
+}
+
+// GateError reports a run that fell short of --min-success-rate or --min-passing-models, or nil
+// The run fails on this error instead of on any success rate below 100%.
+// With --success-criteria, the expression is the gate instead (see CriteriaVariables).
+func (r *Results) GateError() error {
+	if r.SuccessCriteria != "" {
+		return r.criteriaError()
+	}
+
+	var misses []string
+	if r.SuccessRate < r.MinSuccessRate {
+		misses = append(misses, fmt.Sprintf("success rate %.1f%% is below %.1f%%", r.SuccessRate, r.MinSuccessRate))
+	}
+	if r.PassingModels < r.MinPassingModels {
+		misses = append(misses, fmt.Sprintf("%d passing models, need %d", r.PassingModels, r.MinPassingModels))
+	}
+	if len(misses) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrGateNotMet, strings.Join(misses, "; "))
+}
+
+// criteriaError evaluates SuccessCriteria against the results
+// An expression that can't be evaluated (e.g. a misspelled variable) fails the gate too.
+func (r *Results) criteriaError() error {
+	expr, err := criteria.Parse(r.SuccessCriteria)
+	if err != nil {
+		return fmt.Errorf("%w: invalid success criteria %q: %v", ErrGateNotMet, r.SuccessCriteria, err)
+	}
+	met, err := expr.Eval(CriteriaVariables(r))
+	if err != nil {
+		return fmt.Errorf("%w: cannot evaluate success criteria %q: %v", ErrGateNotMet, r.SuccessCriteria, err)
+	}
+	if !met {
+		return fmt.Errorf("%w: success criteria %q evaluated to false", ErrGateNotMet, r.SuccessCriteria)
+	}
+	return nil
+}
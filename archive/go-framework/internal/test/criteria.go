@@ -0,0 +1,143 @@
+package test
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mlOS-foundation/system-test/internal/monitor"
+)
+
+// CriteriaVariables returns the variables a --success-criteria expression can use:
+//
+//	success_rate                  Inference success rate in percent
+//	total_inferences              Inference tests run (small, large and batch)
+//	successful_inferences         ... of which passed
+//	failed_inferences             ... of which failed
+//	skipped_inferences            Small and large tests that never ran
+//	models, passing_models        Models in the matrix / whose every inference test passed
+//	errors                        Failures recorded anywhere in the run
+//	core_startup_ms, core_restarts, duration_s
+//	mean_ms, p95_ms, max_ms       Latency of the passing small and large tests (0 if none)
+//	memory_mb, cpu_percent        Core under load (only when resources were monitored)
+//	idle_memory_mb                Core at idle (only when resources were monitored)
+//	gpu_memory_mb                 Core's GPU memory under load (only with a GPU)
+//	model.<name>.passed           Every inference test of the model passed (true/false)
+//	model.<name>.tested           The model has an inference result (true/false)
+//	model.<name>.small_ms         Small inference latency (only if it passed)
+//	model.<name>.large_ms         Large inference latency (only if it passed)
+//	category.<name>.total         Models of the category in the matrix
+//	category.<name>.tested        ... with an inference result
+//	category.<name>.passed        ... whose every inference test passed
+//	category.<name>.all_passed    At least one was tested and all tested ones passed (true/false)
+//
+// Characters other than letters, digits, "_" and "." in model and category names become "_".
+// Variables marked "only" are missing otherwise; using a missing one fails the gate.
+func CriteriaVariables(results *Results) map[string]interface{} {
+	m := results.Metrics
+	vars := map[string]interface{}{
+		"success_rate":          results.SuccessRate,
+		"total_inferences":      float64(m.TotalInferences),
+		"successful_inferences": float64(m.SuccessfulInferences),
+		"failed_inferences":     float64(m.FailedInferences),
+		"skipped_inferences":    float64(m.SkippedInferences),
+		"models":                float64(len(results.Models)),
+		"passing_models":        float64(PassingModels(results, results.Models)),
+		"errors":                float64(len(results.Errors)),
+		"core_startup_ms":       float64(m.CoreStartupTimeMs),
+		"core_restarts":         float64(len(results.CoreRestarts)),
+		"duration_s":            results.Duration.Seconds(),
+	}
+
+	var latencies []float64
+	for _, spec := range results.Models {
+		prefix := "model." + criteriaName(spec.Name) + "."
+		_, tested := m.ModelInferenceStatus[spec.Name]
+		if _, ok := m.ModelLargeInferenceStatus[spec.Name]; ok {
+			tested = true
+		}
+		vars[prefix+"tested"] = tested
+		vars[prefix+"passed"] = tested && modelPassed(results, spec.Name)
+		if m.ModelInferenceStatus[spec.Name] == "success" {
+			ms := float64(m.ModelInferenceTimes[spec.Name])
+			vars[prefix+"small_ms"] = ms
+			latencies = append(latencies, ms)
+		}
+		if m.ModelLargeInferenceStatus[spec.Name] == "success" {
+			ms := float64(m.ModelLargeInferenceTimes[spec.Name])
+			vars[prefix+"large_ms"] = ms
+			latencies = append(latencies, ms)
+		}
+	}
+	vars["mean_ms"], vars["p95_ms"], vars["max_ms"] = latencySummary(latencies)
+
+	// Unlike CountCategories, a model only counts as passed if its large and batch tests passed too
+	for category := range CountCategories(results, results.Models) {
+		var total, tested, passed int
+		for _, spec := range results.Models {
+			if spec.Category != category {
+				continue
+			}
+			total++
+			if vars["model."+criteriaName(spec.Name)+".tested"] == true {
+				tested++
+				if vars["model."+criteriaName(spec.Name)+".passed"] == true {
+					passed++
+				}
+			}
+		}
+		prefix := "category." + criteriaName(category) + "."
+		vars[prefix+"total"] = float64(total)
+		vars[prefix+"tested"] = float64(tested)
+		vars[prefix+"passed"] = float64(passed)
+		vars[prefix+"all_passed"] = tested > 0 && passed == tested
+	}
+
+	if usage, ok := results.ResourceUsage["under_load"].(map[string]interface{}); ok {
+		vars["memory_mb"], _ = usage["MemoryMB"].(float64)
+		vars["cpu_percent"], _ = usage["CPUPercent"].(float64)
+		if status, _ := usage["GPUStatus"].(string); status != "" && status != monitor.GPUNone {
+			vars["gpu_memory_mb"], _ = usage["GPUMemoryMB"].(float64)
+		}
+	}
+	if usage, ok := results.ResourceUsage["idle"].(map[string]interface{}); ok {
+		vars["idle_memory_mb"], _ = usage["MemoryMB"].(float64)
+	}
+	return vars
+}
+
+// modelPassed reports whether none of a model's inference tests failed
+func modelPassed(results *Results, name string) bool {
+	m := results.Metrics
+	for _, statuses := range []map[string]string{m.ModelInferenceStatus, m.ModelLargeInferenceStatus, m.ModelBatchInferenceStatus} {
+		if status, ok := statuses[name]; ok && status != "success" {
+			return false
+		}
+	}
+	return true
+}
+
+// latencySummary returns the mean, 95th percentile (nearest rank) and maximum of latencies
+func latencySummary(latencies []float64) (float64, float64, float64) {
+	if len(latencies) == 0 {
+		return 0.0, 0.0, 0.0
+	}
+	sorted := append([]float64(nil), latencies...)
+	sort.Float64s(sorted)
+	var sum float64
+	for _, ms := range sorted {
+		sum += ms
+	}
+	rank := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+	return sum / float64(len(sorted)), sorted[rank], sorted[len(sorted)-1]
+}
+
+// criteriaName makes a model or category name usable inside a variable name
+func criteriaName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '.' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
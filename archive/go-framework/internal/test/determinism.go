@@ -0,0 +1,43 @@
+package test
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/mlOS-foundation/system-test/internal/model"
+)
+
+// checkDeterminism sends every passing model its small input twice and compares the two outputs
+// The same input through the same graph must give the same output; a model that doesn't points at
+// a Core bug (a race between sessions, uninitialized memory) rather than at the model.
+func (r *Runner) checkDeterminism(results *Results) error {
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("🔂 Checking Output Determinism (tolerance %g)", r.cfg.DeterminismTolerance)
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	results.Metrics.RecordDeterminismTolerance(r.cfg.DeterminismTolerance)
+	for _, spec := range r.getTestModels() {
+		// A negative-test model passes with an error response, which has no outputs to compare
+		if results.Metrics.InferenceStatus(spec.Name, SizeSmall) != "success" || r.cfg.ExpectedStatusFor(spec.Name) != http.StatusOK {
+			continue
+		}
+		r.ensureCoreRunning(results, spec.Name)
+
+		divergences, err := model.CheckDeterminism(spec.ID, spec.Name, spec.Type, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(false), r.cfg.InputKey, r.cfg.DeterminismTolerance, r.inference)
+		switch {
+		case err != nil:
+			results.Metrics.RecordDeterminism(spec.Name, "failed", []string{err.Error()})
+			results.RecordError("determinism", spec.Name, err.Error())
+			log.Printf("ERROR: %s determinism check failed: %v", spec.Name, err)
+		case len(divergences) > 0:
+			results.Metrics.RecordDeterminism(spec.Name, "nondeterministic", divergences)
+			results.RecordError("determinism", spec.Name, "identical input gave different outputs: "+strings.Join(divergences, "; "))
+			log.Printf("ERROR: %s is not deterministic: %s", spec.Name, strings.Join(divergences, "; "))
+		default:
+			results.Metrics.RecordDeterminism(spec.Name, "deterministic", nil)
+			log.Printf("✅ %s: identical input gave identical outputs", spec.Name)
+		}
+	}
+	return nil
+}
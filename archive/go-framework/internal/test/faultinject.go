@@ -0,0 +1,251 @@
+package test
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/config"
+	"github.com/mlOS-foundation/system-test/internal/model"
+	"github.com/mlOS-foundation/system-test/internal/monitor"
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+const (
+	// wrongPortTimeout bounds the request sent to a port nothing listens on
+	wrongPortTimeout = 5 * time.Second
+	// killCoreDelay is how long inference runs before Core is killed under it
+	killCoreDelay = 200 * time.Millisecond
+	// killCoreWindow bounds how long inference keeps being sent while waiting for Core to die
+	killCoreWindow = 30 * time.Second
+	// reapTimeout bounds the wait for a killed Core process to exit
+	reapTimeout = 10 * time.Second
+)
+
+// runFailureInjection breaks things on purpose (--inject-failures) and checks the harness records
+// each failure correctly and cleans up after it. Scenarios run in config.FailureScenarios order,
+// so the Core-killing one comes last; Core is restarted afterwards for the remaining stages.
+func (r *Runner) runFailureInjection(results *Results) error {
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("💥 Injecting Failures")
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	// Failures only mean something against a model that otherwise works
+	var target *ModelSpec
+	for _, spec := range r.getTestModels() {
+		if results.Metrics.InferenceStatus(spec.Name, SizeSmall) == "success" {
+			spec := spec
+			target = &spec
+			break
+		}
+	}
+
+	for _, scenario := range r.cfg.InjectFailures {
+		if target == nil {
+			r.recordInjectedFailure(results, InjectedFailure{
+				Scenario: scenario,
+				Observed: "no model passed the small inference test",
+				Status:   "skipped",
+			})
+			continue
+		}
+		switch scenario {
+		case config.FailureWrongPort:
+			r.recordInjectedFailure(results, r.injectWrongPort(*target))
+		case config.FailureMalformedPayload:
+			for _, f := range r.injectMalformedPayloads(*target) {
+				r.recordInjectedFailure(results, f)
+			}
+		case config.FailureKillCore:
+			r.recordInjectedFailure(results, r.injectKillCore(results, *target))
+		}
+	}
+
+	passed := 0
+	for _, f := range results.InjectedFailures {
+		if f.Status == "success" {
+			passed++
+		}
+	}
+	log.Printf("✅ %d/%d injected failures handled as expected", passed, len(results.InjectedFailures))
+	return nil
+}
+
+// recordInjectedFailure stores and logs the outcome of one injected failure
+func (r *Runner) recordInjectedFailure(results *Results, f InjectedFailure) {
+	results.InjectedFailures = append(results.InjectedFailures, f)
+	name := f.Scenario
+	if f.Detail != "" {
+		name += " (" + f.Detail + ")"
+	}
+	switch f.Status {
+	case "success":
+		log.Printf("✅ %s: %s", name, f.Observed)
+	case "skipped":
+		log.Printf("⏭️  %s skipped: %s", name, f.Observed)
+	default:
+		log.Printf("ERROR: %s: expected %s, got %s", name, f.Expected, f.Observed)
+		results.RecordError("inject-failures", "", fmt.Sprintf("%s: expected %s, got %s", name, f.Expected, f.Observed))
+	}
+}
+
+// injectWrongPort sends inference to a free port and expects a prompt connection error
+func (r *Runner) injectWrongPort(spec ModelSpec) InjectedFailure {
+	f := InjectedFailure{
+		Scenario: config.FailureWrongPort,
+		Expected: "a connection error",
+	}
+
+	// Reserving and releasing a port gives one nothing else is listening on
+	reservation, err := release.NewPortAllocator(r.cfg.Host, r.cfg.CorePort+1).Reserve()
+	if err != nil {
+		f.Observed = err.Error()
+		f.Status = "skipped"
+		return f
+	}
+	_ = reservation.Release()
+	f.Detail = fmt.Sprintf("port %d", reservation.Port)
+
+	start := time.Now()
+	_, err = model.RunInference(spec.ID, spec.Name, spec.Type, false, r.cfg.URLForPort(reservation.Port), wrongPortTimeout, r.cfg.InputKey, r.inference)
+	elapsed := time.Since(start).Milliseconds()
+	switch {
+	case err == nil:
+		f.Observed = "the request succeeded"
+		f.Status = "failed"
+	case model.ClassifyFailure(err) != model.FailureConnection:
+		f.Observed = fmt.Sprintf("a %s failure: %v", model.ClassifyFailure(err), err)
+		f.Status = "failed"
+	default:
+		f.Observed = fmt.Sprintf("connection error after %dms", elapsed)
+		f.Status = "success"
+	}
+	return f
+}
+
+// injectMalformedPayloads sends invalid bodies and expects each to be rejected with a 4xx
+// Core must also still be healthy afterwards; a crash fails every payload sent after it.
+func (r *Runner) injectMalformedPayloads(spec ModelSpec) []InjectedFailure {
+	var failures []InjectedFailure
+	for _, payload := range model.MalformedPayloads(r.cfg.InputKey) {
+		f := InjectedFailure{
+			Scenario: config.FailureMalformedPayload,
+			Detail:   payload.Name,
+			Expected: "a 4xx rejection with Core still healthy",
+		}
+
+		err := model.RunRawInference(spec.ID, payload.Body, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(false), r.inference)
+		kind := ""
+		if err != nil {
+			kind = model.ClassifyFailure(err)
+		}
+		switch {
+		case err == nil:
+			f.Observed = "the request succeeded"
+			f.Status = "failed"
+		case kind != model.FailureRejected:
+			f.Observed = fmt.Sprintf("a %s failure: %v", kind, err)
+			f.Status = "failed"
+		default:
+			f.Observed = err.Error()
+			f.Status = "success"
+		}
+
+		if healthErr := release.CheckCoreHealth(r.cfg.CoreURL(), r.releaseOptions()); healthErr != nil {
+			f.Observed += fmt.Sprintf("; Core unhealthy afterwards: %v", healthErr)
+			f.Status = "failed"
+			r.logCoreOutputIfCrashed()
+		}
+		failures = append(failures, f)
+	}
+	return failures
+}
+
+// injectKillCore kills Core while inference requests are in flight, then checks the request
+// failed as a lost connection, the process was reaped and its port freed, and Core restarts
+func (r *Runner) injectKillCore(results *Results, spec ModelSpec) InjectedFailure {
+	f := InjectedFailure{
+		Scenario: config.FailureKillCore,
+		Detail:   spec.Name,
+		Expected: "a connection error, the process reaped, its port freed and a successful restart",
+	}
+	process := r.core()
+	if process == nil || process.Cmd == nil || process.Cmd.Process == nil {
+		f.Observed = "Core is not managed by this run"
+		f.Status = "skipped"
+		return f
+	}
+
+	// Keep large requests going so one is in flight when Core dies
+	inferErr := make(chan error, 1)
+	go func() {
+		deadline := time.Now().Add(killCoreWindow)
+		for time.Now().Before(deadline) {
+			if _, err := model.RunInference(spec.ID, spec.Name, spec.Type, true, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(true), r.cfg.InputKey, r.inference); err != nil {
+				inferErr <- err
+				return
+			}
+		}
+		inferErr <- nil
+	}()
+	time.Sleep(killCoreDelay)
+
+	log.Printf("   Killing Core (PID %d) mid-inference", process.PID)
+	if err := monitor.StopProcess(process); err != nil {
+		<-inferErr
+		f.Observed = fmt.Sprintf("could not kill Core: %v", err)
+		f.Status = "failed"
+		return f
+	}
+
+	var problems, observed []string
+	if err := <-inferErr; err == nil {
+		problems = append(problems, "inference kept succeeding")
+	} else if kind := model.ClassifyFailure(err); kind != model.FailureConnection {
+		problems = append(problems, fmt.Sprintf("a %s failure (%v)", kind, err))
+	} else {
+		observed = append(observed, "connection error")
+	}
+
+	// Nothing else waits on Core, so reap it here rather than leave a zombie
+	reaped := make(chan struct{})
+	go func() {
+		_ = process.Wait() // "signal: killed" is the expected outcome
+		close(reaped)
+	}()
+	select {
+	case <-reaped:
+		observed = append(observed, "process reaped")
+		r.setCore(nil)
+	case <-time.After(reapTimeout):
+		problems = append(problems, fmt.Sprintf("process still running after %s", reapTimeout))
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(r.cfg.Host, strconv.Itoa(r.cfg.CorePort)))
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("port %d still in use", r.cfg.CorePort))
+	} else {
+		_ = listener.Close()
+		observed = append(observed, "port freed")
+	}
+
+	// Later stages (and unregistration) need Core back
+	r.restartCore(results, "", "injected failure: "+config.FailureKillCore)
+	if err := release.CheckCoreHealth(r.cfg.CoreURL(), r.releaseOptions()); err != nil {
+		problems = append(problems, fmt.Sprintf("Core not healthy after restart (%v)", err))
+	} else {
+		observed = append(observed, "restarted")
+	}
+
+	if len(problems) > 0 {
+		f.Observed = strings.Join(problems, "; ")
+		f.Status = "failed"
+		return f
+	}
+	f.Observed = strings.Join(observed, ", ")
+	f.Status = "success"
+	return f
+}
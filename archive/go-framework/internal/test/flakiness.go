@@ -0,0 +1,143 @@
+package test
+
+import (
+	"log"
+	"sort"
+)
+
+// RunRepeated is RunWithRetries, repeated cfg.Repeat times to tell flaky models from broken ones
+// The last repetition that completed provides the results, with every repetition's per-model
+// outcomes and the flakiness they add up to. A repetition that aborted counts against no model:
+// its failure is the infrastructure's, which is what the flakiness scores are meant to separate out.
+func (r *Runner) RunRepeated() (*Results, error) {
+	if r.cfg.Repeat <= 1 {
+		return r.RunWithRetries()
+	}
+
+	var last *Results
+	var lastErr error
+	var repetitions []Repetition
+	for i := 1; i <= r.cfg.Repeat; i++ {
+		log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		log.Printf("🔁 Repetition %d of %d", i, r.cfg.Repeat)
+		log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+		repetition := Repetition{Index: i}
+		results, err := r.RunWithRetries()
+		if err != nil {
+			log.Printf("WARN: Repetition %d aborted: %v", i, err)
+			repetition.Error = err.Error()
+			lastErr = err
+		} else {
+			repetition.Outcomes = modelOutcomes(results, r.getTestModels())
+			last = results
+		}
+		repetitions = append(repetitions, repetition)
+
+		// Run stopped its Cores on the way out; the next repetition starts its own
+		r.setCore(nil)
+		r.extraCores = nil
+	}
+	if last == nil {
+		return nil, lastErr
+	}
+
+	last.Repetitions = repetitions
+	last.Flakiness = Flakiness(repetitions)
+	logFlakiness(last.Flakiness, len(repetitions))
+	return last, nil
+}
+
+// modelOutcomes returns whether each tested model passed all its inference tests (as PassingModels counts them)
+func modelOutcomes(results *Results, models []ModelSpec) []ModelOutcome {
+	m := results.Metrics
+	var outcomes []ModelOutcome
+	for _, spec := range models {
+		outcome := ModelOutcome{Model: spec.Name, Passed: true}
+		tested := false
+		for _, test := range []struct {
+			size     string
+			statuses map[string]string
+			errors   map[string]string
+		}{
+			{SizeSmall, m.ModelInferenceStatus, m.ModelInferenceErrors},
+			{SizeLarge, m.ModelLargeInferenceStatus, m.ModelLargeInferenceErrors},
+			{SizeBatch, m.ModelBatchInferenceStatus, m.ModelBatchInferenceErrors},
+		} {
+			status, ok := test.statuses[spec.Name]
+			if !ok {
+				continue
+			}
+			tested = true
+			if status != "success" && outcome.Passed {
+				outcome.Passed = false
+				outcome.Failure = test.size + ": " + status
+				if detail := test.errors[spec.Name]; detail != "" {
+					outcome.Failure = test.size + ": " + detail
+				}
+			}
+		}
+		if tested {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	return outcomes
+}
+
+// Flakiness scores every model tested in repetitions by the fraction of them it failed in
+// The result is sorted by score, highest first, then by model name.
+func Flakiness(repetitions []Repetition) []ModelFlakiness {
+	byModel := make(map[string]*ModelFlakiness)
+	var order []string
+	for _, repetition := range repetitions {
+		for _, outcome := range repetition.Outcomes {
+			f, ok := byModel[outcome.Model]
+			if !ok {
+				f = &ModelFlakiness{Model: outcome.Model, FailureModes: make(map[string]int)}
+				byModel[outcome.Model] = f
+				order = append(order, outcome.Model)
+			}
+			f.Runs++
+			if !outcome.Passed {
+				f.Failures++
+				f.FailureModes[outcome.Failure]++
+			}
+		}
+	}
+
+	flakiness := make([]ModelFlakiness, 0, len(order))
+	for _, name := range order {
+		f := byModel[name]
+		f.Score = float64(f.Failures) / float64(f.Runs)
+		flakiness = append(flakiness, *f)
+	}
+	sort.SliceStable(flakiness, func(i, j int) bool {
+		if flakiness[i].Score != flakiness[j].Score {
+			return flakiness[i].Score > flakiness[j].Score
+		}
+		return flakiness[i].Model < flakiness[j].Model
+	})
+	return flakiness
+}
+
+// logFlakiness prints the models that failed at least once across the repetitions
+func logFlakiness(flakiness []ModelFlakiness, repetitions int) {
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("🎲 Flakiness across %d repetitions", repetitions)
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	unstable := 0
+	for _, f := range flakiness {
+		if f.Failures == 0 {
+			continue
+		}
+		unstable++
+		verdict := "flaky"
+		if f.Failures == f.Runs {
+			verdict = "broken"
+		}
+		log.Printf("   %s: failed %d of %d (%s, score %.2f)", f.Model, f.Failures, f.Runs, verdict, f.Score)
+	}
+	if unstable == 0 {
+		log.Printf("✅ Every model passed in every repetition that tested it")
+	}
+}
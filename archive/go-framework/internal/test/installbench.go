@@ -0,0 +1,151 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/model"
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// DefaultInstallBenchIterations is how many times install-bench installs each model by default
+const DefaultInstallBenchIterations = 5
+
+// InstallBenchResult is one model's install times across the iterations of install-bench
+type InstallBenchResult struct {
+	Model    string
+	TimesMs  []int64  // Successful installs, in iteration order
+	Errors   []string // Failed installs
+	Skipped  string   // Why Axon didn't install the model at all, if it didn't
+	MinMs    float64
+	MedianMs float64
+	MeanMs   float64
+	P95Ms    float64 // Nearest rank
+	MaxMs    float64
+	StdDevMs float64
+}
+
+// RunInstallBench installs every model in the matrix iterations times and times each install (install-bench)
+// Each model is removed from Axon's cache before every install, so every iteration converts from scratch.
+// Core is never started: this is Axon's convert/install path alone. A converter image fetched during an
+// install (see Install's Transfer) is left out of its time, so the first iteration compares with the rest.
+func (r *Runner) RunInstallBench(iterations int) ([]InstallBenchResult, error) {
+	if iterations < 1 {
+		iterations = DefaultInstallBenchIterations
+	}
+	models, err := ResolveModels(r.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("⏱️  Benchmarking Axon installs (%d model(s) × %d iterations)", len(models), iterations)
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if _, err := release.DownloadAxon(r.cfg.AxonVersion, r.cfg.OutputDir, r.releaseOptions()); err != nil {
+		return nil, fmt.Errorf("failed to download Axon: %w", err)
+	}
+
+	var results []InstallBenchResult
+	for _, spec := range models {
+		result := InstallBenchResult{Model: spec.Name}
+		for i := 1; i <= iterations; i++ {
+			if err := model.RemoveModel(spec.ID, r.cfg.AxonCacheDir); err != nil {
+				return results, err
+			}
+
+			log.Printf("📦 %s: install %d of %d", spec.ID, i, iterations)
+			start := time.Now()
+			installed, converter, err := model.Install(spec.ID, r.cfg.TestAllModels, r.cfg.AxonCacheDir, r.cfg.ModelFilenames, r.cfg.ReleaseMirror, r.cfg.ConverterDigest, r.axonMatcher(), r.releaseOptions())
+			elapsed := time.Since(start) - converter.Duration
+			if err != nil {
+				log.Printf("WARN: %s: install %d failed: %v", spec.ID, i, err)
+				result.Errors = append(result.Errors, err.Error())
+				continue
+			}
+			if !installed {
+				// Nothing was in the cache, so Install passed over the model (see its category filter)
+				result.Skipped = "not installed by Axon for this matrix (vision and multimodal models need --all-models)"
+				log.Printf("⏭️  %s: %s", spec.ID, result.Skipped)
+				break
+			}
+			result.TimesMs = append(result.TimesMs, elapsed.Milliseconds())
+			log.Printf("✅ %s: installed in %dms", spec.ID, elapsed.Milliseconds())
+		}
+		summarizeInstallTimes(&result)
+		results = append(results, result)
+	}
+	r.checkConverterContainers("after install-bench")
+	return results, nil
+}
+
+// summarizeInstallTimes fills in the statistics of result.TimesMs (all zero if no install succeeded)
+func summarizeInstallTimes(result *InstallBenchResult) {
+	if len(result.TimesMs) == 0 {
+		return
+	}
+	times := make([]float64, len(result.TimesMs))
+	for i, ms := range result.TimesMs {
+		times[i] = float64(ms)
+	}
+	result.MeanMs, result.P95Ms, result.MaxMs = latencySummary(times)
+
+	sort.Float64s(times)
+	result.MinMs = times[0]
+	mid := len(times) / 2
+	result.MedianMs = times[mid]
+	if len(times)%2 == 0 {
+		result.MedianMs = (times[mid-1] + times[mid]) / 2
+	}
+	var squares float64
+	for _, ms := range times {
+		squares += (ms - result.MeanMs) * (ms - result.MeanMs)
+	}
+	result.StdDevMs = math.Sqrt(squares / float64(len(times)))
+}
+
+// PrintInstallBench writes install-bench results as a table, in seconds, with every iteration's time
+func PrintInstallBench(w io.Writer, results []InstallBenchResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODEL\tOK\tFAILED\tMIN\tMEDIAN\tMEAN\tP95\tMAX\tSTDDEV\tTIMES")
+	for _, result := range results {
+		if result.Skipped != "" {
+			fmt.Fprintf(tw, "%s\t-\t-\t-\t-\t-\t-\t-\t-\tskipped: %s\n", result.Model, result.Skipped)
+			continue
+		}
+		times := make([]string, len(result.TimesMs))
+		for i, ms := range result.TimesMs {
+			times[i] = seconds(float64(ms))
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", result.Model, len(result.TimesMs), len(result.Errors),
+			seconds(result.MinMs), seconds(result.MedianMs), seconds(result.MeanMs), seconds(result.P95Ms),
+			seconds(result.MaxMs), seconds(result.StdDevMs), strings.Join(times, " "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	failuresShown := false
+	for _, result := range results {
+		for _, msg := range result.Errors {
+			if !failuresShown {
+				fmt.Fprintln(w)
+				failuresShown = true
+			}
+			if _, err := fmt.Fprintf(w, "%s failed: %s\n", result.Model, msg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// seconds formats milliseconds as seconds for install-bench's table
+func seconds(ms float64) string {
+	return fmt.Sprintf("%.1fs", ms/1000)
+}
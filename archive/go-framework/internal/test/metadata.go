@@ -0,0 +1,109 @@
+package test
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/mlOS-foundation/system-test/internal/config"
+	"github.com/mlOS-foundation/system-test/internal/model"
+)
+
+// checkModelMetadata compares each installed model's ONNX graph with its --model-expectations entry
+// A converter that produced a different input signature is reported here by name, instead of
+// surfacing later as a 400 from Core during inference.
+func (r *Runner) checkModelMetadata(results *Results) {
+	if len(r.cfg.ModelExpectations) == 0 {
+		return
+	}
+	log.Printf("🏷️  Checking model metadata against expectations")
+
+	for _, spec := range r.getTestModels() {
+		expect, ok := r.cfg.ModelExpectations[spec.Name]
+		if !ok {
+			continue
+		}
+		modelPath, err := model.GetPath(spec.ID, r.cfg.AxonCacheDir, r.cfg.ModelFilenames, r.releaseOptions())
+		if err != nil {
+			continue // The install failure is already recorded
+		}
+
+		var mismatches []string
+		meta, err := model.ReadONNXMetadata(modelPath)
+		if err != nil {
+			mismatches = []string{err.Error()}
+		} else {
+			mismatches = metadataMismatches(expect, meta)
+		}
+		results.Metrics.RecordMetadataCheck(spec.Name, mismatches)
+
+		if len(mismatches) == 0 {
+			log.Printf("✅ %s metadata matches", spec.Name)
+			continue
+		}
+		for _, mismatch := range mismatches {
+			log.Printf("ERROR: %s metadata: %s", spec.Name, mismatch)
+			results.RecordError("metadata", spec.Name, mismatch)
+		}
+	}
+}
+
+// metadataMismatches lists how meta differs from expect (nil if it doesn't)
+func metadataMismatches(expect config.ModelExpectation, meta model.ONNXMetadata) []string {
+	var mismatches []string
+	if len(expect.Inputs) > 0 {
+		mismatches = append(mismatches, nameMismatches("input", expect.Inputs, meta.Inputs)...)
+	}
+	if len(expect.Outputs) > 0 {
+		mismatches = append(mismatches, nameMismatches("output", expect.Outputs, meta.Outputs)...)
+	}
+
+	keys := make([]string, 0, len(expect.Metadata))
+	for key := range expect.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		got, ok := meta.Properties[key]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf("metadata %q is missing, expected %q", key, expect.Metadata[key]))
+		case got != expect.Metadata[key]:
+			mismatches = append(mismatches, fmt.Sprintf("metadata %q is %q, expected %q", key, got, expect.Metadata[key]))
+		}
+	}
+	return mismatches
+}
+
+// nameMismatches compares graph input or output names as sets
+func nameMismatches(kind string, want, got []string) []string {
+	var missing, unexpected []string
+	for _, name := range want {
+		if !containsString(got, name) {
+			missing = append(missing, name)
+		}
+	}
+	for _, name := range got {
+		if !containsString(want, name) {
+			unexpected = append(unexpected, name)
+		}
+	}
+	var mismatches []string
+	if len(missing) > 0 {
+		mismatches = append(mismatches, fmt.Sprintf("missing %s(s) %s (graph has %s)", kind, strings.Join(missing, ", "), strings.Join(got, ", ")))
+	}
+	if len(unexpected) > 0 {
+		mismatches = append(mismatches, fmt.Sprintf("unexpected %s(s) %s", kind, strings.Join(unexpected, ", ")))
+	}
+	return mismatches
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
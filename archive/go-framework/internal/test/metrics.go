@@ -0,0 +1,93 @@
+package test
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mlOS-foundation/system-test/internal/config"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// ResultsSchemaVersion is the version of the metrics.json format
+// Bump it together with results.schema.json when the format changes incompatibly
+const ResultsSchemaVersion = 1
+
+//go:embed results.schema.json
+var resultsSchemaJSON string
+
+// resultsSchemaURL is the schema's $id, used to register it with the compiler
+const resultsSchemaURL = "https://github.com/mlOS-foundation/system-test/results/v1.schema.json"
+
+// WriteMetrics writes results to cfg.MetricsPath and, with --validate-metrics,
+// checks the written file against the embedded schema
+func WriteMetrics(results *Results, cfg *config.Config) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metrics: %w", err)
+	}
+	if err := os.WriteFile(cfg.MetricsPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	if cfg.ValidateMetrics {
+		if err := ValidateMetricsFile(cfg.MetricsPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadMetrics loads results previously written by WriteMetrics
+// Fields missing from older files keep their NewResults defaults, so maps are never nil.
+func ReadMetrics(path string) (*Results, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metrics: %w", err)
+	}
+
+	results := NewResults("", "")
+	if err := json.Unmarshal(data, results); err != nil {
+		return nil, fmt.Errorf("failed to parse metrics %s: %w", path, err)
+	}
+	if results.SchemaVersion > ResultsSchemaVersion {
+		return nil, fmt.Errorf("%s uses results schema v%d, newer than the supported v%d", path, results.SchemaVersion, ResultsSchemaVersion)
+	}
+	if results.Metrics == nil {
+		results.Metrics = NewMetrics()
+	}
+	return results, nil
+}
+
+// ValidateMetricsFile validates a metrics.json file against the embedded results schema
+func ValidateMetricsFile(path string) error {
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat = true
+	if err := compiler.AddResource(resultsSchemaURL, strings.NewReader(resultsSchemaJSON)); err != nil {
+		return fmt.Errorf("failed to load results schema: %w", err)
+	}
+	schema, err := compiler.Compile(resultsSchemaURL)
+	if err != nil {
+		return fmt.Errorf("failed to compile results schema: %w", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open metrics: %w", err)
+	}
+	defer f.Close()
+
+	// UseNumber keeps integers distinguishable from floats for "type": "integer"
+	var doc interface{}
+	dec := json.NewDecoder(f)
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("failed to parse metrics: %w", err)
+	}
+	if err := schema.Validate(doc); err != nil {
+		return fmt.Errorf("%s does not match results schema v%d: %w", path, ResultsSchemaVersion, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/config"
+	"github.com/mlOS-foundation/system-test/internal/model"
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// recordSample records a plausible outcome for name through every Metrics and Results recorder
+func recordSample(results *Results, name string) {
+	for _, record := range sampleRecorders(results, name) {
+		record()
+	}
+}
+
+// sampleRecorders returns one call per Metrics and Results recorder, each recording a plausible outcome for name
+// Add new Record* methods here so the schema and race tests cover them.
+func sampleRecorders(results *Results, name string) []func() {
+	m := results.Metrics
+	return []func(){
+		func() { m.RecordDownloadTime(ComponentCore, 1200) },
+		func() { m.RecordTransfer(ComponentCore, release.Transfer{Bytes: 4 << 20, Duration: time.Second}) },
+		func() { m.RecordCoreStartup(800) },
+		func() { m.RecordInstalled() },
+		func() {
+			m.RecordManifest(name, model.ManifestEntry{Path: name + "/model.onnx", Variant: "model.onnx", SHA256: strings.Repeat("ab", 32), SizeBytes: 1024})
+		},
+		func() { m.RecordModelFormat(name, "onnx") },
+		func() { _ = m.InstalledFormat(name) },
+		func() { m.RecordCacheListing(name, []model.CacheFile{{Path: "model.onnx", SizeBytes: 1024}}) },
+		func() { m.RecordRegistration(name, 150) },
+		func() { m.RecordRegistrationFailure(name+"-broken", "axon register failed") },
+		func() { _ = m.IsRegistered(name) },
+		func() { m.RecordUnregister(name, "") },
+		func() { m.RecordReregister(name, "no-op", "") },
+		func() { m.RecordInference(name, SizeSmall, 40, "success", "") },
+		func() { m.RecordInference(name, SizeLarge, 120, "failed", "inference failed with status 500") },
+		func() { m.RecordInference(name, SizeBatch, 90, "success", "") },
+		func() { m.RecordMetadataCheck(name, nil) },
+		func() { m.RecordResponseBytes(name, SizeSmall, 512) },
+		func() { m.RecordResponseStatus(name, SizeLarge, 413, 413) },
+		func() { _ = m.InferenceStatus(name, SizeSmall) },
+		func() { m.RecordLatencyBudget(name, SizeSmall, 100) },
+		func() { m.RecordSkip(name+"-vision", "vision inference not supported yet", 2) },
+		func() { m.RecordRegistrationConcurrency(2) },
+		func() { m.RecordInferenceParallelism(2) },
+		func() { m.RecordInferenceSizes(name, []string{SizeSmall, SizeLarge}) },
+		func() { m.RecordColdStart(name, 300, 40) },
+		func() { m.RecordBatch(name, 8, 88.5) },
+		func() {
+			m.RecordBatchSweep(name, []BatchSweepPoint{{BatchSize: 1, TimeMs: 40, Throughput: 25}, {BatchSize: 2, Error: "timeout"}}, 0.8)
+		},
+		func() {
+			m.RecordStreaming(name, StreamingResult{Status: "success", Tokens: 12, TimeToFirstTokenMs: 30, MeanInterTokenMs: 5, MaxInterTokenMs: 9, TotalMs: 90})
+		},
+		func() { m.RecordCompression(65536, map[string]int{name: 1}) },
+		func() { m.RecordFuzzSeed(42) },
+		func() { m.RecordFuzz(name, "") },
+		func() { m.RecordDeterminismTolerance(1e-6) },
+		func() { m.RecordDeterminism(name, "deterministic", nil) },
+		func() { m.RecordLatencyTimeseries(name, []float64{40, 41, -1, 39}) },
+		func() { m.RecordCoreBaselineMemory(200) },
+		func() { m.RecordModelMemory(name, 35.5) },
+		func() { m.RecordMemoryStability(10, 235.5, 240, 50) },
+		func() { results.RecordError("inference", name, "inference failed with status 500") },
+	}
+}
+
+func TestWriteMetricsMatchesSchema(t *testing.T) {
+	results := NewResults("v3.1.9", "v6.2.0-alpha")
+	recordSample(results, "gpt2")
+	cfg := &config.Config{MetricsPath: filepath.Join(t.TempDir(), "metrics.json"), ValidateMetrics: true}
+
+	if err := WriteMetrics(results, cfg); err != nil {
+		t.Fatalf("WriteMetrics: %v", err)
+	}
+}
+
+func TestValidateMetricsFileRejectsTypeMismatch(t *testing.T) {
+	results := NewResults("v3.1.9", "v6.2.0-alpha")
+	recordSample(results, "gpt2")
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	if err := WriteMetrics(results, &config.Config{MetricsPath: path}); err != nil {
+		t.Fatalf("WriteMetrics: %v", err)
+	}
+
+	// A registration time written as a string is what a careless type change would produce
+	var doc map[string]interface{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	doc["Metrics"].(map[string]interface{})["ModelRegistrationTimes"] = map[string]interface{}{"gpt2": "150ms"}
+	data, err = json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ValidateMetricsFile(path); err == nil {
+		t.Fatal("ValidateMetricsFile accepted a string registration time")
+	}
+}
@@ -0,0 +1,131 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mlOS-foundation/system-test/internal/config"
+)
+
+// ResolveModels returns the effective model matrix for a configuration:
+// the minimal/default/all-models set, narrowed by --models and --categories
+func ResolveModels(cfg *config.Config) ([]ModelSpec, error) {
+	var models []ModelSpec
+
+	if cfg.MinimalTest {
+		// Minimal test: only one small model for smoke testing
+		models = []ModelSpec{
+			{ID: "hf/distilgpt2@latest", Name: "gpt2", Type: "single", Category: "nlp", Family: "gpt"},
+		}
+	} else {
+		// Essential NLP models (default)
+		models = []ModelSpec{
+			{ID: "hf/distilgpt2@latest", Name: "gpt2", Type: "single", Category: "nlp", Family: "gpt"},
+			{ID: "hf/bert-base-uncased@latest", Name: "bert", Type: "multi", Category: "nlp", Family: "bert"},
+		}
+
+		// Additional models if enabled
+		if cfg.TestAllModels {
+			models = append(models,
+				ModelSpec{ID: "hf/roberta-base@latest", Name: "roberta", Type: "multi", Category: "nlp", Family: "bert"},
+				ModelSpec{ID: "hf/t5-small@latest", Name: "t5", Type: "multi", Category: "nlp", Family: "t5"},
+				ModelSpec{ID: "hf/microsoft/resnet-50@latest", Name: "resnet", Type: "single", Category: "vision", Family: "resnet"},
+				ModelSpec{ID: "hf/timm/vgg16@latest", Name: "vgg", Type: "single", Category: "vision", Family: "vgg"},
+				ModelSpec{ID: "hf/openai/clip-vit-base-patch32@latest", Name: "clip", Type: "multi", Category: "multimodal", Family: "clip"},
+			)
+		}
+	}
+
+	// Narrow by model name/ID, failing on names that match nothing (likely typos)
+	if len(cfg.Models) > 0 {
+		var filtered []ModelSpec
+		for _, want := range cfg.Models {
+			found := false
+			for _, spec := range models {
+				if spec.Name == want || spec.ID == want {
+					filtered = append(filtered, spec)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unknown model in --models: %s (is --all-models needed?)", want)
+			}
+		}
+		models = filtered
+	}
+
+	// Narrow by category
+	if len(cfg.Categories) > 0 {
+		var filtered []ModelSpec
+		for _, spec := range models {
+			for _, category := range cfg.Categories {
+				if spec.Category == category {
+					filtered = append(filtered, spec)
+					break
+				}
+			}
+		}
+		models = filtered
+	}
+
+	if err := checkUniqueNames(models); err != nil {
+		return nil, err
+	}
+	return models, nil
+}
+
+// checkUniqueNames fails if two models in the matrix share a Name
+// Metrics are keyed by name, so such models would silently overwrite each other's results.
+func checkUniqueNames(models []ModelSpec) error {
+	ids := make(map[string][]string)
+	var duplicates []string
+	for _, spec := range models {
+		if len(ids[spec.Name]) == 1 {
+			duplicates = append(duplicates, spec.Name)
+		}
+		ids[spec.Name] = append(ids[spec.Name], spec.ID)
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+	details := make([]string, len(duplicates))
+	for i, name := range duplicates {
+		details[i] = fmt.Sprintf("%s (%s)", name, strings.Join(ids[name], ", "))
+	}
+	return fmt.Errorf("model names must be unique, but the matrix repeats %s", strings.Join(details, "; "))
+}
+
+// PrintModels writes the model matrix as a table (used by list-models)
+func PrintModels(w io.Writer, models []ModelSpec) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tTYPE\tCATEGORY\tFAMILY")
+	for _, spec := range models {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", spec.ID, spec.Name, spec.Type, spec.Category, spec.Family)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d model(s): %s\n", len(models), strings.Join(modelNames(models), ", "))
+	return err
+}
+
+// MatrixModelNames returns the name of every model in the full matrix (--all-models)
+// Per-model settings files are checked against them, e.g. config.LoadLatencyBudgets.
+func MatrixModelNames() ([]string, error) {
+	models, err := ResolveModels(&config.Config{TestAllModels: true})
+	if err != nil {
+		return nil, err
+	}
+	return modelNames(models), nil
+}
+
+func modelNames(models []ModelSpec) []string {
+	names := make([]string, 0, len(models))
+	for _, spec := range models {
+		names = append(names, spec.Name)
+	}
+	return names
+}
@@ -0,0 +1,70 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mlOS-foundation/system-test/internal/config"
+)
+
+func TestCheckUniqueNamesNamesBothIDs(t *testing.T) {
+	models := []ModelSpec{
+		{ID: "hf/distilgpt2@latest", Name: "gpt2"},
+		{ID: "hf/bert-base-uncased@latest", Name: "bert"},
+		{ID: "hf/openai-community/gpt2@latest", Name: "gpt2"},
+	}
+	err := checkUniqueNames(models)
+	if err == nil {
+		t.Fatal("checkUniqueNames accepted two models named gpt2")
+	}
+	if want := "gpt2 (hf/distilgpt2@latest, hf/openai-community/gpt2@latest)"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q does not name both IDs (%s)", err, want)
+	}
+	if strings.Contains(err.Error(), "bert") {
+		t.Errorf("error %q names a model that is not repeated", err)
+	}
+}
+
+func TestResolveModelsDefaultMatricesHaveUniqueNames(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		cfg  config.Config
+	}{
+		{"minimal", config.Config{MinimalTest: true}},
+		{"default", config.Config{}},
+		{"all models", config.Config{TestAllModels: true}},
+	} {
+		cfg := tt.cfg
+		models, err := ResolveModels(&cfg)
+		if err != nil {
+			t.Errorf("%s matrix: %v", tt.name, err)
+			continue
+		}
+		if len(models) == 0 {
+			t.Errorf("%s matrix is empty", tt.name)
+		}
+	}
+}
+
+func TestMatrixModelNamesCoverEveryMatrix(t *testing.T) {
+	names, err := MatrixModelNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	known := make(map[string]bool)
+	for _, name := range names {
+		known[name] = true
+	}
+	for _, cfg := range []config.Config{{MinimalTest: true}, {}, {TestAllModels: true}} {
+		cfg := cfg
+		models, err := ResolveModels(&cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, spec := range models {
+			if !known[spec.Name] {
+				t.Errorf("MatrixModelNames() = %v is missing %s", names, spec.Name)
+			}
+		}
+	}
+}
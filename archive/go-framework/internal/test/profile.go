@@ -0,0 +1,95 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/model"
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// profileFetchSlack is how much longer than the CPU profile itself its request may take
+const profileFetchSlack = 30 * time.Second
+
+// Kinds of Core profiles captured with --profile (keys of Results.CoreProfiles)
+const (
+	ProfileCPU  = "cpu"
+	ProfileHeap = "heap"
+)
+
+// captureProfiles puts Core under sustained inference load and fetches its CPU and heap profiles meanwhile
+// The load replays the small inference of every model that passed it, from MaxParallelInference workers.
+// The profiles are saved next to the report; a Core without pprof endpoints is skipped, not failed.
+func (r *Runner) captureProfiles(results *Results) error {
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("🔬 Profiling Core under load (%ds CPU profile)", r.cfg.ProfileSeconds)
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	results.CoreProfiles = make(map[string]string)
+	var models []ModelSpec
+	for _, spec := range r.getTestModels() {
+		if results.Metrics.InferenceStatus(spec.Name, SizeSmall) == "success" {
+			models = append(models, spec)
+		}
+	}
+	if len(models) == 0 {
+		return fmt.Errorf("no models passed inference, so there is no load to profile")
+	}
+
+	workers := r.cfg.MaxParallelInference
+	if workers < 1 {
+		workers = 1
+	}
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	var requests, failures int64
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				spec := models[i%len(models)]
+				atomic.AddInt64(&requests, 1)
+				if _, err := model.RunInference(spec.ID, spec.Name, spec.Type, false, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(false), r.cfg.InputKey, r.inference); err != nil {
+					atomic.AddInt64(&failures, 1)
+				}
+			}
+		}(w)
+	}
+
+	profiles := []struct {
+		kind, path string
+		timeout    time.Duration
+	}{
+		{ProfileCPU, fmt.Sprintf("/debug/pprof/profile?seconds=%d", r.cfg.ProfileSeconds), time.Duration(r.cfg.ProfileSeconds)*time.Second + profileFetchSlack},
+		{ProfileHeap, "/debug/pprof/heap", profileFetchSlack},
+	}
+	var err error
+	for _, p := range profiles {
+		dest := filepath.Join(r.cfg.TestDir, "core-"+p.kind+".pprof")
+		if err = release.FetchProfile(r.cfg.CoreURL(), p.path, p.timeout, dest, r.releaseOptions()); err != nil {
+			break
+		}
+		results.CoreProfiles[p.kind] = dest
+		log.Printf("✅ Saved Core %s profile to %s", p.kind, dest)
+	}
+	close(stop)
+	wg.Wait()
+	log.Printf("   %d inference requests during profiling (%d failed)", requests, failures)
+
+	if errors.Is(err, release.ErrPprofUnavailable) {
+		log.Printf("ℹ️  %v; no profiles captured", err)
+		return nil
+	}
+	return err
+}
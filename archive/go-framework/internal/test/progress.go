@@ -0,0 +1,58 @@
+package test
+
+import (
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// progress prefixes the start of each pipeline step with an overall [done/total] counter
+// Steps are the release downloads plus install, register and inference per model.
+// A nil or disabled progress prints nothing.
+type progress struct {
+	mu      sync.Mutex
+	total   int
+	started int
+}
+
+// newProgress returns a progress tracker for total steps, or nil if disabled
+// It is also disabled when stderr (where the log goes) is not a terminal, e.g. in CI
+func newProgress(enabled bool, total int) *progress {
+	if !enabled || total <= 0 || !isTerminal(os.Stderr) {
+		return nil
+	}
+	return &progress{total: total}
+}
+
+// begin logs the start of the next step
+func (p *progress) begin(step string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.started++
+	percent := (p.started - 1) * 100 / p.total
+	log.Printf("📊 [%d/%d] %s (%d%% done)", p.started, p.total, step, percent)
+}
+
+// eta logs how long the rest of a phase should take, from the average time per item so far
+func (p *progress) eta(phase string, done, total int, elapsed time.Duration) {
+	if p == nil || done == 0 || done >= total {
+		return
+	}
+	remaining := elapsed / time.Duration(done) * time.Duration(total-done)
+	log.Printf("⏳ %s: %d/%d done, about %s remaining", phase, done, total, release.FormatETA(remaining))
+}
+
+// isTerminal reports whether f is a character device such as a TTY
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
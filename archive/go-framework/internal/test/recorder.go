@@ -0,0 +1,405 @@
+package test
+
+import (
+	"github.com/mlOS-foundation/system-test/internal/model"
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// Inference sizes accepted by the Metrics recorder methods
+const (
+	SizeSmall = "small"
+	SizeLarge = "large"
+	SizeBatch = "batch"
+)
+
+// Download components accepted by RecordDownloadTime and RecordTransfer
+const (
+	ComponentAxon        = "axon"
+	ComponentCore        = "core"
+	ComponentONNXRuntime = "onnxruntime"
+	ComponentConverter   = "converter" // Axon's ONNX converter image (transfers only)
+	ComponentAll         = "all"       // Wall-clock of the whole download phase
+)
+
+// The methods below are the only way the runner writes Metrics. Each one takes
+// the metrics lock, so recording from parallel installs or inferences is safe.
+
+// RecordDownloadTime records how long one release component took to download
+func (m *Metrics) RecordDownloadTime(component string, ms int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch component {
+	case ComponentAxon:
+		m.AxonDownloadTimeMs = ms
+	case ComponentCore:
+		m.CoreDownloadTimeMs = ms
+	case ComponentONNXRuntime:
+		m.ONNXRuntimeDownloadTimeMs = ms
+	case ComponentAll:
+		m.DownloadWallTimeMs = ms
+	}
+}
+
+// RecordTransfer records how many bytes a component download moved and at what rate
+// Cached components (zero bytes) are skipped; repeated transfers of one component add up
+func (m *Metrics) RecordTransfer(component string, t release.Transfer) {
+	if t.Bytes == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DownloadBytes[component] += t.Bytes
+	m.DownloadThroughputMBs[component] = t.MBps()
+}
+
+// RecordCoreStartup records how long Core took to become ready
+func (m *Metrics) RecordCoreStartup(ms int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.CoreStartupTimeMs = ms
+}
+
+// RecordInstalled counts one model as installed (freshly or from cache)
+func (m *Metrics) RecordInstalled() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelsInstalled++
+}
+
+// RecordManifest records the installed file of a model
+func (m *Metrics) RecordManifest(name string, entry model.ManifestEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelManifest[name] = entry
+}
+
+// RecordModelFormat records the format a model was installed in (see model.DetectFormat)
+func (m *Metrics) RecordModelFormat(name, format string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelFormat[name] = format
+}
+
+// InstalledFormat returns the format a model was installed in, or "" if it wasn't detected
+func (m *Metrics) InstalledFormat(name string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ModelFormat[name]
+}
+
+// RecordCacheListing records the files in a model's cache directory
+func (m *Metrics) RecordCacheListing(name string, files []model.CacheFile) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelCacheListing[name] = files
+}
+
+// RecordRegistration records a successful registration
+func (m *Metrics) RecordRegistration(name string, ms int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelRegistrationTimes[name] = ms
+	delete(m.ModelRegistrationErrors, name)
+}
+
+// RecordRegistrationFailure records a failed registration, overriding an earlier success
+func (m *Metrics) RecordRegistrationFailure(name, errMsg string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.ModelRegistrationTimes, name)
+	m.ModelRegistrationErrors[name] = errMsg
+}
+
+// IsRegistered reports whether a model was registered successfully
+func (m *Metrics) IsRegistered(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.ModelRegistrationTimes[name]
+	return ok
+}
+
+// RecordUnregister records a model's unregistration; errMsg is empty on success
+func (m *Metrics) RecordUnregister(name, errMsg string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if errMsg == "" {
+		m.ModelUnregisterStatus[name] = "success"
+		delete(m.ModelUnregisterErrors, name)
+		return
+	}
+	m.ModelUnregisterStatus[name] = "failed"
+	m.ModelUnregisterErrors[name] = errMsg
+}
+
+// Re-registration behaviors accepted by RecordReregister
+const (
+	ReregisterNoOp              = "no-op"              // The second registration succeeded
+	ReregisterAlreadyRegistered = "already-registered" // Core refused it with a well-defined already-registered answer
+)
+
+// RecordReregister records a model's second registration; errMsg is empty on success
+// behavior is how Core answered the request, empty if it failed outright.
+func (m *Metrics) RecordReregister(name, behavior, errMsg string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if behavior != "" {
+		m.ModelReregisterBehavior[name] = behavior
+	}
+	if errMsg == "" {
+		m.ModelReregisterStatus[name] = "success"
+		delete(m.ModelReregisterErrors, name)
+		return
+	}
+	m.ModelReregisterStatus[name] = "failed"
+	m.ModelReregisterErrors[name] = errMsg
+}
+
+// RecordInference records one inference test of the given size
+// ms is kept even for failures when non-zero (e.g. a request that was over its latency budget)
+func (m *Metrics) RecordInference(name, size string, ms int64, status, errMsg string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.TotalInferences++
+	if status == "success" {
+		m.SuccessfulInferences++
+	} else {
+		m.FailedInferences++
+	}
+
+	var times map[string]int64
+	var statuses, errs map[string]string
+	switch size {
+	case SizeSmall:
+		times, statuses, errs = m.ModelInferenceTimes, m.ModelInferenceStatus, m.ModelInferenceErrors
+	case SizeLarge:
+		times, statuses, errs = m.ModelLargeInferenceTimes, m.ModelLargeInferenceStatus, m.ModelLargeInferenceErrors
+	case SizeBatch:
+		times, statuses, errs = m.ModelBatchInferenceTimes, m.ModelBatchInferenceStatus, m.ModelBatchInferenceErrors
+	default:
+		return
+	}
+
+	statuses[name] = status
+	if ms > 0 {
+		times[name] = ms
+	}
+	if errMsg != "" {
+		errs[name] = errMsg
+	}
+}
+
+// RecordMetadataCheck records the outcome of a model's metadata check (no mismatches: it passed)
+func (m *Metrics) RecordMetadataCheck(name string, mismatches []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if mismatches == nil {
+		mismatches = []string{}
+	}
+	m.ModelMetadataMismatches[name] = mismatches
+}
+
+// RecordResponseBytes records the response body size of a model's small or large inference test
+func (m *Metrics) RecordResponseBytes(name, size string, bytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch size {
+	case SizeSmall:
+		m.ModelResponseBytes[name] = bytes
+	case SizeLarge:
+		m.ModelLargeResponseBytes[name] = bytes
+	}
+}
+
+// RecordResponseStatus records the HTTP status a negative-test model's inference had to get and got
+func (m *Metrics) RecordResponseStatus(name, size string, expected, actual int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelExpectedStatus[name] = expected
+	switch size {
+	case SizeSmall:
+		m.ModelResponseStatus[name] = actual
+	case SizeLarge:
+		m.ModelLargeResponseStatus[name] = actual
+	}
+}
+
+// InferenceStatus returns the recorded status of a model's inference test of the given size
+func (m *Metrics) InferenceStatus(name, size string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch size {
+	case SizeSmall:
+		return m.ModelInferenceStatus[name]
+	case SizeLarge:
+		return m.ModelLargeInferenceStatus[name]
+	case SizeBatch:
+		return m.ModelBatchInferenceStatus[name]
+	}
+	return ""
+}
+
+// RecordLatencyBudget records the latency budget applied to a model's small or large inference
+func (m *Metrics) RecordLatencyBudget(name, size string, budgetMs int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if size == SizeLarge {
+		m.ModelLargeInferenceBudgetMs[name] = budgetMs
+	} else {
+		m.ModelInferenceBudgetMs[name] = budgetMs
+	}
+}
+
+// RecordSkip records count inference tests of a model that did not run, and why
+func (m *Metrics) RecordSkip(name, reason string, count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.SkippedInferences += count
+	m.InferenceSkipReasons[name] = reason
+}
+
+// RecordRegistrationConcurrency records how many registrations could run at once
+func (m *Metrics) RecordRegistrationConcurrency(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.RegistrationConcurrency = n
+}
+
+// RecordInferenceParallelism records how many inference tests could run at once
+func (m *Metrics) RecordInferenceParallelism(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.InferenceParallelism = n
+}
+
+// RecordInferenceSizes records which inference sizes a model ran
+func (m *Metrics) RecordInferenceSizes(name string, sizes []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelInferenceSizes[name] = sizes
+}
+
+// RecordColdStart records a model's first-inference latency against its warm average
+func (m *Metrics) RecordColdStart(name string, coldMs, warmMs int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelColdInferenceTimes[name] = coldMs
+	m.ModelWarmInferenceTimes[name] = warmMs
+}
+
+// RecordBatch records the batch size and a model's batched throughput (examples/sec)
+func (m *Metrics) RecordBatch(name string, batchSize int, throughput float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.BatchSize = batchSize
+	if throughput > 0 {
+		m.ModelBatchThroughput[name] = throughput
+	}
+}
+
+// RecordBatchSweep records a model's throughput sweep and its scaling efficiency
+func (m *Metrics) RecordBatchSweep(name string, points []BatchSweepPoint, efficiency float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelBatchSweep[name] = points
+	if efficiency > 0 {
+		m.ModelBatchScaling[name] = efficiency
+	}
+}
+
+// RecordStreaming records a model's streamed inference test
+func (m *Metrics) RecordStreaming(name string, result StreamingResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelStreaming[name] = result
+}
+
+// RecordCompression records the gzip threshold and how many requests per model were compressed
+func (m *Metrics) RecordCompression(thresholdBytes int, counts map[string]int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.GzipThresholdBytes = thresholdBytes
+	for name, n := range counts {
+		m.ModelCompressedRequests[name] = n
+	}
+}
+
+// RecordFuzzSeed records the seed that reproduces the fuzz inputs
+func (m *Metrics) RecordFuzzSeed(seed int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.FuzzSeed = seed
+}
+
+// RecordFuzz records one fuzz request; failure is empty unless it crashed, hit a 5xx or timed out
+// Only the first failure per model is kept
+func (m *Metrics) RecordFuzz(name, failure string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelFuzzRequests[name]++
+	if failure == "" {
+		return
+	}
+	m.ModelFuzzFailures[name]++
+	if _, seen := m.ModelFuzzErrors[name]; !seen {
+		m.ModelFuzzErrors[name] = failure
+	}
+}
+
+// RecordDeterminismTolerance records the tolerance the determinism check compared outputs within
+func (m *Metrics) RecordDeterminismTolerance(tolerance float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DeterminismTolerance = tolerance
+}
+
+// RecordDeterminism records one model's determinism check; details say where the outputs differed
+// ("nondeterministic") or why the check couldn't compare them ("failed")
+func (m *Metrics) RecordDeterminism(name, status string, details []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelDeterminismStatus[name] = status
+	if len(details) > 0 {
+		m.ModelDeterminismDetails[name] = details
+	}
+}
+
+// RecordLatencyTimeseries records the per-request latencies of a model's rolling inference
+func (m *Metrics) RecordLatencyTimeseries(name string, latenciesMs []float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelLatencyTimeseries[name] = latenciesMs
+}
+
+// RecordCoreBaselineMemory records Core's RSS before any model was registered
+func (m *Metrics) RecordCoreBaselineMemory(memoryMB float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.CoreBaselineMemoryMB = memoryMB
+}
+
+// RecordModelMemory records the RSS growth attributed to registering one model
+func (m *Metrics) RecordModelMemory(modelName string, deltaMB float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ModelMemoryMB[modelName] = deltaMB
+}
+
+// RecordMemoryStability records RSS before/after the leak check and returns whether a leak is suspected
+func (m *Metrics) RecordMemoryStability(iterations int, beforeMB, afterMB, thresholdMB float64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.LeakCheckIterations = iterations
+	m.MemoryBeforeMB = beforeMB
+	m.MemoryAfterMB = afterMB
+	m.MemoryGrowthMB = afterMB - beforeMB
+	m.MemoryLeakSuspected = m.MemoryGrowthMB > thresholdMB
+	return m.MemoryLeakSuspected
+}
+
+// RecordError adds a failure to Results.Errors; model is empty for run-wide failures
+func (r *Results) RecordError(stage, model, message string) {
+	r.errMu.Lock()
+	defer r.errMu.Unlock()
+	r.Errors = append(r.Errors, RunError{Stage: stage, Model: model, Message: message})
+}
@@ -0,0 +1,67 @@
+package test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestRecordersAreConcurrencySafe hammers every recorder from many goroutines; run it with -race
+// Each goroutine sticks to one recorder: one calling them all in turn would be ordered by the
+// locks of the others, and the race detector would miss a recorder that forgot to lock.
+func TestRecordersAreConcurrencySafe(t *testing.T) {
+	const workers, rounds = 8, 50
+	results := NewResults("v3.1.9", "v6.2.0-alpha")
+	recorders := len(sampleRecorders(results, ""))
+
+	var wg sync.WaitGroup
+	for k := 0; k < recorders; k++ {
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(k, w int) {
+				defer wg.Done()
+				for i := 0; i < rounds; i++ {
+					// Workers share names, so the same map entries are written concurrently too
+					sampleRecorders(results, fmt.Sprintf("model-%d", i))[k]()
+				}
+			}(k, w)
+		}
+	}
+	wg.Wait()
+
+	m := results.Metrics
+	if got, want := m.TotalInferences, 3*workers*rounds; got != want {
+		t.Errorf("TotalInferences = %d, want %d", got, want)
+	}
+	if got := len(m.ModelRegistrationTimes); got != rounds {
+		t.Errorf("%d registration times, want %d", got, rounds)
+	}
+	if got := len(m.ModelInferenceStatus); got != rounds {
+		t.Errorf("%d small inference statuses, want %d", got, rounds)
+	}
+	if got := len(m.ModelLatencyTimeseries); got != rounds {
+		t.Errorf("%d latency timeseries, want %d", got, rounds)
+	}
+	if got, want := len(results.Errors), workers*rounds; got != want {
+		t.Errorf("%d errors, want %d", got, want)
+	}
+}
+
+func TestRecordInferenceStoresErrorsForEverySize(t *testing.T) {
+	m := NewMetrics()
+	errs := map[string]map[string]string{
+		SizeSmall: m.ModelInferenceErrors,
+		SizeLarge: m.ModelLargeInferenceErrors,
+		SizeBatch: m.ModelBatchInferenceErrors,
+	}
+	for size, stored := range errs {
+		m.RecordInference("gpt2", size, 0, "failed", size+" inference failed with status 500")
+		m.RecordInference("bert", size, 30, "success", "")
+		if got, want := stored["gpt2"], size+" inference failed with status 500"; got != want {
+			t.Errorf("%s: stored error %q, want %q", size, got, want)
+		}
+		if got, ok := stored["bert"]; ok {
+			t.Errorf("%s: stored error %q for a success", size, got)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package test
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/model"
+)
+
+// writeSession saves the inference exchanges captured during the inference tests to cfg.RecordPath
+func (r *Runner) writeSession() error {
+	session := model.Session{
+		CoreVersion: r.cfg.CoreVersion,
+		RecordedAt:  time.Now(),
+		Exchanges:   r.inference.Recorder.Exchanges(),
+	}
+	if err := model.WriteSession(r.cfg.RecordPath, session); err != nil {
+		return err
+	}
+	log.Printf("⏺️  Recorded %d inference requests to %s", len(session.Exchanges), r.cfg.RecordPath)
+	return nil
+}
+
+// runReplay resends every request of the session loaded from cfg.ReplayPath to this Core
+// and records where the responses diverge from the recorded ones
+func (r *Runner) runReplay(results *Results, session model.Session) error {
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("⏪ Replaying Recorded Session")
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("   Session: %s (%d requests, recorded against Core %s)",
+		r.cfg.ReplayPath, len(session.Exchanges), session.CoreVersion)
+
+	names := make(map[string]string)
+	for _, spec := range r.getTestModels() {
+		names[spec.ID] = spec.Name
+	}
+
+	replay := &ReplayResult{
+		SessionPath:         r.cfg.ReplayPath,
+		RecordedCoreVersion: session.CoreVersion,
+		Requests:            len(session.Exchanges),
+	}
+	results.Replay = replay
+
+	for i, exchange := range session.Exchanges {
+		name, ok := names[exchange.ModelID]
+		if !ok {
+			name = exchange.ModelID
+		}
+		// Large recorded requests get the large timeout; we can't tell which were large
+		details := model.ReplayExchange(exchange, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(true), r.inference)
+		if len(details) == 0 {
+			replay.Matched++
+			continue
+		}
+		replay.Divergences = append(replay.Divergences, ReplayDivergence{
+			Model:   name,
+			Request: i,
+			Path:    exchange.Path,
+			Details: details,
+		})
+		results.RecordError("replay", name, fmt.Sprintf("request %d diverged: %s", i, strings.Join(details, "; ")))
+		log.Printf("ERROR: Replayed request %d (%s) diverged: %s", i, name, strings.Join(details, "; "))
+	}
+
+	if len(replay.Divergences) == 0 {
+		log.Printf("✅ All %d replayed responses match the recording", replay.Requests)
+	} else {
+		log.Printf("⚠️  %d of %d replayed responses diverged from the recording", len(replay.Divergences), replay.Requests)
+	}
+	return nil
+}
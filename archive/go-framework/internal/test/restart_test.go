@@ -0,0 +1,176 @@
+package test
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/mlOS-foundation/system-test/internal/config"
+	"github.com/mlOS-foundation/system-test/internal/monitor"
+	"github.com/mlOS-foundation/system-test/internal/release"
+)
+
+// fakeCoreEnv makes the test binary run as a stand-in Core server instead of the tests (see runFakeCore)
+const fakeCoreEnv = "SYSTEM_TEST_FAKE_CORE"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(fakeCoreEnv) == "1" {
+		runFakeCore()
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// runFakeCore serves /health like Core, on the --http-port StartCore passes, and exits with
+// status 1 (a crash) when /crash is requested
+func runFakeCore() {
+	flags := flag.NewFlagSet("mlos_core", flag.ExitOnError)
+	port := flags.Int("http-port", 0, "")
+	_ = flags.Parse(os.Args[1:])
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"status":"healthy"}`)
+	})
+	mux.HandleFunc("/crash", func(http.ResponseWriter, *http.Request) {
+		fmt.Fprintln(os.Stderr, "fake core: crashing on request")
+		os.Exit(1)
+	})
+	fmt.Printf("fake core listening on port %d\n", *port)
+	if err := http.ListenAndServe(net.JoinHostPort("127.0.0.1", strconv.Itoa(*port)), mux); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
+
+// fakeCoreRunner returns a Runner with auto-restart on whose Core is the test binary run as a fake Core
+func fakeCoreRunner(t *testing.T) *Runner {
+	t.Helper()
+	testBinary, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Lay it out like a Core checkout (build/mlos_core) with the ONNX Runtime the binary names
+	root := t.TempDir()
+	libDir := filepath.Join(root, "build", "onnxruntime", "lib")
+	if err := os.MkdirAll(libDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	libName := "libonnxruntime.1.18.0.dylib"
+	if runtime.GOOS == "linux" {
+		libName = "libonnxruntime.1.18.0.so"
+	}
+	if err := os.WriteFile(filepath.Join(libDir, libName), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	binary := filepath.Join(root, "build", "mlos_core")
+	if err := os.Symlink(testBinary, binary); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(fakeCoreEnv, "1")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	if err := listener.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		CoreVersion:     "v0.0.0-test",
+		CoreBinary:      binary,
+		OutputDir:       t.TempDir(),
+		Host:            "127.0.0.1",
+		CorePort:        port,
+		AutoRestartCore: true,
+		MaxCoreRestarts: 3,
+	}
+	return &Runner{cfg: cfg}
+}
+
+// TestAutoRestartWithConcurrentCrashChecks restarts a crashed Core while other goroutines, like
+// parallel inference jobs, keep checking it for a crash. Run with -race.
+func TestAutoRestartWithConcurrentCrashChecks(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("StartCore only runs Core natively on Linux and macOS")
+	}
+	r := fakeCoreRunner(t)
+	results := NewResults("v0.0.0-test", r.cfg.CoreVersion)
+	if _, err := r.startCore(results); err != nil {
+		t.Fatalf("startCore: %v", err)
+	}
+	t.Cleanup(func() {
+		if process := r.core(); process != nil {
+			_ = monitor.StopProcess(process)
+			_ = process.Wait()
+		}
+	})
+
+	const workers = 8
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					r.logCoreOutputIfCrashed()
+				}
+			}
+		}()
+	}
+
+	const restarts = 2
+	for i := 0; i < restarts; i++ {
+		crashed := r.core()
+		if crashed == nil {
+			t.Fatalf("restart %d: no Core running", i)
+		}
+
+		// Reap it the way injectKillCore does, while the workers are checking it
+		reaped := make(chan error, 1)
+		go func() { reaped <- crashed.Wait() }()
+		if resp, err := http.Get(r.cfg.CoreURL() + "/crash"); err == nil {
+			resp.Body.Close()
+		}
+		if err := <-reaped; err == nil {
+			t.Fatalf("restart %d: fake Core exited cleanly, want a crash", i)
+		}
+		if !crashed.Exited() {
+			t.Fatalf("restart %d: Exited() = false after the process exited", i)
+		}
+
+		r.ensureCoreRunning(results, fmt.Sprintf("model-%d", i))
+		if process := r.core(); process == nil || process == crashed {
+			t.Fatalf("restart %d: Core was not replaced", i)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	if len(results.CoreRestarts) != restarts {
+		t.Fatalf("recorded %d restarts, want %d", len(results.CoreRestarts), restarts)
+	}
+	for _, event := range results.CoreRestarts {
+		if event.Error != "" {
+			t.Errorf("restart before %s failed: %s", event.BeforeModel, event.Error)
+		}
+	}
+	if err := release.CheckCoreHealth(r.cfg.CoreURL(), r.releaseOptions()); err != nil {
+		t.Errorf("Core is not healthy after the restarts: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// StageError is a failure that aborted Run, with the pipeline stage it happened in
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// infraStages are the stages whose failure is the environment's (a flaky download, a port, Core
+// not coming up) rather than a test result. Installing and registering models are not among them:
+// they record each model's failure in the results and carry on, so they never abort Run.
+var infraStages = map[string]bool{
+	"download": true,
+	"start":    true,
+}
+
+// IsInfraFailure reports whether err aborted Run while downloading releases or starting Core
+// Such runs may be retried; every other failure is a real result and never is.
+func IsInfraFailure(err error) bool {
+	var stageErr *StageError
+	return errors.As(err, &stageErr) && infraStages[stageErr.Stage]
+}
+
+// pipelineRetryDelay is the wait before the first pipeline retry; it doubles with each further one
+const pipelineRetryDelay = 10 * time.Second
+
+// RunWithRetries is Run, repeated up to cfg.PipelineRetries more times while it fails on
+// infrastructure (see IsInfraFailure). The successful attempt's results list the failures retried.
+func (r *Runner) RunWithRetries() (*Results, error) {
+	var retried []PipelineRetry
+	delay := pipelineRetryDelay
+	for attempt := 0; ; attempt++ {
+		results, err := r.Run()
+		if err == nil {
+			results.PipelineRetries = retried
+			return results, nil
+		}
+		if attempt >= r.cfg.PipelineRetries || !IsInfraFailure(err) {
+			return results, err
+		}
+
+		var stageErr *StageError
+		errors.As(err, &stageErr)
+		retried = append(retried, PipelineRetry{Time: time.Now(), Stage: stageErr.Stage, Error: err.Error()})
+		log.Printf("WARN: Run failed in the %s stage (%v); retrying the pipeline in %s (retry %d of %d)",
+			stageErr.Stage, err, delay, attempt+1, r.cfg.PipelineRetries)
+		time.Sleep(delay)
+		delay *= 2
+
+		// Run leaves no Core running when it fails, but start the next attempt from a clean slate
+		r.setCore(nil)
+		r.extraCores = nil
+	}
+}
+
+// stageFailure wraps a fatal error of Run with its stage
+func stageFailure(stage, action string, err error) error {
+	return &StageError{Stage: stage, Err: fmt.Errorf("%s: %w", action, err)}
+}
@@ -0,0 +1,28 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsInfraFailureOnlyForStagesThatAbortRun(t *testing.T) {
+	for _, tt := range []struct {
+		stage string
+		want  bool
+	}{
+		{"download", true},
+		{"start", true},
+		{"install", false},
+		{"register", false},
+		{"inference", false},
+	} {
+		err := fmt.Errorf("run: %w", stageFailure(tt.stage, "failed", errors.New("boom")))
+		if got := IsInfraFailure(err); got != tt.want {
+			t.Errorf("IsInfraFailure(%s failure) = %v, want %v", tt.stage, got, tt.want)
+		}
+	}
+	if IsInfraFailure(errors.New("boom")) {
+		t.Error("IsInfraFailure is true for an error without a stage")
+	}
+}
@@ -1,11 +1,17 @@
 package test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math/rand"
+	"net/http"
 	"os"
-	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mlOS-foundation/system-test/internal/config"
@@ -13,107 +19,671 @@ import (
 	"github.com/mlOS-foundation/system-test/internal/model"
 	"github.com/mlOS-foundation/system-test/internal/monitor"
 	"github.com/mlOS-foundation/system-test/internal/release"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
 )
 
+// maxConcurrentDownloads caps how many release artifacts are fetched at once
+const maxConcurrentDownloads = 3
+
+// SubLinearScalingThreshold is the batch scaling efficiency below which batching is reported as limited
+const SubLinearScalingThreshold = 0.8
+
+// thermalSampleInterval is how often the thermal state is read with --monitor-thermal
+const thermalSampleInterval = 2 * time.Second
+
+// modelMemorySampleDuration is how long Core's RSS is averaged over around each registration
+const modelMemorySampleDuration = time.Second
+
 // Runner executes E2E tests
 type Runner struct {
 	cfg         *config.Config
-	coreProcess *monitor.Process
+	coreMu      sync.Mutex         // Guards coreProcess, which inference goroutines read while a restart replaces it
+	coreProcess *monitor.Process   // Use core and setCore
+	extraCores  []*monitor.Process // Isolation-test instances, aligned with Results.CoreInstances (nil if not started)
+	models      []ModelSpec        // Resolved model matrix for this run
+	progress    *progress          // Overall step counter (nil unless --progress on a terminal)
+
+	// Settings of every inference request: from cfg in NewRunner, completed for the model matrix in Run
+	inference model.InferenceOptions
+
+	// OpenTelemetry spans of the stages and inference tests (no-op unless --otlp-endpoint; see newTracer)
+	tracer         trace.Tracer
+	tracerProvider *sdktrace.TracerProvider // nil when not exporting
+	traceCtx       context.Context          // Span of the current run, parent of its stages
+	stageCtx       context.Context          // Span of the stage running now, parent of its inference tests
 }
 
 // NewRunner creates a new test runner
 func NewRunner(cfg *config.Config) *Runner {
-	return &Runner{cfg: cfg}
+	inference := model.InferenceOptions{PathTemplate: cfg.InferencePathTemplate, VerboseHTTP: cfg.VerboseHTTP}
+	if cfg.GzipRequests {
+		inference.GzipThreshold = cfg.GzipThresholdBytes
+	}
+	if cfg.APIStyle == config.APIStyleOpenAI {
+		// The OpenAI routes take text, so --prompt is sent as is
+		inference.APIStyle = model.APIStyleOpenAI
+		if cfg.Prompt != "" {
+			inference.OpenAIPrompt = cfg.Prompt
+			inference.OpenAILargePrompt = strings.TrimSpace(strings.Repeat(cfg.Prompt+" ", largePromptRepeats))
+		}
+	}
+	tracer, tracerProvider := newTracer(cfg)
+	r := &Runner{cfg: cfg, inference: inference, tracer: tracer, tracerProvider: tracerProvider}
+	r.inference.Release = r.releaseOptions() // Inference requests talk to Core like every other request
+	return r
 }
 
 // Run executes all E2E tests and returns results
 func (r *Runner) Run() (*Results, error) {
+	models, err := ResolveModels(r.cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.models = models
+	r.progress = newProgress(r.cfg.Progress, r.countSteps())
+
+	// Inference requests address models by ID, so key the per-model headers by it
+	r.inference.Headers = r.cfg.InferenceHeaders
+	r.inference.ModelHeaders = make(map[string]map[string]string)
+	for _, spec := range models {
+		if headers, ok := r.cfg.ModelHeaders[spec.Name]; ok {
+			r.inference.ModelHeaders[spec.ID] = headers
+		}
+	}
+
+	// Count this run's gzipped requests from zero, not on top of an earlier run's
+	r.inference.Compressed = &model.CompressionCounts{}
+
+	// Negative tests expect another status than 200; requests address models by ID here too
+	r.inference.ExpectedStatuses = make(map[string]int)
+	for _, spec := range models {
+		if status, ok := r.cfg.ExpectedStatuses[spec.Name]; ok {
+			r.inference.ExpectedStatuses[spec.ID] = status
+		}
+	}
+
 	results := NewResults(r.cfg.AxonVersion, r.cfg.CoreVersion)
 	results.StartTime = time.Now()
+	results.Models = models
+	results.Harness = r.cfg.Harness
+	runSpan := r.startRunSpan()
+	defer r.endRunSpan(runSpan, results)
 
 	log.Printf("🚀 Starting MLOS Release E2E Validation")
+	log.Printf("   Harness: %s", r.cfg.Harness)
 	log.Printf("   Axon: %s", r.cfg.AxonVersion)
 	log.Printf("   Core: %s", r.cfg.CoreVersion)
+	if r.cfg.CoreBinary != "" {
+		log.Printf("   Core binary: %s (local build, not downloaded)", r.cfg.CoreBinary)
+		results.CoreBinary = r.cfg.CoreBinary
+	}
+
+	results.APIStyle = config.APIStyleNative
+	if r.cfg.APIStyle != "" {
+		results.APIStyle = r.cfg.APIStyle
+	}
+	if results.APIStyle == config.APIStyleOpenAI {
+		log.Printf("   API style: OpenAI-compatible (/v1/completions, /v1/chat/completions)")
+	} else {
+		results.InferencePath = model.DefaultInferencePathTemplate
+		if r.cfg.InferencePathTemplate != "" {
+			results.InferencePath = r.cfg.InferencePathTemplate
+			log.Printf("   Inference path: %s", results.InferencePath)
+		}
+	}
+
+	// A lenient readiness check passes a Core that answers 404 everywhere, so record which one ran
+	results.HealthCheckMode = release.HealthCheckMode(r.releaseOptions())
+	if r.cfg.StrictHealth {
+		log.Printf("   Health check: strict (/health must return 200 with %s)", r.cfg.HealthBody)
+	}
+	if len(r.cfg.InferenceHeaders) > 0 || len(r.cfg.ModelHeaders) > 0 {
+		// Only names: values are often credentials
+		log.Printf("   Inference headers: %s", strings.Join(r.inferenceHeaderNames(), ", "))
+	}
+	if r.cfg.ConverterDigest != "" {
+		log.Printf("   Converter image: pinned to %s", r.cfg.ConverterDigest)
+	}
+	if r.tracerProvider != nil {
+		log.Printf("   Tracing: exporting spans to %s", r.cfg.OTLPEndpoint)
+	}
+	if len(r.cfg.InstallOnlyCategories) > 0 {
+		log.Printf("   Install only: %s (installed and registered, no inference)", strings.Join(r.cfg.InstallOnlyCategories, ", "))
+	}
+	if len(r.cfg.InjectFailures) > 0 {
+		log.Printf("   Failure injection: %s (Core will be broken on purpose)", strings.Join(r.cfg.InjectFailures, ", "))
+	}
+	if r.cfg.RecordPath != "" {
+		log.Printf("   Recording inference requests to %s", r.cfg.RecordPath)
+	}
+
+	// A bad session file should fail the run before anything is downloaded
+	var replaySession model.Session
+	if r.cfg.ReplayPath != "" {
+		replaySession, err = model.LoadSession(r.cfg.ReplayPath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot replay: %w", err)
+		}
+		log.Printf("   Replaying %d recorded requests from %s", len(replaySession.Exchanges), r.cfg.ReplayPath)
+	}
+
+	// Environment variables change behavior silently, so the run records which were set
+	results.Environment = config.EnvironmentSummary()
+	if len(results.Environment) > 0 {
+		log.Printf("   Environment:")
+		for _, v := range config.RecognizedEnvVars {
+			if value, ok := results.Environment[v.Name]; ok {
+				log.Printf("     %s=%s", v.Name, value)
+			}
+		}
+	}
+
+	// Fail now rather than have Core or the converter OOM-killed halfway through
+	if err := r.checkMemory(); err != nil {
+		return nil, err
+	}
+
+	// Step 0: Score the machine, so timings can be compared across runner hardware
+	// It runs before anything else loads the machine.
+	if r.cfg.NormalizeTimings {
+		_ = r.stage(results, "baseline", func() error {
+			results.PerformanceIndex = hardware.MeasurePerformanceIndex()
+			log.Printf("📏 Performance index: %.2f (matrix-multiply baseline; 1.0 is %.1f GFLOPS)", results.PerformanceIndex, hardware.ReferenceGFLOPS)
+			return nil
+		})
+	}
 
 	// Step 1: Download releases
 	if !r.cfg.SkipInstall {
-		if err := r.downloadReleases(results); err != nil {
-			return nil, fmt.Errorf("failed to download releases: %w", err)
+		if err := r.stage(results, "download", func() error {
+			return r.downloadReleases(results)
+		}); err != nil {
+			return nil, stageFailure("download", "failed to download releases", err)
 		}
 	}
 
 	// Step 2: Install models
-	if err := r.installModels(results); err != nil {
-		return nil, fmt.Errorf("failed to install models: %w", err)
+	if err := r.stage(results, "install", func() error {
+		return r.installModels(results)
+	}); err != nil {
+		return nil, stageFailure("install", "failed to install models", err)
 	}
 
 	// Step 3: Start MLOS Core
-	coreProcess, err := r.startCore(results)
-	if err != nil {
-		return nil, fmt.Errorf("failed to start Core: %w", err)
+	// r.core() returns the live process, which changes if the watchdog restarts Core
+	if err := r.stage(results, "start", func() error {
+		_, err := r.startCore(results)
+		return err
+	}); err != nil {
+		return nil, stageFailure("start", "failed to start Core", err)
 	}
 	defer func() {
-		if coreProcess != nil {
+		if process := r.core(); process != nil {
 			log.Printf("WARN: Cleaning up...")
-			if err := monitor.StopProcess(coreProcess); err != nil {
+			if err := monitor.StopProcess(process); err != nil {
 				log.Printf("WARN: Failed to stop Core process: %v", err)
 			}
 		}
 	}()
 
-	// Step 4: Collect hardware specs
-	if err := r.collectHardwareSpecs(results); err != nil {
-		log.Printf("WARN: Failed to collect hardware specs: %v", err)
+	if r.core() == nil {
+		log.Printf("WARN: Core process is not managed by this run; skipping resource monitoring and memory stability checks")
+	}
+
+	// Step 3b: Start additional Core instances for isolation tests
+	if r.cfg.CoreInstances > 1 {
+		if r.cfg.UsesExternalCore() {
+			log.Printf("WARN: Multiple Core instances need a Core started by this run; ignoring %d extra instances", r.cfg.CoreInstances-1)
+		} else {
+			_ = r.stage(results, "start-instances", func() error {
+				r.startExtraCores(results)
+				return nil
+			})
+		}
 	}
+	defer func() {
+		for i, process := range r.extraCores {
+			if process == nil {
+				continue
+			}
+			if err := monitor.StopProcess(process); err != nil {
+				log.Printf("WARN: Failed to stop Core instance %d: %v", i+1, err)
+			}
+		}
+	}()
 
-	// Step 5: Monitor resources (idle)
-	if err := r.monitorResources(results, coreProcess, false); err != nil {
-		log.Printf("WARN: Failed to monitor idle resources: %v", err)
+	// Steps 4 and 5: Collect hardware specs while Core's idle usage is sampled
+	// Both mostly wait (on the commands hardware.Collect runs, and out the fixed sampling window), so they overlap
+	preInference := []parallelStage{{"hardware", func() error {
+		return r.collectHardwareSpecs(results)
+	}}}
+	if process := r.core(); process != nil {
+		preInference = append(preInference, parallelStage{"monitor-idle", func() error {
+			return r.monitorResources(results, process, false)
+		}})
+	}
+	preInferenceErrs := r.parallelStages(results, preInference)
+	if err := preInferenceErrs[0]; err != nil {
+		log.Printf("WARN: Failed to collect hardware specs: %v", err)
+	}
+	if len(preInferenceErrs) > 1 && preInferenceErrs[1] != nil {
+		log.Printf("WARN: Failed to monitor idle resources: %v", preInferenceErrs[1])
 	}
 
 	// Step 6: Register models
-	if err := r.registerModels(results); err != nil {
-		return nil, fmt.Errorf("failed to register models: %w", err)
+	if err := r.stage(results, "register", func() error {
+		return r.registerModels(results)
+	}); err != nil {
+		return nil, stageFailure("register", "failed to register models", err)
+	}
+
+	// Step 6b: Confirm Core actually lists what axon reported as registered
+	if err := r.stage(results, "verify-registration", func() error {
+		return r.verifyRegistrations(results)
+	}); err != nil {
+		log.Printf("WARN: Failed to verify registrations: %v", err)
+	}
+
+	// Thermal throttling during the timed phases would skew every comparison
+	var thermal *hardware.ThermalMonitor
+	if r.cfg.MonitorThermal {
+		thermal = hardware.StartThermalMonitor(thermalSampleInterval)
+		defer thermal.Stop()
 	}
 
 	// Step 7: Run inference tests
-	if err := r.runInferenceTests(results); err != nil {
-		return nil, fmt.Errorf("failed to run inference tests: %w", err)
+	// --record captures this phase only; the checks after it send other traffic on purpose
+	r.inference.Recorder = nil
+	if r.cfg.RecordPath != "" {
+		r.inference.Recorder = &model.Recorder{}
+	}
+	err = r.stage(results, "inference", func() error {
+		return r.runInferenceTests(results)
+	})
+	r.inference.Recorder.Stop()
+	if err != nil {
+		return nil, stageFailure("inference", "failed to run inference tests", err)
+	}
+	r.recordExecutionProvider(results)
+
+	// Step 7a: Repeat inference on the additional Core instances
+	if len(r.extraCores) > 0 {
+		if err := r.stage(results, "isolation", func() error {
+			return r.runIsolationTests(results)
+		}); err != nil {
+			log.Printf("WARN: Failed to run isolation tests: %v", err)
+		}
+	}
+
+	// Step 7b: Characterize throughput scaling across batch sizes
+	if len(r.cfg.BatchSweepSizes) > 0 {
+		if err := r.stage(results, "batch-sweep", func() error {
+			return r.runBatchSweep(results)
+		}); err != nil {
+			log.Printf("WARN: Failed to run batch-size sweep: %v", err)
+		}
+	}
+
+	// Step 7c: Check Core memory stays stable across repeated inference
+	if process := r.core(); r.cfg.LeakCheckIterations > 0 && process != nil {
+		if err := r.stage(results, "memory-stability", func() error {
+			return r.checkMemoryStability(results, process)
+		}); err != nil {
+			log.Printf("WARN: Failed to check memory stability: %v", err)
+		}
+	}
+
+	// Step 7d: Fuzz Core with randomized but valid inputs
+	if r.cfg.FuzzIterations > 0 {
+		if err := r.stage(results, "fuzz", func() error {
+			return r.runFuzzInference(results)
+		}); err != nil {
+			log.Printf("WARN: Failed to run fuzz inference: %v", err)
+		}
+	}
+
+	// Step 7e: Check identical input gives identical outputs
+	if r.cfg.CheckDeterminism {
+		if results.APIStyle == config.APIStyleOpenAI {
+			log.Printf("WARN: The determinism check compares native inference outputs; skipping it with the OpenAI API style")
+		} else if err := r.stage(results, "determinism", func() error {
+			return r.checkDeterminism(results)
+		}); err != nil {
+			log.Printf("WARN: Failed to check determinism: %v", err)
+		}
+	}
+
+	// Step 7f: Time every request of a rolling inference run to expose warmup and drift
+	if r.cfg.RecordTimeseries {
+		if err := r.stage(results, "timeseries", func() error {
+			return r.recordTimeseries(results)
+		}); err != nil {
+			log.Printf("WARN: Failed to record inference timeseries: %v", err)
+		}
+	}
+
+	// Step 7g: Resend a recorded session's requests and compare the responses
+	if r.cfg.ReplayPath != "" {
+		if err := r.stage(results, "replay", func() error {
+			return r.runReplay(results, replaySession)
+		}); err != nil {
+			log.Printf("WARN: Failed to replay session: %v", err)
+		}
 	}
 
 	// Step 8: Monitor resources (under load)
-	if err := r.monitorResources(results, coreProcess, true); err != nil {
-		log.Printf("WARN: Failed to monitor resources under load: %v", err)
+	if process := r.core(); process != nil {
+		if err := r.stage(results, "monitor-load", func() error {
+			return r.monitorResources(results, process, true)
+		}); err != nil {
+			log.Printf("WARN: Failed to monitor resources under load: %v", err)
+		}
+	}
+
+	// Step 8a: Profile Core under sustained load
+	if r.cfg.Profile {
+		if err := r.stage(results, "profile", func() error {
+			return r.captureProfiles(results)
+		}); err != nil {
+			log.Printf("WARN: Failed to profile Core: %v", err)
+		}
+	}
+
+	if thermal != nil {
+		r.recordThermal(results, thermal.Stop())
+	}
+
+	// Step 8b: Register every model again; Core should treat it as a no-op
+	if r.cfg.TestReregister {
+		if err := r.stage(results, "reregister", func() error {
+			return r.testReregistration(results)
+		}); err != nil {
+			log.Printf("WARN: Failed to test re-registration: %v", err)
+		}
+	}
+
+	// Step 8c: Break things on purpose and check the harness copes (never on by default)
+	if len(r.cfg.InjectFailures) > 0 {
+		if err := r.stage(results, "inject-failures", func() error {
+			return r.runFailureInjection(results)
+		}); err != nil {
+			log.Printf("WARN: Failed to run failure injection: %v", err)
+		}
+	}
+
+	// Step 9: Tear down registrations through Core's lifecycle-cleanup path
+	if r.cfg.TestUnregister {
+		if err := r.stage(results, "unregister", func() error {
+			return r.unregisterModels(results)
+		}); err != nil {
+			log.Printf("WARN: Failed to test unregistration: %v", err)
+		}
+	}
+
+	if r.cfg.GzipRequests {
+		r.recordCompression(results)
+	}
+
+	if r.cfg.RecordPath != "" {
+		if err := r.writeSession(); err != nil {
+			log.Printf("WARN: Failed to write recorded session: %v", err)
+		}
 	}
 
+	r.recordCoreOutput(results)
+
 	// Calculate final metrics
 	results.EndTime = time.Now()
 	results.Duration = results.EndTime.Sub(results.StartTime)
 	results.SuccessRate = r.calculateSuccessRate(results)
+	r.checkRequiredCategories(results)
+	r.checkGate(results)
 
 	return results, nil
 }
 
+// Cleanup removes downloaded artifacts according to cfg.Cleanup and cfg.CleanupModels, after
+// auditing the run's temporary files (see auditTempFiles).
+// Callers defer it right after NewRunner, so it runs after the report is written
+// and even when the run failed. Reports, metrics.json and logs are never removed.
+func (r *Runner) Cleanup() {
+	r.auditTempFiles()
+	if !r.cfg.Cleanup && !r.cfg.CleanupModels {
+		return
+	}
+
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("🧹 Cleaning Up")
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if r.cfg.Cleanup {
+		freed, err := release.RemoveArtifacts(r.cfg.OutputDir)
+		if err != nil {
+			log.Printf("WARN: %v", err)
+		}
+		log.Printf("✅ Removed downloaded artifacts from %s (%.1f MB freed)", r.cfg.OutputDir, float64(freed)/(1024*1024))
+	}
+	if r.cfg.CleanupModels {
+		if err := model.PurgeCache(r.cfg.AxonCacheDir); err != nil {
+			log.Printf("WARN: %v", err)
+		} else {
+			log.Printf("✅ Purged Axon model cache")
+		}
+	}
+}
+
+// auditTempFiles reports the temporary files the run left behind, and removes them with --cleanup-temp
+// Downloads clean up after themselves when they succeed; a failed or interrupted one can leave
+// a partial converter image of several GB, which adds up on long-lived CI runners.
+func (r *Runner) auditTempFiles() {
+	leftovers, err := release.AuditTempFiles(r.cfg.CleanupTemp)
+	if err != nil {
+		log.Printf("WARN: %v", err)
+	}
+	if len(leftovers) == 0 {
+		return
+	}
+	var total int64
+	for _, leftover := range leftovers {
+		total += leftover.Bytes
+	}
+	switch {
+	case !r.cfg.CleanupTemp:
+		log.Printf("WARN: This run left %d temporary file(s) behind (%.1f MB); --cleanup-temp removes them", len(leftovers), float64(total)/(1024*1024))
+	case err == nil:
+		log.Printf("🧹 Removed %d temporary file(s) left behind by this run (%.1f MB freed)", len(leftovers), float64(total)/(1024*1024))
+	}
+	for _, leftover := range leftovers {
+		log.Printf("   %s (%.1f MB)", leftover.Path, float64(leftover.Bytes)/(1024*1024))
+	}
+}
+
+// checkMemory fails if the machine has less available memory than the run needs (--min-memory-mb)
+func (r *Runner) checkMemory() error {
+	required := r.cfg.MinMemoryRequiredMB()
+	if required == 0 {
+		return nil
+	}
+	totalMB, availableMB, err := monitor.SystemMemory()
+	if err != nil {
+		log.Printf("WARN: Could not check memory (%v); skipping the %d MB minimum", err, required)
+		return nil
+	}
+	what, haveMB := "available", availableMB
+	if availableMB < 0 {
+		what, haveMB = "total", totalMB
+	}
+	if haveMB < float64(required) {
+		scope := ""
+		if r.cfg.TestAllModels && r.cfg.MinMemoryMB == 0 {
+			scope = " for --all-models"
+		}
+		return fmt.Errorf("insufficient memory: %.0f MB %s of %.0f MB, need at least %d MB%s (free memory, use a larger runner, or lower --min-memory-mb)",
+			haveMB, what, totalMB, required, scope)
+	}
+	log.Printf("   Memory: %.0f MB %s of %.0f MB (minimum %d MB)", haveMB, what, totalMB, required)
+	return nil
+}
+
+// inferenceHeaderNames lists the configured inference headers, per-model ones tagged with their model
+func (r *Runner) inferenceHeaderNames() []string {
+	var names []string
+	for key := range r.cfg.InferenceHeaders {
+		names = append(names, key)
+	}
+	for name, headers := range r.cfg.ModelHeaders {
+		for key := range headers {
+			names = append(names, fmt.Sprintf("%s (%s)", key, name))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// countSteps returns how many steps the progress counter tracks for this run
+func (r *Runner) countSteps() int {
+	steps := 3 * len(r.models) // Install, register and inference per model
+	if !r.cfg.SkipInstall {
+		steps++ // Axon
+		if !r.cfg.UsesExternalCore() {
+			steps++ // ONNX Runtime
+			if r.cfg.CoreBinary == "" {
+				steps++ // Core (--core-binary skips the download)
+			}
+		}
+	}
+	return steps
+}
+
+// stage runs one pipeline step and records its span on the results timeline
+// All steps in Run go through here (or parallelStages), so new steps show up in the timeline automatically
+func (r *Runner) stage(results *Results, name string, fn func() error) error {
+	start := time.Now()
+	ctx, span := r.tracer.Start(r.traceCtx, name)
+	r.stageCtx = ctx
+	err := fn()
+	r.stageCtx = r.traceCtx
+	endSpan(span, err)
+	results.Timeline = append(results.Timeline, Span{
+		Name:       name,
+		StartMs:    start.Sub(results.StartTime).Milliseconds(),
+		DurationMs: time.Since(start).Milliseconds(),
+		Failed:     err != nil,
+	})
+	if err != nil {
+		results.RecordError(name, "", err.Error())
+	}
+	return err
+}
+
+// parallelStage is one stage run by parallelStages
+type parallelStage struct {
+	name string
+	fn   func() error
+}
+
+// parallelStages is stage for independent stages that run concurrently
+// The stages must write disjoint parts of results. Spans and errors are recorded once all have
+// finished, in the order given, and each stage's error is returned at its index.
+func (r *Runner) parallelStages(results *Results, stages []parallelStage) []error {
+	errs := make([]error, len(stages))
+	spans := make([]Span, len(stages))
+	var g errgroup.Group
+	for i, s := range stages {
+		i, s := i, s
+		g.Go(func() error {
+			start := time.Now()
+			_, span := r.tracer.Start(r.traceCtx, s.name)
+			errs[i] = s.fn()
+			endSpan(span, errs[i])
+			spans[i] = Span{
+				Name:       s.name,
+				StartMs:    start.Sub(results.StartTime).Milliseconds(),
+				DurationMs: time.Since(start).Milliseconds(),
+				Failed:     errs[i] != nil,
+			}
+			return nil
+		})
+	}
+	_ = g.Wait() // Errors are per stage, never the group's
+
+	for i, s := range stages {
+		results.Timeline = append(results.Timeline, spans[i])
+		if errs[i] != nil {
+			results.RecordError(s.name, "", errs[i].Error())
+		}
+	}
+	return errs
+}
+
 func (r *Runner) downloadReleases(results *Results) error {
 	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	log.Printf("📦 Downloading Releases")
 	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	// Download Axon
+	// Axon, Core and ONNX Runtime are independent, so fetch them concurrently
+	var g errgroup.Group
+	g.SetLimit(maxConcurrentDownloads)
+
 	start := time.Now()
-	if err := release.DownloadAxon(r.cfg.AxonVersion, r.cfg.OutputDir); err != nil {
-		return fmt.Errorf("failed to download Axon: %w", err)
+	g.Go(func() error {
+		r.progress.begin("Download Axon")
+		start := time.Now()
+		transfer, err := release.DownloadAxon(r.cfg.AxonVersion, r.cfg.OutputDir, r.releaseOptions())
+		if err != nil {
+			return fmt.Errorf("failed to download Axon: %w", err)
+		}
+		results.Metrics.RecordTransfer(ComponentAxon, transfer)
+		elapsed := time.Since(start).Milliseconds()
+		results.Metrics.RecordDownloadTime(ComponentAxon, elapsed)
+		log.Printf("✅ Axon downloaded (%dms)", elapsed)
+		return nil
+	})
+	// An external Core needs neither the Core release nor its ONNX Runtime
+	external := r.cfg.UsesExternalCore()
+	g.Go(func() error {
+		// --core-binary still needs ONNX Runtime, just not the release
+		if external || r.cfg.CoreBinary != "" {
+			return nil
+		}
+		r.progress.begin("Download Core")
+		start := time.Now()
+		transfer, err := release.DownloadCore(r.cfg.CoreVersion, r.cfg.OutputDir, r.cfg.ReleaseMirror, r.releaseOptions())
+		if err != nil {
+			return fmt.Errorf("failed to download Core: %w", err)
+		}
+		results.Metrics.RecordTransfer(ComponentCore, transfer)
+		elapsed := time.Since(start).Milliseconds()
+		results.Metrics.RecordDownloadTime(ComponentCore, elapsed)
+		log.Printf("✅ Core downloaded (%dms)", elapsed)
+		return nil
+	})
+	g.Go(func() error {
+		// Staged in the output dir; StartCore moves it next to the Core binary
+		if external {
+			return nil
+		}
+		r.progress.begin("Download ONNX Runtime")
+		start := time.Now()
+		transfer, err := release.DownloadONNXRuntime(r.cfg.OutputDir, r.cfg.ReleaseMirror, r.releaseOptions())
+		if err != nil {
+			return fmt.Errorf("failed to download ONNX Runtime: %w", err)
+		}
+		results.Metrics.RecordTransfer(ComponentONNXRuntime, transfer)
+		elapsed := time.Since(start).Milliseconds()
+		results.Metrics.RecordDownloadTime(ComponentONNXRuntime, elapsed)
+		log.Printf("✅ ONNX Runtime downloaded (%dms)", elapsed)
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return err
 	}
-	results.Metrics.AxonDownloadTimeMs = time.Since(start).Milliseconds()
-	log.Printf("✅ Axon downloaded (%dms)", results.Metrics.AxonDownloadTimeMs)
 
-	// Download Core
-	start = time.Now()
-	if err := release.DownloadCore(r.cfg.CoreVersion, r.cfg.OutputDir); err != nil {
-		return fmt.Errorf("failed to download Core: %w", err)
-	}
-	results.Metrics.CoreDownloadTimeMs = time.Since(start).Milliseconds()
-	log.Printf("✅ Core downloaded (%dms)", results.Metrics.CoreDownloadTimeMs)
+	elapsed := time.Since(start).Milliseconds()
+	results.Metrics.RecordDownloadTime(ComponentAll, elapsed)
+	log.Printf("✅ All releases downloaded (%dms wall-clock)", elapsed)
 
 	return nil
 }
@@ -125,13 +695,23 @@ func (r *Runner) installModels(results *Results) error {
 
 	testModels := r.getTestModels()
 
+	// A converter hung in an earlier run would otherwise keep holding memory through this one
+	r.checkConverterContainers("before install")
+
+	start := time.Now()
 	for i, spec := range testModels {
 		// Show progress indicator
+		r.progress.begin("Install " + spec.Name)
+		r.progress.eta("Model installs", i, len(testModels), time.Since(start))
 		log.Printf("📦 [%d/%d] Installing %s...", i+1, len(testModels), spec.ID)
 		
-		installed, err := model.Install(spec.ID, r.cfg.TestAllModels)
+		installed, converter, err := model.Install(spec.ID, r.cfg.TestAllModels, r.cfg.AxonCacheDir, r.cfg.ModelFilenames, r.cfg.ReleaseMirror, r.cfg.ConverterDigest, r.axonMatcher(), r.releaseOptions())
+		results.Metrics.RecordTransfer(ComponentConverter, converter)
+		// Install fails on a PyTorch fallback too, so check what Axon left behind either way
+		r.recordModelFormat(results, spec)
 		if err != nil {
 			log.Printf("WARN: Failed to install %s: %v", spec.ID, err)
+			results.RecordError("install", spec.Name, err.Error())
 			log.Printf("   Installation returned error, skipping this model")
 			continue
 		}
@@ -139,73 +719,632 @@ func (r *Runner) installModels(results *Results) error {
 		// Count model if it was just installed OR if it was already installed
 		// (Install returns false if already installed, but we still want to count it)
 		if installed {
-			results.Metrics.ModelsInstalled++
+			results.Metrics.RecordInstalled()
 			log.Printf("✅ Installed %s", spec.ID)
 		} else {
 			log.Printf("   Install returned false (model already exists or skipped)")
 			// Check if model exists (was already installed)
-			modelPath, pathErr := model.GetPath(spec.ID)
+			modelPath, pathErr := model.GetPath(spec.ID, r.cfg.AxonCacheDir, r.cfg.ModelFilenames, r.releaseOptions())
 			if pathErr == nil {
-				results.Metrics.ModelsInstalled++
+				results.Metrics.RecordInstalled()
 				log.Printf("✅ Model already cached: %s at %s", spec.ID, modelPath)
 			} else {
 				log.Printf("WARN: Model not found after installation: %v", pathErr)
+				results.RecordError("install", spec.Name, "model not found after installation: "+pathErr.Error())
 				log.Printf("   This model will not be available for testing")
 			}
 		}
+
+		// Surprising layouts are otherwise only visible when verification fails
+		if r.cfg.Verbose {
+			r.recordCacheListing(results, spec)
+		}
 	}
 
 	log.Printf("✅ Installed %d models", results.Metrics.ModelsInstalled)
+	r.checkConverterContainers("after install")
+	r.checkModelMetadata(results)
+
+	r.recordManifest(results)
 	return nil
 }
 
+// checkConverterContainers reports axon-converter containers left behind, removing them with --cleanup-containers
+// Every install has finished at both call sites, so any such container is orphaned (or another run's).
+func (r *Runner) checkConverterContainers(when string) {
+	containers, err := model.ListConverterContainers()
+	if err != nil {
+		if r.cfg.Verbose {
+			log.Printf("   Skipping converter container check: %v", err)
+		}
+		return
+	}
+	if len(containers) == 0 {
+		return
+	}
+	for _, c := range containers {
+		log.Printf("WARN: Stale converter container %.12s (%s, %s) %s", c.ID, c.Image, c.Status, when)
+	}
+	if !r.cfg.CleanupContainers {
+		log.Printf("   Pass --cleanup-containers to remove them")
+		return
+	}
+	if err := model.RemoveConverterContainers(containers); err != nil {
+		log.Printf("WARN: %v", err)
+		return
+	}
+	log.Printf("✅ Removed %d stale converter container(s)", len(containers))
+}
+
+// recordModelFormat records the format Axon installed a model in
+// Core only loads ONNX, so any other format means the conversion fell back and the model fails.
+func (r *Runner) recordModelFormat(results *Results, spec ModelSpec) {
+	format, err := model.DetectFormat(spec.ID, r.cfg.AxonCacheDir, r.cfg.ModelFilenames)
+	if err != nil || format == "" {
+		return
+	}
+	results.Metrics.RecordModelFormat(spec.Name, format)
+	if format != model.FormatONNX {
+		log.Printf("ERROR: %s: ONNX conversion fell back to %s format", spec.Name, format)
+		results.RecordError("install", spec.Name, fmt.Sprintf("ONNX conversion fell back to %s format", format))
+	}
+}
+
+// recordCacheListing captures and logs the files in a model's cache directory
+func (r *Runner) recordCacheListing(results *Results, spec ModelSpec) {
+	dir, files, err := model.ListModelDir(spec.ID, r.cfg.AxonCacheDir, r.cfg.ModelFilenames, r.releaseOptions())
+	if err != nil {
+		log.Printf("WARN: Failed to list cache for %s: %v", spec.ID, err)
+		return
+	}
+	results.Metrics.RecordCacheListing(spec.Name, files)
+	log.Printf("   📁 %s (%d files)", dir, len(files))
+	for _, f := range files {
+		log.Printf("      %s (%d bytes)", f.Path, f.SizeBytes)
+	}
+}
+
+// recordManifest hashes every installed model file and writes the manifest to the output dir
+// Comparing manifests across runs shows whether two runs tested byte-identical models
+func (r *Runner) recordManifest(results *Results) {
+	for _, spec := range r.getTestModels() {
+		entry, err := model.HashModel(spec.ID, r.cfg.AxonCacheDir, r.cfg.ModelFilenames, r.releaseOptions())
+		if err != nil {
+			continue // Not installed; already reported above
+		}
+		results.Metrics.RecordManifest(spec.Name, entry)
+		log.Printf("   %s %s sha256=%s (%d bytes)", spec.Name, entry.Variant, entry.SHA256, entry.SizeBytes)
+	}
+
+	data, err := json.MarshalIndent(results.Metrics.ModelManifest, "", "  ")
+	if err != nil {
+		log.Printf("WARN: Failed to encode model manifest: %v", err)
+		return
+	}
+	if err := os.WriteFile(r.cfg.ManifestPath, data, 0644); err != nil {
+		log.Printf("WARN: Failed to write model manifest: %v", err)
+		return
+	}
+	log.Printf("📝 Model manifest: %s", r.cfg.ManifestPath)
+}
+
 func (r *Runner) startCore(results *Results) (*monitor.Process, error) {
 	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	log.Printf("🚀 Starting MLOS Core Server")
 	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	// A Core we didn't start is only checked, never launched (or torn down)
+	if r.cfg.UsesExternalCore() {
+		if err := r.cfg.ValidateCoreURL(); err != nil {
+			return nil, err
+		}
+		log.Printf("Using already-running Core at %s", r.cfg.CoreURL())
+		if r.cfg.InsecureSkipVerify {
+			log.Printf("WARN: TLS certificate verification is disabled for Core requests (axon register still verifies)")
+		}
+		if err := release.CheckCoreHealth(r.cfg.CoreURL(), r.releaseOptions()); err != nil {
+			return nil, err
+		}
+		log.Printf("✅ External MLOS Core is healthy")
+		return nil, nil
+	}
+
 	log.Printf("Using port %d (non-privileged, no sudo required)", r.cfg.CorePort)
 
 	start := time.Now()
-	process, err := release.StartCore(r.cfg.CoreVersion, r.cfg.OutputDir, r.cfg.CorePort)
+	process, err := release.StartCore(r.cfg.CoreVersion, r.cfg.OutputDir, r.cfg.Host, r.cfg.CorePort, r.cfg.ReleaseMirror, r.releaseOptions())
 	if err != nil {
 		return nil, err
 	}
 
 	// Store process for crash diagnostics
-	r.coreProcess = process
+	r.setCore(process)
 
-	results.Metrics.CoreStartupTimeMs = time.Since(start).Milliseconds()
-	log.Printf("✅ MLOS Core ready on port %d (%dms)", r.cfg.CorePort, results.Metrics.CoreStartupTimeMs)
+	elapsed := time.Since(start).Milliseconds()
+	results.Metrics.RecordCoreStartup(elapsed)
+	log.Printf("✅ MLOS Core ready at %s (%dms)", r.cfg.CoreURL(), elapsed)
+
+	// Healthy doesn't mean every model Core autoloaded came up
+	if err := r.checkAutoload(results, process); err != nil {
+		if stopErr := monitor.StopProcess(process); stopErr != nil {
+			log.Printf("WARN: Failed to stop Core process: %v", stopErr)
+		}
+		r.setCore(nil)
+		return nil, err
+	}
 
 	return process, nil
 }
 
-func (r *Runner) registerModels(results *Results) error {
+// checkAutoload scans Core's startup output for models it failed to load and records them
+// It fails if one of them is in the model matrix, since that model could never pass.
+func (r *Runner) checkAutoload(results *Results, process *monitor.Process) error {
+	failures := release.ScanAutoloadFailures(process.StdoutLog, process.StderrLog)
+	var required []string
+	for _, f := range failures {
+		failure := AutoloadFailure{Model: f.Model, Line: f.Line}
+		if spec, ok := r.matrixModel(f.Model); ok {
+			failure.Required = true
+			required = append(required, spec.Name)
+			results.RecordError("start", spec.Name, "failed to autoload: "+f.Line)
+			log.Printf("ERROR: Core failed to autoload %s: %s", spec.Name, f.Line)
+		} else {
+			results.RecordError("start", "", "Core failed to autoload a model: "+f.Line)
+			log.Printf("WARN: Core failed to autoload a model outside the test matrix: %s", f.Line)
+		}
+		results.AutoloadFailures = append(results.AutoloadFailures, failure)
+	}
+	if len(required) > 0 {
+		return fmt.Errorf("Core started, but failed to autoload %s (see %s)", strings.Join(required, ", "), process.StderrLog)
+	}
+	return nil
+}
+
+// matrixModel finds the tested model a name from Core's log refers to, by ID (with or without its
+// @version) or short name
+func (r *Runner) matrixModel(name string) (ModelSpec, bool) {
+	if name == "" {
+		return ModelSpec{}, false
+	}
+	for _, spec := range r.getTestModels() {
+		id, _, _ := strings.Cut(spec.ID, "@")
+		if name == spec.ID || name == spec.Name || name == id || strings.TrimPrefix(id, "hf/") == name {
+			return spec, true
+		}
+	}
+	return ModelSpec{}, false
+}
+
+// startExtraCores starts Core instances 1..CoreInstances-1 concurrently on free ports after CorePort
+// Ports come from a PortAllocator, so instances starting at the same time never collide.
+// A failed instance is recorded with its error; the others still run.
+func (r *Runner) startExtraCores(results *Results) {
 	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	log.Printf("📝 Registering Models with MLOS Core")
+	log.Printf("🧪 Starting %d Additional Core Instances", r.cfg.CoreInstances-1)
 	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	testModels := r.getTestModels()
-	for _, spec := range testModels {
-		start := time.Now()
-		// Verify model is installed before registering
-		if _, err := model.GetPath(spec.ID); err != nil {
-			log.Printf("WARN: Model %s not found, skipping registration", spec.ID)
-			continue
+	count := r.cfg.CoreInstances - 1
+	r.extraCores = make([]*monitor.Process, count)
+	results.CoreInstances = make([]CoreInstance, count)
+	ports := release.NewPortAllocator(r.cfg.Host, r.cfg.CorePort+1)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= count; i++ {
+		instance := &results.CoreInstances[i-1]
+		*instance = CoreInstance{
+			Index:                i,
+			ModelInferenceTimes:  make(map[string]int64),
+			ModelInferenceStatus: make(map[string]string),
+			ModelInferenceErrors: make(map[string]string),
 		}
 
-		// Use axon register command (proper flow: install -> register -> inference)
-		if err := model.Register(spec.ID, r.cfg.CorePort); err != nil {
-			log.Printf("ERROR: Failed to register %s: %v", spec.Name, err)
+		reservation, err := ports.Reserve()
+		if err != nil {
+			log.Printf("ERROR: Failed to start Core instance %d: %v", i, err)
+			results.RecordError("start-instances", "", fmt.Sprintf("instance %d: %v", i, err))
+			instance.Error = err.Error()
 			continue
 		}
+		instance.URL = r.cfg.URLForPort(reservation.Port)
 
-		results.Metrics.ModelRegistrationTimes[spec.Name] = time.Since(start).Milliseconds()
-		log.Printf("✅ Registered %s (%dms)", spec.Name, results.Metrics.ModelRegistrationTimes[spec.Name])
+		wg.Add(1)
+		go func(i int, instance *CoreInstance, reservation *release.PortReservation) {
+			defer wg.Done()
+			port := reservation.Port
+			start := time.Now()
+			// The port stays bound until just before Core is started on it
+			process, err := release.StartCoreOnReservation(r.cfg.CoreVersion, r.cfg.OutputDir, r.cfg.Host, reservation, r.cfg.ReleaseMirror, fmt.Sprintf("core-%d", port), r.releaseOptions())
+			if err != nil {
+				log.Printf("ERROR: Failed to start Core instance %d on port %d: %v", i, port, err)
+				results.RecordError("start-instances", "", fmt.Sprintf("instance %d on port %d: %v", i, port, err))
+				instance.Error = err.Error()
+				return
+			}
+			instance.StartupTimeMs = time.Since(start).Milliseconds()
+			r.extraCores[i-1] = process
+			log.Printf("✅ Core instance %d ready at %s (%dms)", i, instance.URL, instance.StartupTimeMs)
+		}(i, instance, reservation)
 	}
-
-	log.Printf("✅ Registered %d models", len(results.Metrics.ModelRegistrationTimes))
-	return nil
+	wg.Wait()
+}
+
+// runIsolationTests registers every model registered on the main Core with each
+// additional instance and runs the small inference test against it
+func (r *Runner) runIsolationTests(results *Results) error {
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("🧪 Running Inference on Additional Core Instances")
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	var models []ModelSpec
+	for _, spec := range r.getTestModels() {
+		if results.Metrics.IsRegistered(spec.Name) {
+			models = append(models, spec)
+		}
+	}
+	if len(models) == 0 {
+		return fmt.Errorf("no models are registered on the main Core instance")
+	}
+
+	for i := range results.CoreInstances {
+		instance := &results.CoreInstances[i]
+		if r.extraCores[i] == nil {
+			continue
+		}
+		for _, spec := range models {
+			if err := model.Register(spec.ID, instance.URL, r.cfg.AxonCacheDir, r.axonMatcher(), r.releaseOptions()); err != nil {
+				log.Printf("ERROR: Failed to register %s with instance %d: %v", spec.Name, instance.Index, err)
+				results.RecordError("isolation", spec.Name, fmt.Sprintf("registration with instance %d failed: %v", instance.Index, err))
+				instance.ModelInferenceStatus[spec.Name] = "failed"
+				instance.ModelInferenceErrors[spec.Name] = "registration failed: " + err.Error()
+				continue
+			}
+
+			start := time.Now()
+			_, err := model.RunInference(spec.ID, spec.Name, spec.Type, false, instance.URL, r.cfg.InferenceTimeoutFor(false), r.cfg.InputKey, r.inference)
+			elapsed := time.Since(start).Milliseconds()
+			if err != nil {
+				log.Printf("ERROR: %s inference failed on instance %d: %v", spec.Name, instance.Index, err)
+				results.RecordError("isolation", spec.Name, fmt.Sprintf("inference on instance %d failed: %v", instance.Index, err))
+				instance.ModelInferenceStatus[spec.Name] = "failed"
+				instance.ModelInferenceErrors[spec.Name] = err.Error()
+				continue
+			}
+			instance.ModelInferenceTimes[spec.Name] = elapsed
+			instance.ModelInferenceStatus[spec.Name] = "success"
+			log.Printf("✅ %s on instance %d (%dms)", spec.Name, instance.Index, elapsed)
+		}
+	}
+	return nil
+}
+
+// axonMatcher returns how axon output is checked for errors (--strict-axon-output)
+func (r *Runner) axonMatcher() model.AxonOutputMatcher {
+	return model.AxonOutputMatcher{ErrorPrefixes: r.cfg.AxonErrorPrefixes, Strict: r.cfg.StrictAxonOutput}
+}
+
+// releaseOptions returns the settings for downloading, starting and talking to Core
+func (r *Runner) releaseOptions() release.Options {
+	return release.Options{
+		Output:             r.cfg.Output,
+		EchoCommands:       r.cfg.Verbose, // Print axon/gh/curl command lines so failures can be reproduced
+		InsecureSkipVerify: r.cfg.InsecureSkipVerify,
+		StrictVersions:     r.cfg.StrictVersions,
+		BasePath:           r.cfg.BasePath,
+		CoreBinary:         r.cfg.CoreBinary,
+		CoreDockerImage:    r.cfg.CoreDockerImage,
+		DownloadETA:        r.cfg.Progress && isTerminal(os.Stderr), // Same gating as the step counter (see newProgress)
+		StrictHealth:       r.cfg.StrictHealth,
+		HealthBody:         r.cfg.HealthBody,
+	}
+}
+
+func (r *Runner) registerModels(results *Results) error {
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("📝 Registering Models with MLOS Core")
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	// With --register-concurrency > 1 registrations overlap, which exercises Core's concurrent model
+	// loading. Every registration times itself, so times stay per model (contention included).
+	concurrency := r.cfg.RegisterConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	results.Metrics.RecordRegistrationConcurrency(concurrency)
+
+	// RSS growth between samples only belongs to one model while registrations happen one at a time
+	process := r.core()
+	measureMemory := r.cfg.MeasureModelMemory && process != nil
+	if r.cfg.MeasureModelMemory && !measureMemory {
+		log.Printf("WARN: Core process is not managed by this run; skipping per-model memory footprint")
+	}
+	if measureMemory && concurrency > 1 {
+		log.Printf("WARN: Registering %d models at once; skipping per-model memory footprint", concurrency)
+		measureMemory = false
+	}
+	var lastMemoryMB float64
+	if measureMemory {
+		usage, err := monitor.MonitorProcess(process, modelMemorySampleDuration)
+		if err != nil {
+			log.Printf("WARN: Failed to sample Core memory before registration: %v", err)
+			measureMemory = false
+		} else {
+			lastMemoryMB = usage.MemoryMB
+			results.Metrics.RecordCoreBaselineMemory(usage.MemoryMB)
+			log.Printf("🧠 Core baseline RSS: %.1fMB", usage.MemoryMB)
+		}
+	}
+
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for _, spec := range r.getTestModels() {
+		spec := spec
+		r.progress.begin("Register " + spec.Name)
+		if concurrency > 1 {
+			g.Go(func() error {
+				r.registerModel(results, spec)
+				return nil
+			})
+			continue
+		}
+		if !r.registerModel(results, spec) {
+			continue
+		}
+
+		if measureMemory {
+			usage, err := monitor.MonitorProcess(process, modelMemorySampleDuration)
+			if err != nil {
+				log.Printf("WARN: Failed to sample Core memory after registering %s: %v", spec.Name, err)
+				continue
+			}
+			results.Metrics.RecordModelMemory(spec.Name, usage.MemoryMB-lastMemoryMB)
+			log.Printf("   🧠 %s added %.1fMB (Core RSS %.1fMB)", spec.Name, usage.MemoryMB-lastMemoryMB, usage.MemoryMB)
+			lastMemoryMB = usage.MemoryMB
+		}
+	}
+
+	_ = g.Wait()
+
+	log.Printf("✅ Registered %d models", len(results.Metrics.ModelRegistrationTimes))
+	return nil
+}
+
+// registerModel registers one installed model with Core and records its time or failure under its name
+// It is safe to call from several goroutines at once. Returns whether the model was registered.
+func (r *Runner) registerModel(results *Results, spec ModelSpec) bool {
+	start := time.Now()
+	// Verify model is installed before registering
+	modelPath, err := model.GetPath(spec.ID, r.cfg.AxonCacheDir, r.cfg.ModelFilenames, r.releaseOptions())
+	if err != nil {
+		log.Printf("WARN: Model %s not found, skipping registration", spec.ID)
+		return false
+	}
+	// Large exports keep their weights in sibling files; Core only notices missing ones at load time
+	if err := model.CheckExternalData(modelPath); err != nil {
+		log.Printf("ERROR: Failed to register %s: %v", spec.Name, err)
+		results.RecordError("register", spec.Name, err.Error())
+		results.Metrics.RecordRegistrationFailure(spec.Name, err.Error())
+		return false
+	}
+
+	// Use axon register command (proper flow: install -> register -> inference)
+	if err := model.Register(spec.ID, r.cfg.CoreURL(), r.cfg.AxonCacheDir, r.axonMatcher(), r.releaseOptions()); err != nil {
+		log.Printf("ERROR: Failed to register %s: %v", spec.Name, err)
+		results.RecordError("register", spec.Name, err.Error())
+		results.Metrics.RecordRegistrationFailure(spec.Name, err.Error())
+		return false
+	}
+
+	elapsed := time.Since(start).Milliseconds()
+	results.Metrics.RecordRegistration(spec.Name, elapsed)
+	log.Printf("✅ Registered %s (%dms)", spec.Name, elapsed)
+	return true
+}
+
+// ensureCoreRunning restarts Core and re-registers models if it is no longer healthy
+// Only active with AutoRestartCore, and at most MaxCoreRestarts times per run
+func (r *Runner) ensureCoreRunning(results *Results, beforeModel string) {
+	if !r.cfg.AutoRestartCore || r.cfg.UsesExternalCore() {
+		return
+	}
+	healthErr := release.CheckCoreHealth(r.cfg.CoreURL(), r.releaseOptions())
+	if healthErr == nil {
+		return
+	}
+	if len(results.CoreRestarts) >= r.cfg.MaxCoreRestarts {
+		log.Printf("ERROR: Core is down (%v) but the restart limit (%d) was reached", healthErr, r.cfg.MaxCoreRestarts)
+		results.RecordError("restart", beforeModel, fmt.Sprintf("Core is down (%v) but the restart limit (%d) was reached", healthErr, r.cfg.MaxCoreRestarts))
+		return
+	}
+
+	log.Printf("WARN: Core is down before testing %s (%v); restarting", beforeModel, healthErr)
+	r.restartCore(results, beforeModel, healthErr.Error())
+}
+
+// restartCore replaces the managed Core process with a fresh one and re-registers the models
+// that were registered before. The attempt is recorded in results.CoreRestarts.
+func (r *Runner) restartCore(results *Results, beforeModel, reason string) {
+	r.logCoreOutputIfCrashed()
+	event := CoreRestart{Time: time.Now(), BeforeModel: beforeModel, Reason: reason}
+
+	if dead := r.core(); dead != nil {
+		if err := monitor.StopProcess(dead); err != nil {
+			log.Printf("WARN: Failed to stop dead Core process: %v", err)
+		}
+		r.setCore(nil)
+	}
+	process, err := release.StartCore(r.cfg.CoreVersion, r.cfg.OutputDir, r.cfg.Host, r.cfg.CorePort, r.cfg.ReleaseMirror, r.releaseOptions())
+	if err != nil {
+		event.Error = err.Error()
+		results.CoreRestarts = append(results.CoreRestarts, event)
+		log.Printf("ERROR: Failed to restart Core: %v", err)
+		results.RecordError("restart", beforeModel, "failed to restart Core: "+err.Error())
+		return
+	}
+	r.setCore(process)
+
+	// A fresh Core has nothing registered; restore what was registered before the crash
+	for _, spec := range r.getTestModels() {
+		if !results.Metrics.IsRegistered(spec.Name) {
+			continue
+		}
+		if err := model.Register(spec.ID, r.cfg.CoreURL(), r.cfg.AxonCacheDir, r.axonMatcher(), r.releaseOptions()); err != nil {
+			log.Printf("WARN: Failed to re-register %s after restart: %v", spec.Name, err)
+		}
+	}
+
+	event.Succeeded = true
+	results.CoreRestarts = append(results.CoreRestarts, event)
+	log.Printf("✅ Core restarted (%d/%d)", len(results.CoreRestarts), r.cfg.MaxCoreRestarts)
+}
+
+// verifyRegistrations checks each registered model against Core's model list
+// A model axon registered but Core doesn't list is recorded as a registration failure
+func (r *Runner) verifyRegistrations(results *Results) error {
+	listed, err := model.ListModels(r.cfg.CoreURL(), r.releaseOptions())
+	if err != nil {
+		return err
+	}
+	if listed == nil {
+		log.Printf("WARN: Core has no model list endpoint; registrations not verified")
+		return nil
+	}
+	results.CoreModelList = listed
+
+	known := make(map[string]bool, len(listed))
+	for _, id := range listed {
+		known[id] = true
+	}
+	for _, spec := range r.getTestModels() {
+		if !results.Metrics.IsRegistered(spec.Name) {
+			continue
+		}
+		if known[spec.ID] {
+			continue
+		}
+		results.Metrics.RecordRegistrationFailure(spec.Name, fmt.Sprintf("axon register succeeded but Core does not list %s", spec.ID))
+		results.RecordError("verify-registration", spec.Name, fmt.Sprintf("axon register succeeded but Core does not list %s", spec.ID))
+		log.Printf("ERROR: %s is missing from Core's model list", spec.ID)
+	}
+
+	log.Printf("✅ Core lists %d models", len(listed))
+	return nil
+}
+
+// testReregistration registers every registered model a second time and checks Core neither errors
+// nor lists the model twice. An explicit already-registered answer counts as well-defined behavior.
+func (r *Runner) testReregistration(results *Results) error {
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("🔁 Re-registering Models")
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	var models []ModelSpec
+	for _, spec := range r.getTestModels() {
+		if results.Metrics.IsRegistered(spec.Name) {
+			models = append(models, spec)
+		}
+	}
+	if len(models) == 0 {
+		return fmt.Errorf("no models are registered")
+	}
+
+	behaviors := make(map[string]string, len(models))
+	for _, spec := range models {
+		err := model.Register(spec.ID, r.cfg.CoreURL(), r.cfg.AxonCacheDir, r.axonMatcher(), r.releaseOptions())
+		switch {
+		case err == nil:
+			behaviors[spec.Name] = ReregisterNoOp
+		case model.IsAlreadyRegistered(err):
+			behaviors[spec.Name] = ReregisterAlreadyRegistered
+		default:
+			results.Metrics.RecordReregister(spec.Name, "", err.Error())
+			results.RecordError("reregister", spec.Name, err.Error())
+			log.Printf("ERROR: Re-registering %s failed: %v", spec.Name, err)
+		}
+	}
+
+	// A clean answer isn't enough; Core must still list the model exactly once
+	listed, err := model.ListModels(r.cfg.CoreURL(), r.releaseOptions())
+	if err != nil {
+		log.Printf("WARN: Could not list models after re-registering: %v", err)
+	} else if listed == nil {
+		log.Printf("WARN: Core has no model list endpoint; duplicate registrations not checked")
+	}
+	counts := make(map[string]int, len(listed))
+	for _, id := range listed {
+		counts[id]++
+	}
+	for _, spec := range models {
+		behavior, ok := behaviors[spec.Name]
+		if !ok {
+			continue
+		}
+		errMsg := ""
+		switch {
+		case listed == nil:
+		case counts[spec.ID] == 0:
+			errMsg = fmt.Sprintf("Core no longer lists %s after re-registering", spec.ID)
+		case counts[spec.ID] > 1:
+			errMsg = fmt.Sprintf("Core lists %s %d times after re-registering", spec.ID, counts[spec.ID])
+		}
+		results.Metrics.RecordReregister(spec.Name, behavior, errMsg)
+		if errMsg != "" {
+			results.RecordError("reregister", spec.Name, errMsg)
+			log.Printf("ERROR: %s", errMsg)
+			continue
+		}
+		log.Printf("✅ %s re-registered cleanly (%s)", spec.Name, behavior)
+	}
+	return nil
+}
+
+// unregisterModels unregisters every registered model and checks Core's model list no longer has it
+func (r *Runner) unregisterModels(results *Results) error {
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("🧹 Unregistering Models")
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	var models []ModelSpec
+	for _, spec := range r.getTestModels() {
+		if results.Metrics.IsRegistered(spec.Name) {
+			models = append(models, spec)
+		}
+	}
+	if len(models) == 0 {
+		return fmt.Errorf("no models are registered")
+	}
+
+	var unregistered []ModelSpec
+	for _, spec := range models {
+		if err := model.Unregister(spec.ID, r.cfg.CoreURL(), r.cfg.AxonCacheDir, r.axonMatcher(), r.releaseOptions()); err != nil {
+			results.Metrics.RecordUnregister(spec.Name, err.Error())
+			log.Printf("ERROR: Failed to unregister %s: %v", spec.Name, err)
+			results.RecordError("unregister", spec.Name, err.Error())
+			continue
+		}
+		unregistered = append(unregistered, spec)
+	}
+
+	// Core accepting the request isn't enough; the model must actually be gone
+	listed, err := model.ListModels(r.cfg.CoreURL(), r.releaseOptions())
+	if err != nil {
+		log.Printf("WARN: Could not list models after unregistering: %v", err)
+	} else if listed == nil {
+		log.Printf("WARN: Core has no model list endpoint; unregistrations not verified")
+	}
+	stillListed := make(map[string]bool, len(listed))
+	for _, id := range listed {
+		stillListed[id] = true
+	}
+	for _, spec := range unregistered {
+		if stillListed[spec.ID] {
+			results.Metrics.RecordUnregister(spec.Name, fmt.Sprintf("unregister succeeded but Core still lists %s", spec.ID))
+			log.Printf("ERROR: %s is still in Core's model list after unregistering", spec.ID)
+			results.RecordError("unregister", spec.Name, fmt.Sprintf("unregister succeeded but Core still lists %s", spec.ID))
+			continue
+		}
+		results.Metrics.RecordUnregister(spec.Name, "")
+		log.Printf("✅ %s unregistered", spec.Name)
+	}
+	return nil
 }
 
 func (r *Runner) runInferenceTests(results *Results) error {
@@ -214,64 +1353,584 @@ func (r *Runner) runInferenceTests(results *Results) error {
 	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	testModels := r.getTestModels()
+	if r.cfg.Prompt != "" {
+		r.preparePromptInputs(results, testModels)
+	}
+	// Each test is a job; with --max-parallel-inference > 1 the jobs of different models and
+	// sizes overlap. Every job times its own request, so latencies stay per request.
+	parallelism := r.cfg.MaxParallelInference
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	results.Metrics.RecordInferenceParallelism(parallelism)
+	var g errgroup.Group
+	g.SetLimit(parallelism)
+	run := func(job func()) {
+		if parallelism == 1 {
+			job()
+			return
+		}
+		g.Go(func() error {
+			job()
+			return nil
+		})
+	}
+
+	start := time.Now()
 	for _, spec := range testModels {
+		spec := spec
+		r.progress.begin("Inference " + spec.Name)
+		// Install-only categories are there to exercise the install/convert path; not a skip
+		if r.cfg.InstallOnly(spec.Category) {
+			results.InstallOnlyModels = append(results.InstallOnlyModels, spec.Name)
+			log.Printf("📦 %s: %s is install-only, not running inference", spec.Name, spec.Category)
+			continue
+		}
 		// Only test NLP models for now (vision and multimodal can be enabled later)
 		if spec.Category != "nlp" {
+			r.recordSkip(results, spec, fmt.Sprintf("%s inference not supported yet", spec.Category))
+			continue
+		}
+
+		// A model can opt out of one size (see config.LoadInferenceSizes)
+		// Only the sizes it runs are counted, failures before any request included.
+		runSmall := r.cfg.RunsInferenceSize(spec.Name, false)
+		runLarge := r.cfg.RunsInferenceSize(spec.Name, true)
+		var sizes []string
+		if runSmall {
+			sizes = append(sizes, SizeSmall)
+		}
+		if runLarge {
+			sizes = append(sizes, SizeLarge)
+		}
+
+		// A model Axon left in its native format can never load in Core: fail it rather than skip it
+		if format := results.Metrics.InstalledFormat(spec.Name); format != "" && format != model.FormatONNX {
+			reason := fmt.Sprintf("ONNX conversion fell back to %s format (Core requires ONNX)", format)
+			for _, size := range sizes {
+				results.Metrics.RecordInference(spec.Name, size, 0, "failed", reason)
+			}
+			log.Printf("ERROR: %s: %s", spec.Name, reason)
 			continue
 		}
 
 		// Check if model is available before testing
-		_, err := model.GetPath(spec.ID)
+		_, err := model.GetPath(spec.ID, r.cfg.AxonCacheDir, r.cfg.ModelFilenames, r.releaseOptions())
 		if err != nil {
 			log.Printf("WARN: Model %s not available, skipping: %v", spec.ID, err)
+			r.recordSkip(results, spec, "model not installed")
 			continue
 		}
 
-		// Small inference test
-		// Use spec.ID (full model spec) for URL, spec.Name (short name) for input generation
-		start := time.Now()
-		err = model.RunInference(spec.ID, spec.Name, spec.Type, false, r.cfg.CorePort)
-		elapsed := time.Since(start).Milliseconds()
-		results.Metrics.TotalInferences++
+		// Bring Core back first if it died while testing the previous model
+		// Let in-flight requests finish so none of them straddles the restart
+		if parallelism > 1 && r.cfg.AutoRestartCore && release.CheckCoreHealth(r.cfg.CoreURL(), r.releaseOptions()) != nil {
+			_ = g.Wait()
+		}
+		r.ensureCoreRunning(results, spec.Name)
 
-		if err != nil {
-			results.Metrics.FailedInferences++
-			results.Metrics.ModelInferenceStatus[spec.Name] = "failed"
-			log.Printf("ERROR: %s inference failed: %v", spec.Name, err)
-			// If Core crashed, try to read its logs
-			r.logCoreOutputIfCrashed()
+		// Pre-flight: make sure the test input matches what Core expects
+		if err := r.checkInputSchema(results, spec); err != nil {
+			// Neither size can succeed with a mismatched input
+			for _, size := range sizes {
+				results.Metrics.RecordInference(spec.Name, size, 0, "failed", err.Error())
+			}
+			log.Printf("ERROR: %s pre-flight check failed: %v", spec.Name, err)
+			results.RecordError("inference", spec.Name, "pre-flight check failed: "+err.Error())
+			continue
+		}
+
+		results.Metrics.RecordInferenceSizes(spec.Name, sizes)
+		if !runSmall || !runLarge {
+			log.Printf("   %s: running %s inference only", spec.Name, strings.Join(sizes, ", "))
+		}
+
+		// Small inference test
+		smallDone := make(chan struct{})
+		if runSmall {
+			run(func() {
+				defer close(smallDone)
+				elapsed, ok := r.runSizedInference(results, spec, false)
+				// The small test is the first inference since registration, so it pays any lazy load
+				if ok && r.cfg.MeasureColdStart {
+					r.measureColdStart(results, spec, elapsed)
+				}
+			})
 		} else {
-			results.Metrics.SuccessfulInferences++
-			results.Metrics.ModelInferenceTimes[spec.Name] = elapsed
-			results.Metrics.ModelInferenceStatus[spec.Name] = "success"
-			log.Printf("✅ %s inference succeeded (%dms)", spec.Name, elapsed)
+			close(smallDone)
 		}
 
 		// Large inference test
-		start = time.Now()
-		err = model.RunInference(spec.ID, spec.Name, spec.Type, true, r.cfg.CorePort)
-		elapsed = time.Since(start).Milliseconds()
-		results.Metrics.TotalInferences++
+		if runLarge {
+			run(func() {
+				r.runSizedInference(results, spec, true)
+			})
+		}
 
-		if err != nil {
-			results.Metrics.FailedInferences++
-			results.Metrics.ModelLargeInferenceStatus[spec.Name] = "failed"
-			log.Printf("ERROR: %s large inference failed: %v", spec.Name, err)
-			// If Core crashed, try to read its logs
-			r.logCoreOutputIfCrashed()
-		} else {
-			results.Metrics.SuccessfulInferences++
-			results.Metrics.ModelLargeInferenceTimes[spec.Name] = elapsed
-			results.Metrics.ModelLargeInferenceStatus[spec.Name] = "success"
-			log.Printf("✅ %s large inference succeeded (%dms)", spec.Name, elapsed)
+		// Batched and streamed tests check outputs, which a negative test by design gets none of
+		negative := r.cfg.ExpectedStatusFor(spec.Name) != http.StatusOK
+
+		// Batched inference test (exercises Core's batching path)
+		if r.cfg.BatchSize > 1 && !negative {
+			run(func() {
+				r.runBatchInference(results, spec)
+			})
+		}
+
+		// Streamed inference test (token timing for generation endpoints; needs the small test to pass)
+		// The small test was queued first, so it is already running when this job waits on it
+		if r.cfg.StreamInference && runSmall && !negative {
+			run(func() {
+				<-smallDone
+				if results.Metrics.InferenceStatus(spec.Name, SizeSmall) == "success" {
+					r.runStreamingInference(results, spec)
+				}
+			})
 		}
 	}
+	_ = g.Wait()
 
 	log.Printf("✅ Completed %d/%d inference tests",
 		results.Metrics.SuccessfulInferences, results.Metrics.TotalInferences)
+	if parallelism > 1 {
+		log.Printf("   up to %d at a time, %dms wall-clock", parallelism, time.Since(start).Milliseconds())
+	}
+	if results.Metrics.SkippedInferences > 0 {
+		log.Printf("⏭️  Skipped %d inference tests:", results.Metrics.SkippedInferences)
+		for _, spec := range testModels {
+			if reason, ok := results.Metrics.InferenceSkipReasons[spec.Name]; ok {
+				log.Printf("   - %s: %s", spec.Name, reason)
+			}
+		}
+	}
+	return nil
+}
+
+// runSizedInference runs the small or large inference test for a model and records the result
+// It returns the request latency and whether the test passed (including its latency budget).
+func (r *Runner) runSizedInference(results *Results, spec ModelSpec, large bool) (int64, bool) {
+	size, label := SizeSmall, "inference"
+	if large {
+		size, label = SizeLarge, "large inference"
+	}
+
+	// Use spec.ID (full model spec) for URL, spec.Name (short name) for input generation
+	span := r.startInferenceSpan(spec, size)
+	start := time.Now()
+	responseBytes, err := model.RunInference(spec.ID, spec.Name, spec.Type, large, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(large), r.cfg.InputKey, r.inference)
+	elapsed := time.Since(start).Milliseconds()
+	if responseBytes > 0 {
+		results.Metrics.RecordResponseBytes(spec.Name, size, responseBytes)
+	}
+	expected := r.cfg.ExpectedStatusFor(spec.Name)
+	if expected != http.StatusOK {
+		results.Metrics.RecordResponseStatus(spec.Name, size, expected, model.ResponseStatus(spec.ID, err, r.inference))
+	}
+	span.SetAttributes(attribute.Int64("mlos.inference.latency_ms", elapsed), attribute.Int64("mlos.inference.response_bytes", responseBytes))
+
+	if err != nil {
+		endSpan(span, err)
+		results.Metrics.RecordInference(spec.Name, size, 0, "failed", err.Error())
+		log.Printf("ERROR: %s %s failed: %v", spec.Name, label, err)
+		results.RecordError("inference", spec.Name, fmt.Sprintf("%s failed: %v", label, err))
+		// If Core crashed, try to read its logs
+		r.logCoreOutputIfCrashed()
+		return elapsed, false
+	}
+	if budgetErr := r.checkLatencyBudget(results, spec, large, elapsed); budgetErr != nil {
+		// The request worked but was too slow, which still fails the gate
+		results.Metrics.RecordInference(spec.Name, size, elapsed, "failed", budgetErr.Error())
+		log.Printf("ERROR: %s %s %v", spec.Name, label, budgetErr)
+		results.RecordError("inference", spec.Name, fmt.Sprintf("%s %v", label, budgetErr))
+		endSpan(span, budgetErr)
+		return elapsed, false
+	}
+	endSpan(span, nil)
+	results.Metrics.RecordInference(spec.Name, size, elapsed, "success", "")
+	if expected != http.StatusOK {
+		log.Printf("✅ %s %s got the expected status %d (%dms)", spec.Name, label, expected, elapsed)
+	} else {
+		log.Printf("✅ %s %s succeeded (%dms)", spec.Name, label, elapsed)
+	}
+	return elapsed, true
+}
+
+// largePromptRepeats is how many copies of the prompt make up the large inference input
+const largePromptRepeats = 4
+
+// preparePromptInputs tokenizes --prompt with each NLP model's tokenizer and uses the result as its
+// inference input. Models without a usable tokenizer keep the built-in token IDs.
+func (r *Runner) preparePromptInputs(results *Results, models []ModelSpec) {
+	results.Prompt = r.cfg.Prompt
+	log.Printf("💬 Tokenizing prompt %q", r.cfg.Prompt)
+
+	largePrompt := strings.TrimSpace(strings.Repeat(r.cfg.Prompt+" ", largePromptRepeats))
+	r.inference.PromptInputs = make(map[string]model.PromptInput)
+	for _, spec := range models {
+		if spec.Category != "nlp" {
+			continue
+		}
+		path := r.cfg.TokenizerPath
+		if path == "" {
+			found, err := model.FindTokenizer(spec.ID, r.cfg.AxonCacheDir, r.cfg.ModelFilenames, r.releaseOptions())
+			if err != nil {
+				log.Printf("WARN: %s: %v; using built-in token IDs", spec.Name, err)
+				continue
+			}
+			path = found
+		}
+		tokenizer, err := model.LoadTokenizer(path)
+		if err != nil {
+			log.Printf("WARN: %s: %v; using built-in token IDs", spec.Name, err)
+			continue
+		}
+		small, err := tokenizer.Encode(r.cfg.Prompt)
+		if err != nil {
+			log.Printf("WARN: %s: %v; using built-in token IDs", spec.Name, err)
+			continue
+		}
+		large, err := tokenizer.Encode(largePrompt)
+		if err != nil {
+			large = small
+		}
+
+		r.inference.PromptInputs[spec.Name] = model.PromptInput{Small: small, Large: large}
+		results.PromptTokens[spec.Name] = small
+		log.Printf("   %s: %v", spec.Name, small)
+	}
+}
+
+// checkLatencyBudget records the model's budget for this input size and
+// returns an error if elapsedMs exceeds it (models without a budget always pass)
+func (r *Runner) checkLatencyBudget(results *Results, spec ModelSpec, large bool, elapsedMs int64) error {
+	budget := r.cfg.LatencyBudgetFor(spec.Name, large)
+	if budget <= 0 {
+		return nil
+	}
+	size := SizeSmall
+	if large {
+		size = SizeLarge
+	}
+	results.Metrics.RecordLatencyBudget(spec.Name, size, budget)
+	if elapsedMs > budget {
+		return fmt.Errorf("over latency budget: %dms > %dms", elapsedMs, budget)
+	}
+	return nil
+}
+
+// recordSkip notes that a model's small and large inference tests did not run, and why
+func (r *Runner) recordSkip(results *Results, spec ModelSpec, reason string) {
+	results.Metrics.RecordSkip(spec.Name, reason, 2)
+}
+
+// checkInputSchema validates the generated test input against the schema Core reports for the model
+// Cores that don't expose a schema are not an error
+func (r *Runner) checkInputSchema(results *Results, spec ModelSpec) error {
+	schema, err := model.FetchInputSchema(spec.ID, r.cfg.CoreURL(), r.releaseOptions())
+	if err != nil {
+		log.Printf("WARN: Could not fetch input schema for %s: %v", spec.Name, err)
+		return nil
+	}
+	if schema == nil {
+		return nil
+	}
+	results.ModelInputSchemas[spec.Name] = schema
+	return model.ValidateTestInput(spec.Name, spec.Type, r.cfg.InputKey, schema, r.inference)
+}
+
+// warmInferenceIterations is how many follow-up inferences are averaged for the warm latency
+const warmInferenceIterations = 5
+
+// measureColdStart records coldMs (the first inference after registration) against the
+// average of warmInferenceIterations follow-up inferences of the same input
+func (r *Runner) measureColdStart(results *Results, spec ModelSpec, coldMs int64) {
+	var totalMs int64
+	for i := 0; i < warmInferenceIterations; i++ {
+		start := time.Now()
+		if _, err := model.RunInference(spec.ID, spec.Name, spec.Type, false, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(false), r.cfg.InputKey, r.inference); err != nil {
+			log.Printf("WARN: %s warm inference failed, skipping cold-start measurement: %v", spec.Name, err)
+			return
+		}
+		totalMs += time.Since(start).Milliseconds()
+	}
+
+	warmMs := totalMs / warmInferenceIterations
+	results.Metrics.RecordColdStart(spec.Name, coldMs, warmMs)
+	log.Printf("   %s cold start: %dms, warm: %dms (penalty %dms)", spec.Name, coldMs, warmMs, coldMs-warmMs)
+}
+
+func (r *Runner) runBatchInference(results *Results, spec ModelSpec) {
+	batchSize := r.cfg.BatchSize
+
+	start := time.Now()
+	err := model.RunBatchInference(spec.ID, spec.Name, spec.Type, batchSize, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(true), r.cfg.InputKey, r.inference)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		results.Metrics.RecordInference(spec.Name, SizeBatch, 0, "failed", err.Error())
+		results.Metrics.RecordBatch(spec.Name, batchSize, 0)
+		log.Printf("ERROR: %s batch inference (x%d) failed: %v", spec.Name, batchSize, err)
+		results.RecordError("inference", spec.Name, fmt.Sprintf("batch inference (x%d) failed: %v", batchSize, err))
+		r.logCoreOutputIfCrashed()
+		return
+	}
+
+	throughput := 0.0
+	if elapsed > 0 {
+		throughput = float64(batchSize) / elapsed.Seconds()
+	}
+	results.Metrics.RecordInference(spec.Name, SizeBatch, elapsed.Milliseconds(), "success", "")
+	results.Metrics.RecordBatch(spec.Name, batchSize, throughput)
+	log.Printf("✅ %s batch inference (x%d) succeeded (%dms, %.1f examples/sec)",
+		spec.Name, batchSize, elapsed.Milliseconds(), throughput)
+}
+
+// runStreamingInference requests a streamed response and records time-to-first-token and inter-token latency
+// Models Core answers without streaming are recorded as unsupported rather than failed
+func (r *Runner) runStreamingInference(results *Results, spec ModelSpec) {
+	stats, err := model.RunStreamingInference(spec.ID, spec.Name, spec.Type, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(true), r.cfg.InputKey, r.inference)
+	switch {
+	case errors.Is(err, model.ErrNotStreaming):
+		results.Metrics.RecordStreaming(spec.Name, StreamingResult{Status: "unsupported"})
+		log.Printf("   %s: Core did not stream the response, no token timing", spec.Name)
+	case err != nil:
+		results.Metrics.RecordStreaming(spec.Name, StreamingResult{Status: "failed", Error: err.Error(), Tokens: stats.Tokens})
+		log.Printf("ERROR: %s streaming inference failed: %v", spec.Name, err)
+		results.RecordError("inference", spec.Name, "streaming inference failed: "+err.Error())
+		r.logCoreOutputIfCrashed()
+	default:
+		results.Metrics.RecordStreaming(spec.Name, StreamingResult{
+			Status:             "success",
+			Tokens:             stats.Tokens,
+			TimeToFirstTokenMs: stats.TimeToFirstTokenMs,
+			MeanInterTokenMs:   stats.MeanInterTokenMs,
+			MaxInterTokenMs:    stats.MaxInterTokenMs,
+			TotalMs:            stats.TotalMs,
+		})
+		log.Printf("✅ %s streamed %d tokens (first token %.1fms, %.1fms between tokens)",
+			spec.Name, stats.Tokens, stats.TimeToFirstTokenMs, stats.MeanInterTokenMs)
+	}
+}
+
+// runBatchSweep measures batched throughput at each of BatchSweepSizes for every model
+// that passed the small inference test
+func (r *Runner) runBatchSweep(results *Results) error {
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("📈 Sweeping Batch Sizes %v", r.cfg.BatchSweepSizes)
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	var models []ModelSpec
+	for _, spec := range r.getTestModels() {
+		// Negative-test models "pass" with an error response, which has no throughput to measure
+		if results.Metrics.InferenceStatus(spec.Name, SizeSmall) == "success" && r.cfg.ExpectedStatusFor(spec.Name) == http.StatusOK {
+			models = append(models, spec)
+		}
+	}
+	if len(models) == 0 {
+		return fmt.Errorf("no models passed inference")
+	}
+
+	for _, spec := range models {
+		var points []BatchSweepPoint
+		for _, batchSize := range r.cfg.BatchSweepSizes {
+			point := BatchSweepPoint{BatchSize: batchSize}
+			start := time.Now()
+			err := model.RunBatchInference(spec.ID, spec.Name, spec.Type, batchSize, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(true), r.cfg.InputKey, r.inference)
+			elapsed := time.Since(start)
+			point.TimeMs = elapsed.Milliseconds()
+			if err != nil {
+				point.Error = err.Error()
+				log.Printf("ERROR: %s batch x%d failed: %v", spec.Name, batchSize, err)
+				results.RecordError("batch-sweep", spec.Name, fmt.Sprintf("batch x%d failed: %v", batchSize, err))
+			} else if elapsed > 0 {
+				point.Throughput = float64(batchSize) / elapsed.Seconds()
+				log.Printf("   %s x%d: %.1f examples/sec (%dms)", spec.Name, batchSize, point.Throughput, point.TimeMs)
+			}
+			points = append(points, point)
+		}
+
+		efficiency := batchScalingEfficiency(points)
+		results.Metrics.RecordBatchSweep(spec.Name, points, efficiency)
+		switch {
+		case efficiency == 0:
+			log.Printf("WARN: %s: not enough successful batch sizes to judge scaling", spec.Name)
+		case efficiency < SubLinearScalingThreshold:
+			log.Printf("WARN: %s throughput scales sub-linearly (%.0f%% of linear)", spec.Name, efficiency*100)
+		default:
+			log.Printf("✅ %s throughput scales near-linearly (%.0f%% of linear)", spec.Name, efficiency*100)
+		}
+	}
+	return nil
+}
+
+// batchScalingEfficiency compares throughput at the largest successful batch size with
+// linear scaling from the smallest: 1.0 is linear, lower means batching stops paying off.
+// Returns 0 when fewer than two batch sizes succeeded.
+func batchScalingEfficiency(points []BatchSweepPoint) float64 {
+	var first, last *BatchSweepPoint
+	for i := range points {
+		p := &points[i]
+		if p.Throughput <= 0 {
+			continue
+		}
+		if first == nil || p.BatchSize < first.BatchSize {
+			first = p
+		}
+		if last == nil || p.BatchSize > last.BatchSize {
+			last = p
+		}
+	}
+	if first == nil || first.BatchSize == last.BatchSize {
+		return 0
+	}
+	linear := first.Throughput * float64(last.BatchSize) / float64(first.BatchSize)
+	return last.Throughput / linear
+}
+
+// runFuzzInference sends FuzzIterations randomized inputs to every model that passed inference
+// Only crashes, 5xx responses and timeouts count as failures; they point at robustness bugs in Core
+func (r *Runner) runFuzzInference(results *Results) error {
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("🎲 Fuzzing Inference (%d inputs per model)", r.cfg.FuzzIterations)
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	seed := r.cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	results.Metrics.RecordFuzzSeed(seed)
+	rng := rand.New(rand.NewSource(seed))
+	log.Printf("   Seed: %d", seed)
+
+	for _, spec := range r.getTestModels() {
+		if results.Metrics.InferenceStatus(spec.Name, SizeSmall) != "success" {
+			continue
+		}
+		r.ensureCoreRunning(results, spec.Name)
+
+		sent, failures := 0, 0
+		for i := 0; i < r.cfg.FuzzIterations; i++ {
+			sent++
+			err := model.RunFuzzInference(spec.ID, spec.Name, spec.Type, rng, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(false), r.cfg.InputKey, r.inference)
+			if err == nil {
+				results.Metrics.RecordFuzz(spec.Name, "")
+				continue
+			}
+
+			kind := model.ClassifyFailure(err)
+			if kind == model.FailureRejected || kind == model.FailureOther {
+				results.Metrics.RecordFuzz(spec.Name, "") // Core refused the input cleanly
+				continue
+			}
+			failures++
+			results.Metrics.RecordFuzz(spec.Name, fmt.Sprintf("%s (input #%d): %v", kind, i+1, err))
+			log.Printf("ERROR: %s fuzz input #%d: %s: %v", spec.Name, i+1, kind, err)
+			results.RecordError("fuzz", spec.Name, fmt.Sprintf("input #%d: %s: %v", i+1, kind, err))
+
+			// Every further request would fail the same way
+			if kind == model.FailureConnection {
+				r.logCoreOutputIfCrashed()
+				break
+			}
+		}
+
+		log.Printf("   %s: %d/%d fuzz inputs failed", spec.Name, failures, sent)
+	}
+	return nil
+}
+
+// leakWarmupIterations is how many rounds run before the baseline sample so
+// allocator/cache warmup isn't mistaken for a leak
+const leakWarmupIterations = 3
+
+func (r *Runner) checkMemoryStability(results *Results, process *monitor.Process) error {
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("🧠 Checking Memory Stability (%d iterations)", r.cfg.LeakCheckIterations)
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	// Only models that already passed are useful here
+	var models []ModelSpec
+	for _, spec := range r.getTestModels() {
+		if results.Metrics.InferenceStatus(spec.Name, SizeSmall) == "success" {
+			models = append(models, spec)
+		}
+	}
+	if len(models) == 0 {
+		return fmt.Errorf("no models passed inference")
+	}
+
+	runRound := func() {
+		for _, spec := range models {
+			if _, err := model.RunInference(spec.ID, spec.Name, spec.Type, false, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(false), r.cfg.InputKey, r.inference); err != nil {
+				log.Printf("WARN: %s inference failed during memory check: %v", spec.Name, err)
+			}
+		}
+	}
+
+	for i := 0; i < leakWarmupIterations; i++ {
+		runRound()
+	}
+	before, err := monitor.MonitorProcess(process, 2*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to sample baseline memory: %w", err)
+	}
+
+	for i := 0; i < r.cfg.LeakCheckIterations; i++ {
+		runRound()
+	}
+	after, err := monitor.MonitorProcess(process, 2*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to sample memory after inference: %w", err)
+	}
+
+	growth := after.MemoryMB - before.MemoryMB
+	leak := results.Metrics.RecordMemoryStability(r.cfg.LeakCheckIterations, before.MemoryMB, after.MemoryMB, r.cfg.LeakThresholdMB)
+
+	if leak {
+		log.Printf("WARN: Potential memory leak: RSS grew %.1fMB (%.1fMB -> %.1fMB, threshold %.1fMB)",
+			growth, before.MemoryMB, after.MemoryMB, r.cfg.LeakThresholdMB)
+		results.RecordError("memory-stability", "", fmt.Sprintf("potential memory leak: RSS grew %.1fMB (threshold %.1fMB)", growth, r.cfg.LeakThresholdMB))
+	} else {
+		log.Printf("✅ Memory stable: RSS grew %.1fMB (%.1fMB -> %.1fMB)",
+			growth, before.MemoryMB, after.MemoryMB)
+	}
 	return nil
 }
 
+// recordThermal stores the thermal summary and warns if the machine throttled
+func (r *Runner) recordThermal(results *Results, report hardware.ThermalReport) {
+	results.Throttled = report.Throttled
+	results.ThermalDetails = report.Details
+	results.MaxTemperatureC = report.MaxTempC
+	if report.Samples == 0 {
+		log.Printf("WARN: Thermal monitoring produced no samples on this machine")
+		return
+	}
+	if report.Throttled {
+		log.Printf("WARN: Thermal throttling detected; timings from this run may not be comparable:")
+		for _, detail := range report.Details {
+			log.Printf("   - %s", detail)
+		}
+		return
+	}
+	log.Printf("✅ No thermal throttling detected (%d samples)", report.Samples)
+}
+
+// recordCompression records how many inference requests of each model went out gzipped
+func (r *Runner) recordCompression(results *Results) {
+	counts := r.inference.Compressed.Counts()
+	byName := make(map[string]int, len(counts))
+	for _, spec := range r.getTestModels() {
+		if n := counts[spec.ID]; n > 0 {
+			byName[spec.Name] = n
+		}
+	}
+	results.Metrics.RecordCompression(r.cfg.GzipThresholdBytes, byName)
+	if len(byName) == 0 {
+		log.Printf("   No inference request reached the %d-byte gzip threshold", r.cfg.GzipThresholdBytes)
+	}
+}
+
 func (r *Runner) collectHardwareSpecs(results *Results) error {
 	specs, err := hardware.Collect()
 	if err != nil {
@@ -296,10 +1955,46 @@ func (r *Runner) monitorResources(results *Results, process *monitor.Process, un
 		"CPUPercent":    usage.CPUPercent,
 		"MemoryMB":      usage.MemoryMB,
 		"MemoryPercent": usage.MemoryPercent,
+		"GPUMemoryMB":   usage.GPUMemoryMB,
+		"GPUStatus":     usage.GPUStatus,
+	}
+	switch usage.GPUStatus {
+	case monitor.GPUAttributed:
+		log.Printf("   Core GPU memory (%s): %.0f MB", key, usage.GPUMemoryMB)
+	case monitor.GPUNoProcess:
+		log.Printf("   Core holds no GPU memory (%s); it may be running on CPU or in a container", key)
 	}
 	return nil
 }
 
+// checkRequiredCategories records required categories that had no model tested;
+// callers turn these into a failing exit code via Results.RequirementsError
+func (r *Runner) checkRequiredCategories(results *Results) {
+	counts := CountCategories(results, r.getTestModels())
+	for _, category := range r.cfg.RequireCategories {
+		if count, ok := counts[category]; !ok || count.Tested == 0 {
+			results.UnmetCategories = append(results.UnmetCategories, category)
+			log.Printf("ERROR: Required category %q has no tested models", category)
+			results.RecordError("requirements", "", fmt.Sprintf("required category %q has no tested models", category))
+		}
+	}
+}
+
+// checkGate records the quality gate thresholds and whether the run met them
+func (r *Runner) checkGate(results *Results) {
+	results.PassingModels = PassingModels(results, r.getTestModels())
+	results.SuccessCriteria = r.cfg.SuccessCriteria
+	// The expression is the whole gate, so the thresholds are not recorded as applied
+	if r.cfg.SuccessCriteria == "" {
+		results.MinSuccessRate = r.cfg.MinSuccessRate
+		results.MinPassingModels = r.cfg.MinPassingModels
+	}
+	if err := results.GateError(); err != nil {
+		log.Printf("ERROR: %v", err)
+		results.RecordError("gate", "", err.Error())
+	}
+}
+
 func (r *Runner) calculateSuccessRate(results *Results) float64 {
 	if results.Metrics.TotalInferences == 0 {
 		return 0.0
@@ -308,45 +2003,63 @@ func (r *Runner) calculateSuccessRate(results *Results) float64 {
 }
 
 func (r *Runner) getTestModels() []ModelSpec {
-	// Minimal test: only one small model for smoke testing
-	if r.cfg.MinimalTest {
-		return []ModelSpec{
-			{ID: "hf/distilgpt2@latest", Name: "gpt2", Type: "single", Category: "nlp"},
-		}
-	}
+	return r.models
+}
 
-	// Essential NLP models (default)
-	models := []ModelSpec{
-		{ID: "hf/distilgpt2@latest", Name: "gpt2", Type: "single", Category: "nlp"},
-		{ID: "hf/bert-base-uncased@latest", Name: "bert", Type: "multi", Category: "nlp"},
-	}
+// core returns the managed Core process (nil if Core is not managed by this run or is down)
+func (r *Runner) core() *monitor.Process {
+	r.coreMu.Lock()
+	defer r.coreMu.Unlock()
+	return r.coreProcess
+}
+
+// setCore replaces the managed Core process
+func (r *Runner) setCore(process *monitor.Process) {
+	r.coreMu.Lock()
+	defer r.coreMu.Unlock()
+	r.coreProcess = process
+}
+
+// coreOutputTailLines is how much of Core's stdout and stderr the results keep
+const coreOutputTailLines = 50
 
-	// Additional models if enabled
-	if r.cfg.TestAllModels {
-		models = append(models,
-			ModelSpec{ID: "hf/roberta-base@latest", Name: "roberta", Type: "multi", Category: "nlp"},
-			ModelSpec{ID: "hf/t5-small@latest", Name: "t5", Type: "multi", Category: "nlp"},
-			ModelSpec{ID: "hf/microsoft/resnet-50@latest", Name: "resnet", Type: "single", Category: "vision"},
-			ModelSpec{ID: "hf/timm/vgg16@latest", Name: "vgg", Type: "single", Category: "vision"},
-			ModelSpec{ID: "hf/openai/clip-vit-base-patch32@latest", Name: "clip", Type: "multi", Category: "multimodal"},
-		)
+// recordCoreOutput keeps the end of the managed Core's output logs in the results
+func (r *Runner) recordCoreOutput(results *Results) {
+	process := r.core()
+	if process == nil {
+		return
 	}
+	results.CoreStdoutTail = release.LogTail(process.StdoutLog, coreOutputTailLines)
+	results.CoreStderrTail = release.LogTail(process.StderrLog, coreOutputTailLines)
+}
 
-	return models
+// recordExecutionProvider records which ONNX Runtime execution provider Core ran inference on
+// Call it once inference has run: ONNX Runtime only picks (and logs) providers as it creates sessions.
+func (r *Runner) recordExecutionProvider(results *Results) {
+	var stdoutLog, stderrLog string
+	if process := r.core(); process != nil {
+		stdoutLog, stderrLog = process.StdoutLog, process.StderrLog
+	}
+	results.ExecutionProvider, results.ExecutionProviderSource = release.DetectExecutionProvider(r.cfg.CoreURL(), stdoutLog, stderrLog, r.releaseOptions())
+	if results.ExecutionProvider == "" {
+		log.Printf("ℹ️  Could not tell which ONNX Runtime execution provider Core used")
+		return
+	}
+	log.Printf("🧮 ONNX Runtime execution provider: %s (from %s)", results.ExecutionProvider, results.ExecutionProviderSource)
 }
 
 // logCoreOutputIfCrashed reads and logs Core's stdout/stderr if the process has exited
+// Inference goroutines call it while a restart may be replacing Core, so it only sees the process through r.core().
 func (r *Runner) logCoreOutputIfCrashed() {
-	if r.coreProcess == nil || r.coreProcess.Cmd == nil {
+	process := r.core()
+	if process == nil || process.Cmd == nil {
 		return
 	}
 	
 	// Check if process has exited
-	if r.coreProcess.Cmd.ProcessState != nil && r.coreProcess.Cmd.ProcessState.Exited() {
-		// Try to find log files in the results directory
-		logDir := filepath.Join(r.cfg.OutputDir, "logs")
-		stdoutLog := filepath.Join(logDir, "core-stdout.log")
-		stderrLog := filepath.Join(logDir, "core-stderr.log")
+	if process.Exited() {
+		// Both the native and the Docker path write Core's output to log files
+		stdoutLog, stderrLog := process.StdoutLog, process.StderrLog
 		
 		if stdoutContent, err := os.ReadFile(stdoutLog); err == nil && len(stdoutContent) > 0 {
 			log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
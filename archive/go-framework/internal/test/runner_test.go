@@ -0,0 +1,56 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mlOS-foundation/system-test/internal/config"
+)
+
+func TestCountStepsMatchesDownloads(t *testing.T) {
+	models := []ModelSpec{{Name: "gpt2"}, {Name: "bert"}}
+	tests := []struct {
+		name string
+		cfg  config.Config
+		want int
+	}{
+		{"releases", config.Config{}, 6 + 3},
+		{"skip install", config.Config{SkipInstall: true}, 6},
+		{"external core", config.Config{ExternalCoreURL: "http://localhost:18080"}, 6 + 1},
+		{"core binary", config.Config{CoreBinary: "/src/core/build/mlos_core"}, 6 + 2},
+	}
+	for _, tt := range tests {
+		cfg := tt.cfg
+		r := &Runner{cfg: &cfg, models: models}
+		if got := r.countSteps(); got != tt.want {
+			t.Errorf("%s: countSteps() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFormatFallbackFailsOnlyEnabledSizes(t *testing.T) {
+	cfg := config.Config{
+		MaxParallelInference: 1,
+		InferenceSizes:       map[string][]string{"gpt2": {config.InferenceSizeSmall}},
+	}
+	r := &Runner{cfg: &cfg, models: []ModelSpec{
+		{Name: "gpt2", ID: "hf/openai-community/gpt2@latest", Category: "nlp"},
+		{Name: "bert", ID: "hf/bert-base-uncased@latest", Category: "nlp"},
+	}}
+	results := NewResults("v3.1.9", "v3.2.10-alpha")
+	results.Metrics.RecordModelFormat("gpt2", "pytorch")
+	results.Metrics.RecordModelFormat("bert", "pytorch")
+
+	if err := r.runInferenceTests(results); err != nil {
+		t.Fatal(err)
+	}
+	m := results.Metrics
+	if m.TotalInferences != 3 || m.FailedInferences != 3 {
+		t.Errorf("counted %d inferences (%d failed), want 3 failed: gpt2 small, bert small and large", m.TotalInferences, m.FailedInferences)
+	}
+	if _, ok := m.ModelLargeInferenceStatus["gpt2"]; ok {
+		t.Errorf("gpt2 opted out of large inference but has a large status %q", m.ModelLargeInferenceStatus["gpt2"])
+	}
+	if m.ModelInferenceStatus["gpt2"] != "failed" || m.ModelLargeInferenceStatus["bert"] != "failed" {
+		t.Errorf("statuses: small %v, large %v", m.ModelInferenceStatus, m.ModelLargeInferenceStatus)
+	}
+}
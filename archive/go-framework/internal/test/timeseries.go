@@ -0,0 +1,53 @@
+package test
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/model"
+)
+
+// recordTimeseries sends each passing model its small input TimeseriesIterations times in a row and
+// keeps every request's latency. Percentiles hide the shape over time: a warmup curve, JIT or cache
+// effects settling, or a gradual slowdown that points at a leak.
+func (r *Runner) recordTimeseries(results *Results) error {
+	iterations := r.cfg.TimeseriesIterations
+	if iterations < 1 {
+		return fmt.Errorf("invalid timeseries iterations: %d", iterations)
+	}
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	log.Printf("📉 Recording Inference Timeseries (%d requests per model)", iterations)
+	log.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	for _, spec := range r.getTestModels() {
+		// A negative-test model's error responses say nothing about inference speed
+		if results.Metrics.InferenceStatus(spec.Name, SizeSmall) != "success" || r.cfg.ExpectedStatusFor(spec.Name) != http.StatusOK {
+			continue
+		}
+		r.ensureCoreRunning(results, spec.Name)
+
+		latencies := make([]float64, 0, iterations)
+		failures := 0
+		for i := 0; i < iterations; i++ {
+			start := time.Now()
+			_, err := model.RunInference(spec.ID, spec.Name, spec.Type, false, r.cfg.CoreURL(), r.cfg.InferenceTimeoutFor(false), r.cfg.InputKey, r.inference)
+			if err != nil {
+				if failures == 0 {
+					log.Printf("WARN: %s timeseries request %d failed: %v", spec.Name, i+1, err)
+				}
+				failures++
+				latencies = append(latencies, -1)
+				continue
+			}
+			latencies = append(latencies, float64(time.Since(start).Microseconds())/1000)
+		}
+		results.Metrics.RecordLatencyTimeseries(spec.Name, latencies)
+		if failures > 0 {
+			results.RecordError("timeseries", spec.Name, fmt.Sprintf("%d of %d requests failed", failures, iterations))
+		}
+		log.Printf("   %s: %d requests timed (%d failed)", spec.Name, iterations, failures)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package test
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of the harness's spans
+const tracerName = "github.com/mlOS-foundation/system-test"
+
+// traceFlushTimeout bounds how long the end of a run waits for its spans to be exported
+const traceFlushTimeout = 10 * time.Second
+
+// newTracer returns a tracer exporting to cfg.OTLPEndpoint over OTLP/HTTP (--otlp-endpoint)
+// Without an endpoint, or if the exporter can't be set up, spans go to a no-op tracer and the provider is nil.
+func newTracer(cfg *config.Config) (trace.Tracer, *sdktrace.TracerProvider) {
+	if cfg.OTLPEndpoint == "" {
+		return noop.NewTracerProvider().Tracer(tracerName), nil
+	}
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+	if err != nil {
+		log.Printf("WARN: Failed to set up trace export to %s, not tracing: %v", cfg.OTLPEndpoint, err)
+		return noop.NewTracerProvider().Tracer(tracerName), nil
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "mlos-system-test"),
+			attribute.String("service.version", cfg.Harness.Version),
+		)),
+	)
+	return provider.Tracer(tracerName), provider
+}
+
+// startRunSpan starts the span every stage of this run is a child of
+func (r *Runner) startRunSpan() trace.Span {
+	var span trace.Span
+	r.traceCtx, span = r.tracer.Start(context.Background(), "e2e-run", trace.WithAttributes(
+		attribute.String("mlos.axon.version", r.cfg.AxonVersion),
+		attribute.String("mlos.core.version", r.cfg.CoreVersion),
+	))
+	r.stageCtx = r.traceCtx
+	return span
+}
+
+// endRunSpan ends the run's span with its outcome and exports the run's spans
+// results that never got an EndTime are from a run that aborted in a stage.
+func (r *Runner) endRunSpan(span trace.Span, results *Results) {
+	switch {
+	case results.EndTime.IsZero():
+		span.SetAttributes(attribute.Bool("mlos.success", false))
+		span.SetStatus(codes.Error, "run aborted")
+	default:
+		gateErr := results.GateError()
+		span.SetAttributes(
+			attribute.Bool("mlos.success", gateErr == nil),
+			attribute.Float64("mlos.success_rate", results.SuccessRate),
+			attribute.Int("mlos.passing_models", results.PassingModels),
+		)
+		if gateErr != nil {
+			span.SetStatus(codes.Error, gateErr.Error())
+		}
+	}
+	span.End()
+
+	if r.tracerProvider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+	defer cancel()
+	if err := r.tracerProvider.ForceFlush(ctx); err != nil {
+		log.Printf("WARN: Failed to export traces to %s: %v", r.cfg.OTLPEndpoint, err)
+	}
+}
+
+// startInferenceSpan starts the span of one inference test, a child of the running stage
+func (r *Runner) startInferenceSpan(spec ModelSpec, size string) trace.Span {
+	_, span := r.tracer.Start(r.stageCtx, "inference-"+size, trace.WithAttributes(
+		attribute.String("mlos.model.name", spec.Name),
+		attribute.String("mlos.model.id", spec.ID),
+		attribute.String("mlos.model.category", spec.Category),
+		attribute.String("mlos.inference.size", size),
+	))
+	return span
+}
+
+// endSpan ends a stage or test span, marking it failed with err if there is one
+func endSpan(span trace.Span, err error) {
+	span.SetAttributes(attribute.Bool("mlos.success", err == nil))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
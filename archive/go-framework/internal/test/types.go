@@ -1,7 +1,11 @@
 package test
 
 import (
+	"sync"
 	"time"
+
+	"github.com/mlOS-foundation/system-test/internal/config"
+	"github.com/mlOS-foundation/system-test/internal/model"
 )
 
 // ModelSpec represents a test model specification
@@ -10,37 +14,265 @@ type ModelSpec struct {
 	Name     string // e.g., "gpt2"
 	Type     string // "single" or "multi"
 	Category string // "nlp", "vision", "multimodal"
+	Family   string // e.g., "bert" for BERT and its variants; optional, the report aggregates models sharing one
 }
 
 // Metrics holds all collected metrics
+// The runner writes it only through the Record* methods (see recorder.go), which are safe for concurrent use
 type Metrics struct {
+	mu sync.Mutex
+
 	// Installation metrics
-	AxonDownloadTimeMs int64
-	CoreDownloadTimeMs int64
-	CoreStartupTimeMs  int64
-	ModelsInstalled    int
+	AxonDownloadTimeMs        int64
+	CoreDownloadTimeMs        int64
+	ONNXRuntimeDownloadTimeMs int64
+	DownloadWallTimeMs        int64 // Wall-clock of the concurrent download phase
+	CoreStartupTimeMs         int64
+	ModelsInstalled           int
+
+	// Download sizes and effective bandwidth (component -> bytes / MB/s; see Component* constants)
+	DownloadBytes         map[string]int64
+	DownloadThroughputMBs map[string]float64
+
+	// Installed model files (model_name -> path, SHA256, size)
+	ModelManifest map[string]model.ManifestEntry
+
+	// ONNX metadata checks against --model-expectations (model_name -> mismatches; empty if it matched)
+	ModelMetadataMismatches map[string][]string
+
+	// Format Axon installed each model in (model_name -> model.Format*; anything but onnx is a failed conversion)
+	ModelFormat map[string]string
+
+	// Full cache directory listing per installed model (--verbose only)
+	ModelCacheListing map[string][]model.CacheFile
 
 	// Inference metrics
 	TotalInferences      int
 	SuccessfulInferences int
 	FailedInferences     int
+	SkippedInferences    int               // Small+large tests that never ran
+	InferenceSkipReasons map[string]string // model_name -> why its inference was skipped
+	InferenceParallelism int               // Inference tests run concurrently (1 = serial)
 
 	// Per-model inference metrics
 	ModelInferenceTimes  map[string]int64  // model_name -> time_ms
 	ModelInferenceStatus map[string]string // model_name -> "success" or "failed"
+	ModelInferenceErrors map[string]string // model_name -> error detail (failed models only)
+
+	// Latency budgets applied to this run (model_name -> budget ms; gated models only)
+	ModelInferenceBudgetMs      map[string]int64
+	ModelLargeInferenceBudgetMs map[string]int64
 
 	// Large inference metrics
 	ModelLargeInferenceTimes  map[string]int64
 	ModelLargeInferenceStatus map[string]string
+	ModelLargeInferenceErrors map[string]string
+
+	// Response body sizes of the small and large inference tests (model_name -> bytes; responses that were read)
+	// A tiny body can be a truncated or error output, a huge one a misconfigured model.
+	ModelResponseBytes      map[string]int64
+	ModelLargeResponseBytes map[string]int64
+
+	// Negative tests: the status each model's inference had to get and what it got (model_name -> HTTP status;
+	// only models configured to expect something other than 200, see config.LoadExpectedStatuses)
+	ModelExpectedStatus      map[string]int
+	ModelResponseStatus      map[string]int // Small inference (0: Core never answered)
+	ModelLargeResponseStatus map[string]int
+
+	// Inference sizes each model ran (model_name -> "small" and/or "large"; see config.LoadInferenceSizes)
+	ModelInferenceSizes map[string][]string
+
+	// Cold-start metrics (first inference after registration vs. warm average)
+	ModelColdInferenceTimes map[string]int64 // model_name -> time_ms of the first inference
+	ModelWarmInferenceTimes map[string]int64 // model_name -> mean time_ms of later inferences
+
+	// Batched inference metrics (one request carrying BatchSize examples)
+	BatchSize                 int
+	ModelBatchInferenceTimes  map[string]int64   // model_name -> time_ms for the whole batch
+	ModelBatchInferenceStatus map[string]string  // model_name -> "success" or "failed"
+	ModelBatchInferenceErrors map[string]string  // model_name -> error detail (failed batches only)
+	ModelBatchThroughput      map[string]float64 // model_name -> examples/sec
+
+	// Batch-size sweep (throughput scaling across BatchSweepSizes)
+	ModelBatchSweep   map[string][]BatchSweepPoint // model_name -> one point per batch size
+	ModelBatchScaling map[string]float64           // model_name -> scaling efficiency (1.0 = linear)
+
+	// Streaming inference (token timing of a streamed response)
+	ModelStreaming map[string]StreamingResult // model_name -> token timing, or why streaming failed
+
+	// Request compression (--gzip-requests)
+	GzipThresholdBytes      int            // 0 if request compression was off
+	ModelCompressedRequests map[string]int // model_name -> inference requests sent gzipped
+
+	// Fuzz inference metrics (randomized valid inputs)
+	FuzzSeed          int64             // Seed that reproduces the fuzz inputs
+	ModelFuzzRequests map[string]int    // model_name -> fuzz requests sent
+	ModelFuzzFailures map[string]int    // model_name -> crashes, 5xx responses and timeouts
+	ModelFuzzErrors   map[string]string // model_name -> first fuzz failure
+
+	// Determinism check (--check-determinism: the small input sent twice, outputs compared)
+	DeterminismTolerance    float64             // Relative difference allowed between output numbers (0: identical)
+	ModelDeterminismStatus  map[string]string   // model_name -> "deterministic", "nondeterministic" or "failed"
+	ModelDeterminismDetails map[string][]string // model_name -> where the outputs differed, or why the check failed
+
+	// Rolling inference (--record-timeseries): model_name -> latency of each repeated small inference,
+	// in request order, in ms (-1 for a failed request)
+	ModelLatencyTimeseries map[string][]float64
+
+	// Per-model memory footprint (Core RSS growth across each model's registration)
+	CoreBaselineMemoryMB float64            // RSS before the first registration
+	ModelMemoryMB        map[string]float64 // model_name -> MB added by registering it
+
+	// Memory stability metrics (Core RSS around repeated inference)
+	LeakCheckIterations int
+	MemoryBeforeMB      float64 // Steady-state RSS after warmup
+	MemoryAfterMB       float64
+	MemoryGrowthMB      float64
+	MemoryLeakSuspected bool
 
 	// Registration metrics
-	ModelRegistrationTimes map[string]int64 // model_name -> time_ms
+	ModelRegistrationTimes  map[string]int64  // model_name -> time_ms
+	ModelRegistrationErrors map[string]string // model_name -> why registration failed
+	RegistrationConcurrency int               // Registrations run concurrently (1 = serial)
+
+	// Unregistration teardown (--test-unregister)
+	ModelUnregisterStatus map[string]string // model_name -> "success" or "failed"
+	ModelUnregisterErrors map[string]string // model_name -> why unregistration failed
+
+	// Registration idempotency (--test-reregister)
+	ModelReregisterStatus   map[string]string // model_name -> "success" or "failed"
+	ModelReregisterBehavior map[string]string // model_name -> "no-op" or "already-registered" (how Core answered)
+	ModelReregisterErrors   map[string]string // model_name -> why re-registration failed
+}
+
+// BatchSweepPoint is one batch size of a throughput sweep
+type BatchSweepPoint struct {
+	BatchSize  int
+	TimeMs     int64
+	Throughput float64 // examples/sec (0 if the request failed)
+	Error      string
+}
+
+// StreamingResult is one model's streamed inference test
+type StreamingResult struct {
+	Status             string // "success", "failed" or "unsupported" (Core answered without streaming)
+	Error              string
+	Tokens             int
+	TimeToFirstTokenMs float64
+	MeanInterTokenMs   float64 // Mean gap between consecutive chunks
+	MaxInterTokenMs    float64
+	TotalMs            float64
+}
+
+// CoreRestart records one watchdog restart of a Core that died mid-suite
+type CoreRestart struct {
+	Time        time.Time
+	BeforeModel string // Model about to be tested when Core was found down
+	Reason      string // Why the health check failed
+	Succeeded   bool
+	Error       string // Why the restart failed, if it did
+}
+
+// PipelineRetry is an infrastructure failure that made RunWithRetries run the pipeline again
+type PipelineRetry struct {
+	Time  time.Time
+	Stage string
+	Error string
+}
+
+// Repetition is one run of the pipeline under --repeat
+type Repetition struct {
+	Index    int            // 1-based
+	Error    string         // Why the repetition aborted, in which case it has no Outcomes
+	Outcomes []ModelOutcome // Tested models, in matrix order
+}
+
+// ModelOutcome is whether every inference test of one model passed in one repetition
+type ModelOutcome struct {
+	Model   string
+	Passed  bool
+	Failure string // First failing test and its error, e.g. "large: timeout" (failed models only)
+}
+
+// ModelFlakiness sums up one model's outcomes across repetitions (see Flakiness)
+type ModelFlakiness struct {
+	Model        string
+	Runs         int            // Repetitions that tested the model
+	Failures     int            // Of those, the ones where it failed
+	Score        float64        // Failures / Runs: 0 is stable, 1 consistently broken, anything between flaky
+	FailureModes map[string]int // Failure -> repetitions it happened in
+}
+
+// InjectedFailure is the outcome of one --inject-failures check
+// Status is "success" when the harness and Core handled the failure as expected.
+type InjectedFailure struct {
+	Scenario string // config.Failure* scenario
+	Detail   string // What exactly was injected (e.g. which malformed payload)
+	Expected string
+	Observed string
+	Status   string // "success", "failed" or "skipped"
+}
+
+// RunError is one failure recorded anywhere in the run, for the report's Failures section
+type RunError struct {
+	Stage   string // Timeline stage it happened in, or "restart", "requirements" or "gate"
+	Model   string // Model name, empty for failures not tied to one model
+	Message string
+}
+
+// ReplayResult compares this Core's responses with a recorded session's (see model.ReplayExchange)
+type ReplayResult struct {
+	SessionPath         string
+	RecordedCoreVersion string // Core the session was recorded against
+	Requests            int
+	Matched             int
+	Divergences         []ReplayDivergence
+}
+
+// ReplayDivergence is one replayed request whose response differed from the recorded one
+type ReplayDivergence struct {
+	Model   string // Model name, or the model ID if it isn't in this run's matrix
+	Request int    // Index of the exchange in the session
+	Path    string
+	Details []string
+}
+
+// AutoloadFailure is a model Core logged it could not load while starting up
+type AutoloadFailure struct {
+	Model    string // As named in the log, empty if the line names none
+	Line     string
+	Required bool // The model is in the test matrix, which failed startup
+}
+
+// CoreInstance holds the results of one additional Core instance in an isolation run
+// Models registered on the main instance are registered and tested again here.
+type CoreInstance struct {
+	Index                int
+	URL                  string
+	StartupTimeMs        int64
+	Error                string            // Why the instance could not be started, if it couldn't
+	ModelInferenceTimes  map[string]int64  // model_name -> time_ms
+	ModelInferenceStatus map[string]string // model_name -> "success" or "failed"
+	ModelInferenceErrors map[string]string // model_name -> error message
+}
+
+// Span is one timed pipeline stage, relative to the start of the run
+type Span struct {
+	Name       string
+	StartMs    int64
+	DurationMs int64
+	Failed     bool
 }
 
 // Results holds the complete test results
 type Results struct {
+	errMu sync.Mutex // Guards Errors, which parallel inference tests append to
+
+	SchemaVersion int // ResultsSchemaVersion at the time the results were written
+	Harness       config.HarnessBuild
 	AxonVersion   string
 	CoreVersion   string
+	CoreBinary    string // Locally built Core that ran instead of the CoreVersion release (--core-binary)
 	Duration      time.Duration
 	SuccessRate   float64
 	Metrics       *Metrics
@@ -48,26 +280,150 @@ type Results struct {
 	ResourceUsage map[string]interface{}
 	StartTime     time.Time
 	EndTime       time.Time
+	Timeline      []Span // Pipeline stages in execution order
+	CoreRestarts  []CoreRestart
+	// Failed attempts before the one these results are from (--pipeline-retries)
+	PipelineRetries []PipelineRetry
+	CoreModelList   []string // Model IDs Core listed after registration (nil if not checked)
+
+	// Every repetition of the pipeline and the per-model flakiness across them (--repeat; nil when off)
+	Repetitions []Repetition
+	Flakiness   []ModelFlakiness // Highest score first
+
+	// Core pprof profiles captured under load: ProfileCPU/ProfileHeap -> file (--profile; nil when off)
+	CoreProfiles map[string]string
+
+	// End of Core's output when the run finished, for diagnosing failures (nil if Core wasn't started here)
+	CoreStdoutTail []string
+	CoreStderrTail []string
+
+	// Models Core failed to load at startup (see release.ScanAutoloadFailures)
+	AutoloadFailures []AutoloadFailure
+
+	// Every failure the run hit, in the order it happened (see RecordError)
+	Errors []RunError
+
+	// Failure injection outcomes (--inject-failures; nil when off)
+	InjectedFailures []InjectedFailure
+
+	// Additional Core instances started for isolation tests (instance 0 is the main run)
+	CoreInstances []CoreInstance
+
+	// Thermal state while timings were taken (only with --monitor-thermal)
+	Throttled       bool     // The machine thermally throttled, so timings may be skewed
+	ThermalDetails  []string // What indicated throttling
+	MaxTemperatureC float64  // Hottest reading, -1 if unknown or not monitored
+
+	// Machine speed relative to hardware.ReferenceGFLOPS (--normalize-timings; 0 when not measured)
+	PerformanceIndex float64
+
+	// ONNX Runtime execution provider Core ran inference on, e.g. "CUDA" or "CPU" (empty if it couldn't be told)
+	ExecutionProvider       string
+	ExecutionProviderSource string // Where it was found: release.ProviderSourceCore or ProviderSourceLog
+
+	// Inference API the inference tests exercised: "native" or "openai" (--api-style)
+	APIStyle string
+
+	// Route native inference requests went to, with a {model} placeholder (--inference-path-template; empty for openai)
+	InferencePath string
+
+	// Core readiness check used: "strict" (/health 200 with the expected body) or "lenient" (any HTTP response)
+	HealthCheckMode string
+
+	// Recognized environment variables that were set (see config.RecognizedEnvVars; secrets redacted)
+	Environment map[string]string
+
+	// Model matrix the run tested, so reports can be rebuilt from metrics.json alone
+	Models []ModelSpec
+
+	// Quality gate (--min-success-rate / --min-passing-models); see GateError
+	MinSuccessRate   float64
+	MinPassingModels int
+	PassingModels    int // Models whose every inference test passed
+
+	// Expression that replaces the thresholds above as the gate (--success-criteria; empty: off)
+	SuccessCriteria string
+
+	// Models installed and registered but not inferred because of --install-only-categories
+	InstallOnlyModels []string
+
+	// Recorded session replayed against this Core (--replay; nil when off)
+	Replay *ReplayResult
+
+	// Required categories (--require-categories) with zero tested models
+	UnmetCategories []string
+
+	// Prompt the inference inputs were tokenized from (--prompt; empty if built-in IDs were used)
+	Prompt       string
+	PromptTokens map[string][]int // model_name -> small-input token IDs (models whose tokenizer loaded)
+
+	// Input schemas reported by Core for registered models (model_name -> inputs)
+	ModelInputSchemas map[string][]model.InputSpec
 }
 
 // NewMetrics creates a new Metrics instance
 func NewMetrics() *Metrics {
 	return &Metrics{
-		ModelInferenceTimes:       make(map[string]int64),
-		ModelInferenceStatus:      make(map[string]string),
-		ModelLargeInferenceTimes:  make(map[string]int64),
-		ModelLargeInferenceStatus: make(map[string]string),
-		ModelRegistrationTimes:    make(map[string]int64),
+		DownloadBytes:               make(map[string]int64),
+		DownloadThroughputMBs:       make(map[string]float64),
+		ModelManifest:               make(map[string]model.ManifestEntry),
+		ModelCacheListing:           make(map[string][]model.CacheFile),
+		ModelFormat:                 make(map[string]string),
+		ModelMetadataMismatches:     make(map[string][]string),
+		InferenceSkipReasons:        make(map[string]string),
+		ModelInferenceTimes:         make(map[string]int64),
+		ModelInferenceSizes:         make(map[string][]string),
+		ModelInferenceStatus:        make(map[string]string),
+		ModelInferenceErrors:        make(map[string]string),
+		ModelInferenceBudgetMs:      make(map[string]int64),
+		ModelLargeInferenceBudgetMs: make(map[string]int64),
+		ModelLargeInferenceTimes:    make(map[string]int64),
+		ModelLargeInferenceStatus:   make(map[string]string),
+		ModelLargeInferenceErrors:   make(map[string]string),
+		ModelResponseBytes:          make(map[string]int64),
+		ModelLargeResponseBytes:     make(map[string]int64),
+		ModelExpectedStatus:         make(map[string]int),
+		ModelResponseStatus:         make(map[string]int),
+		ModelLargeResponseStatus:    make(map[string]int),
+		ModelColdInferenceTimes:     make(map[string]int64),
+		ModelWarmInferenceTimes:     make(map[string]int64),
+		ModelBatchInferenceTimes:    make(map[string]int64),
+		ModelBatchInferenceStatus:   make(map[string]string),
+		ModelBatchInferenceErrors:   make(map[string]string),
+		ModelBatchThroughput:        make(map[string]float64),
+		ModelBatchSweep:             make(map[string][]BatchSweepPoint),
+		ModelBatchScaling:           make(map[string]float64),
+		ModelStreaming:              make(map[string]StreamingResult),
+		ModelCompressedRequests:     make(map[string]int),
+		ModelFuzzRequests:           make(map[string]int),
+		ModelFuzzFailures:           make(map[string]int),
+		ModelFuzzErrors:             make(map[string]string),
+		ModelDeterminismStatus:      make(map[string]string),
+		ModelDeterminismDetails:     make(map[string][]string),
+		ModelLatencyTimeseries:      make(map[string][]float64),
+		ModelRegistrationTimes:      make(map[string]int64),
+		ModelRegistrationErrors:     make(map[string]string),
+		ModelUnregisterStatus:       make(map[string]string),
+		ModelUnregisterErrors:       make(map[string]string),
+		ModelReregisterStatus:       make(map[string]string),
+		ModelReregisterBehavior:     make(map[string]string),
+		ModelReregisterErrors:       make(map[string]string),
+		ModelMemoryMB:               make(map[string]float64),
 	}
 }
 
 // NewResults creates a new Results instance
 func NewResults(axonVersion, coreVersion string) *Results {
 	return &Results{
-		AxonVersion:   axonVersion,
-		CoreVersion:   coreVersion,
-		Metrics:       NewMetrics(),
-		HardwareSpecs: make(map[string]string),
-		ResourceUsage: make(map[string]interface{}),
+		SchemaVersion:   ResultsSchemaVersion,
+		MaxTemperatureC: -1,
+		AxonVersion:     axonVersion,
+		CoreVersion:     coreVersion,
+		Metrics:         NewMetrics(),
+		HardwareSpecs:   make(map[string]string),
+		ResourceUsage:   make(map[string]interface{}),
+
+		ModelInputSchemas: make(map[string][]model.InputSpec),
+		PromptTokens:      make(map[string][]int),
 	}
 }